@@ -19,9 +19,14 @@ import (
 const _ = grpc.SupportPackageIsVersion7
 
 const (
-	PolicyEngineService_Generate_FullMethodName   = "/protocols.PolicyEngineService/Generate"
-	PolicyEngineService_GetResults_FullMethodName = "/protocols.PolicyEngineService/GetResults"
-	PolicyEngineService_Configure_FullMethodName  = "/protocols.PolicyEngineService/Configure"
+	PolicyEngineService_Generate_FullMethodName      = "/protocols.PolicyEngineService/Generate"
+	PolicyEngineService_GetResults_FullMethodName    = "/protocols.PolicyEngineService/GetResults"
+	PolicyEngineService_StreamResults_FullMethodName = "/protocols.PolicyEngineService/StreamResults"
+	PolicyEngineService_Configure_FullMethodName     = "/protocols.PolicyEngineService/Configure"
+	PolicyEngineService_Describe_FullMethodName      = "/protocols.PolicyEngineService/Describe"
+	PolicyEngineService_Validate_FullMethodName      = "/protocols.PolicyEngineService/Validate"
+	PolicyEngineService_ListChecks_FullMethodName    = "/protocols.PolicyEngineService/ListChecks"
+	PolicyEngineService_SetHost_FullMethodName       = "/protocols.PolicyEngineService/SetHost"
 )
 
 // PolicyEngineServiceClient is the client API for PolicyEngineService service.
@@ -30,7 +35,12 @@ const (
 type PolicyEngineServiceClient interface {
 	Generate(ctx context.Context, in *GenerateRequest, opts ...grpc.CallOption) (*GenerateResponse, error)
 	GetResults(ctx context.Context, in *GetResultsRequest, opts ...grpc.CallOption) (*GetResultsResponse, error)
+	StreamResults(ctx context.Context, in *StreamResultsRequest, opts ...grpc.CallOption) (PolicyEngineService_StreamResultsClient, error)
 	Configure(ctx context.Context, in *ConfigureRequest, opts ...grpc.CallOption) (*ConfigureResponse, error)
+	Describe(ctx context.Context, in *DescribeRequest, opts ...grpc.CallOption) (*DescribeResponse, error)
+	Validate(ctx context.Context, in *ValidateRequest, opts ...grpc.CallOption) (*ValidateResponse, error)
+	ListChecks(ctx context.Context, in *ListChecksRequest, opts ...grpc.CallOption) (*ListChecksResponse, error)
+	SetHost(ctx context.Context, in *SetHostRequest, opts ...grpc.CallOption) (*SetHostResponse, error)
 }
 
 type policyEngineServiceClient struct {
@@ -59,6 +69,38 @@ func (c *policyEngineServiceClient) GetResults(ctx context.Context, in *GetResul
 	return out, nil
 }
 
+func (c *policyEngineServiceClient) StreamResults(ctx context.Context, in *StreamResultsRequest, opts ...grpc.CallOption) (PolicyEngineService_StreamResultsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &PolicyEngineService_ServiceDesc.Streams[0], PolicyEngineService_StreamResults_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &policyEngineServiceStreamResultsClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type PolicyEngineService_StreamResultsClient interface {
+	Recv() (*StreamResultsResponse, error)
+	grpc.ClientStream
+}
+
+type policyEngineServiceStreamResultsClient struct {
+	grpc.ClientStream
+}
+
+func (x *policyEngineServiceStreamResultsClient) Recv() (*StreamResultsResponse, error) {
+	m := new(StreamResultsResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 func (c *policyEngineServiceClient) Configure(ctx context.Context, in *ConfigureRequest, opts ...grpc.CallOption) (*ConfigureResponse, error) {
 	out := new(ConfigureResponse)
 	err := c.cc.Invoke(ctx, PolicyEngineService_Configure_FullMethodName, in, out, opts...)
@@ -68,13 +110,54 @@ func (c *policyEngineServiceClient) Configure(ctx context.Context, in *Configure
 	return out, nil
 }
 
+func (c *policyEngineServiceClient) Describe(ctx context.Context, in *DescribeRequest, opts ...grpc.CallOption) (*DescribeResponse, error) {
+	out := new(DescribeResponse)
+	err := c.cc.Invoke(ctx, PolicyEngineService_Describe_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *policyEngineServiceClient) Validate(ctx context.Context, in *ValidateRequest, opts ...grpc.CallOption) (*ValidateResponse, error) {
+	out := new(ValidateResponse)
+	err := c.cc.Invoke(ctx, PolicyEngineService_Validate_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *policyEngineServiceClient) ListChecks(ctx context.Context, in *ListChecksRequest, opts ...grpc.CallOption) (*ListChecksResponse, error) {
+	out := new(ListChecksResponse)
+	err := c.cc.Invoke(ctx, PolicyEngineService_ListChecks_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *policyEngineServiceClient) SetHost(ctx context.Context, in *SetHostRequest, opts ...grpc.CallOption) (*SetHostResponse, error) {
+	out := new(SetHostResponse)
+	err := c.cc.Invoke(ctx, PolicyEngineService_SetHost_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // PolicyEngineServiceServer is the server API for PolicyEngineService service.
 // All implementations must embed UnimplementedPolicyEngineServiceServer
 // for forward compatibility
 type PolicyEngineServiceServer interface {
 	Generate(context.Context, *GenerateRequest) (*GenerateResponse, error)
 	GetResults(context.Context, *GetResultsRequest) (*GetResultsResponse, error)
+	StreamResults(*StreamResultsRequest, PolicyEngineService_StreamResultsServer) error
 	Configure(context.Context, *ConfigureRequest) (*ConfigureResponse, error)
+	Describe(context.Context, *DescribeRequest) (*DescribeResponse, error)
+	Validate(context.Context, *ValidateRequest) (*ValidateResponse, error)
+	ListChecks(context.Context, *ListChecksRequest) (*ListChecksResponse, error)
+	SetHost(context.Context, *SetHostRequest) (*SetHostResponse, error)
 	mustEmbedUnimplementedPolicyEngineServiceServer()
 }
 
@@ -88,9 +171,24 @@ func (UnimplementedPolicyEngineServiceServer) Generate(context.Context, *Generat
 func (UnimplementedPolicyEngineServiceServer) GetResults(context.Context, *GetResultsRequest) (*GetResultsResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetResults not implemented")
 }
+func (UnimplementedPolicyEngineServiceServer) StreamResults(*StreamResultsRequest, PolicyEngineService_StreamResultsServer) error {
+	return status.Errorf(codes.Unimplemented, "method StreamResults not implemented")
+}
 func (UnimplementedPolicyEngineServiceServer) Configure(context.Context, *ConfigureRequest) (*ConfigureResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Configure not implemented")
 }
+func (UnimplementedPolicyEngineServiceServer) Describe(context.Context, *DescribeRequest) (*DescribeResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Describe not implemented")
+}
+func (UnimplementedPolicyEngineServiceServer) Validate(context.Context, *ValidateRequest) (*ValidateResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Validate not implemented")
+}
+func (UnimplementedPolicyEngineServiceServer) ListChecks(context.Context, *ListChecksRequest) (*ListChecksResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListChecks not implemented")
+}
+func (UnimplementedPolicyEngineServiceServer) SetHost(context.Context, *SetHostRequest) (*SetHostResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetHost not implemented")
+}
 func (UnimplementedPolicyEngineServiceServer) mustEmbedUnimplementedPolicyEngineServiceServer() {}
 
 // UnsafePolicyEngineServiceServer may be embedded to opt out of forward compatibility for this service.
@@ -140,6 +238,27 @@ func _PolicyEngineService_GetResults_Handler(srv interface{}, ctx context.Contex
 	return interceptor(ctx, in, info, handler)
 }
 
+func _PolicyEngineService_StreamResults_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(StreamResultsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(PolicyEngineServiceServer).StreamResults(m, &policyEngineServiceStreamResultsServer{stream})
+}
+
+type PolicyEngineService_StreamResultsServer interface {
+	Send(*StreamResultsResponse) error
+	grpc.ServerStream
+}
+
+type policyEngineServiceStreamResultsServer struct {
+	grpc.ServerStream
+}
+
+func (x *policyEngineServiceStreamResultsServer) Send(m *StreamResultsResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
 func _PolicyEngineService_Configure_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(ConfigureRequest)
 	if err := dec(in); err != nil {
@@ -158,6 +277,78 @@ func _PolicyEngineService_Configure_Handler(srv interface{}, ctx context.Context
 	return interceptor(ctx, in, info, handler)
 }
 
+func _PolicyEngineService_Describe_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DescribeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PolicyEngineServiceServer).Describe(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PolicyEngineService_Describe_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PolicyEngineServiceServer).Describe(ctx, req.(*DescribeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PolicyEngineService_Validate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ValidateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PolicyEngineServiceServer).Validate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PolicyEngineService_Validate_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PolicyEngineServiceServer).Validate(ctx, req.(*ValidateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PolicyEngineService_ListChecks_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListChecksRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PolicyEngineServiceServer).ListChecks(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PolicyEngineService_ListChecks_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PolicyEngineServiceServer).ListChecks(ctx, req.(*ListChecksRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PolicyEngineService_SetHost_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetHostRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PolicyEngineServiceServer).SetHost(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PolicyEngineService_SetHost_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PolicyEngineServiceServer).SetHost(ctx, req.(*SetHostRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // PolicyEngineService_ServiceDesc is the grpc.ServiceDesc for PolicyEngineService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -177,7 +368,29 @@ var PolicyEngineService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "Configure",
 			Handler:    _PolicyEngineService_Configure_Handler,
 		},
+		{
+			MethodName: "Describe",
+			Handler:    _PolicyEngineService_Describe_Handler,
+		},
+		{
+			MethodName: "Validate",
+			Handler:    _PolicyEngineService_Validate_Handler,
+		},
+		{
+			MethodName: "ListChecks",
+			Handler:    _PolicyEngineService_ListChecks_Handler,
+		},
+		{
+			MethodName: "SetHost",
+			Handler:    _PolicyEngineService_SetHost_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "StreamResults",
+			Handler:       _PolicyEngineService_StreamResults_Handler,
+			ServerStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "policy.proto",
 }
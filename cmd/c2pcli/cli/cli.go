@@ -47,6 +47,7 @@ func New() *cobra.Command {
 		subcommands.NewOSCAL2Policy(logger),
 		subcommands.NewResult2OSCAL(logger),
 		subcommands.NewTools(logger),
+		subcommands.NewPlugin(logger),
 	)
 	command.PersistentFlags().BoolVar(&debug, "debug", false, "Run with debug log level")
 
@@ -22,6 +22,7 @@ import (
 
 	"github.com/oscal-compass/compliance-to-policy-go/v2/framework"
 	"github.com/oscal-compass/compliance-to-policy-go/v2/framework/actions"
+	"github.com/oscal-compass/compliance-to-policy-go/v2/plugin"
 )
 
 // Config returns a populated C2PConfig for the CLI to use.
@@ -32,12 +33,62 @@ func Config(option *Options) (*framework.C2PConfig, error) {
 		c2pConfig.PluginDir = pluginsPath
 		c2pConfig.PluginManifestDir = pluginsPath
 	}
+	for _, dir := range option.PluginPath {
+		c2pConfig.PluginPath = append(c2pConfig.PluginPath, plugin.SearchDir{PluginDir: dir})
+	}
 	// Set logger
 	c2pConfig.Logger = option.logger
+	c2pConfig.RelaunchPlugins = option.AdvancedOptions.RelaunchPlugins
+	c2pConfig.TrustedKeys = option.TrustedKeys
+	c2pConfig.RequireSignedPlugins = option.RequireSigned
+	c2pConfig.EvidenceDir = option.EvidenceDir
+	if len(option.PluginAliases) > 0 {
+		c2pConfig.PluginAliases = make(map[string]plugin.ID, len(option.PluginAliases))
+		for title, id := range option.PluginAliases {
+			c2pConfig.PluginAliases[title] = plugin.ID(id)
+		}
+	}
+	if len(option.CallPolicies) > 0 {
+		c2pConfig.CallPolicies = make(map[plugin.ID]plugin.CallPolicy, len(option.CallPolicies))
+		for id, callPolicy := range option.CallPolicies {
+			c2pConfig.CallPolicies[plugin.ID(id)] = callPolicy
+		}
+	}
+	if len(option.Reattach) > 0 {
+		c2pConfig.Reattach = make(map[plugin.ID]plugin.ReattachConfig, len(option.Reattach))
+		for id, reattach := range option.Reattach {
+			c2pConfig.Reattach[plugin.ID(id)] = reattach
+		}
+	}
 	return c2pConfig, nil
 }
 
-func Context(option *Options, ap *oscalTypes.AssessmentPlan) (*actions.InputContext, error) {
+// PluginSelections returns the plugin configuration selections from the CLI options.
+//
+// Selections for a named plugin instance (e.g. kyverno@prod) are merged over the selections
+// for the base plugin (e.g. kyverno), so shared options only need to be set once.
+func PluginSelections(option *Options) framework.PluginConfig {
+	return func(pluginID plugin.ID) map[string]string {
+		base, hasBase := option.Plugins[pluginID.Base().String()]
+		if pluginID.Instance() == "" {
+			return base
+		}
+		instance, hasInstance := option.Plugins[pluginID.String()]
+		if !hasBase && !hasInstance {
+			return nil
+		}
+		selections := make(map[string]string, len(base)+len(instance))
+		for key, value := range base {
+			selections[key] = value
+		}
+		for key, value := range instance {
+			selections[key] = value
+		}
+		return selections
+	}
+}
+
+func Context(option *Options, ap *oscalTypes.AssessmentPlan, opts ...actions.ContextOption) (*actions.InputContext, error) {
 	if ap.LocalDefinitions == nil || ap.LocalDefinitions.Components == nil || ap.AssessmentAssets.Components == nil {
 		return nil, fmt.Errorf("missing components in assessment plan %q", ap.Metadata.Title)
 	}
@@ -56,7 +107,7 @@ func Context(option *Options, ap *oscalTypes.AssessmentPlan) (*actions.InputCont
 		allComponents = append(allComponents, compAdapter)
 	}
 
-	inputCtx, err := actions.NewContextFromComponents(allComponents)
+	inputCtx, err := actions.NewContextFromComponents(allComponents, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -67,12 +118,14 @@ func Context(option *Options, ap *oscalTypes.AssessmentPlan) (*actions.InputCont
 
 	apSettings := settings.NewAssessmentActivitiesSettings(*ap.LocalDefinitions.Activities)
 	inputCtx.Settings = apSettings
+	inputCtx.Metadata = actions.AddActivityParameterValues(inputCtx.Metadata, *ap.LocalDefinitions.Activities)
 
 	// Set the max concurrency if set by the user
 	if option.AdvancedOptions.MaxConcurrency != 0 {
 		option.logger.Debug("Setting max concurrency", "max", option.AdvancedOptions.MaxPluginTimeout)
 		inputCtx.MaxConcurrency = option.AdvancedOptions.MaxConcurrency
 	}
+	inputCtx.ContinueOnError = option.AdvancedOptions.ContinueOnError
 
 	return inputCtx, nil
 }
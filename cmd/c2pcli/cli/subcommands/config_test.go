@@ -5,6 +5,8 @@ import (
 
 	oscalTypes "github.com/defenseunicorns/go-oscal/src/types/oscal-1-1-3"
 	"github.com/stretchr/testify/require"
+
+	"github.com/oscal-compass/compliance-to-policy-go/v2/plugin"
 )
 
 func TestConfig(t *testing.T) {
@@ -105,3 +107,71 @@ func TestConfig(t *testing.T) {
 	}
 
 }
+
+func TestPluginSelections(t *testing.T) {
+	testOptions := &Options{
+		Plugins: map[string]map[string]string{
+			"kyverno": {
+				"policy-dir":         "policies",
+				"policy-results-dir": "results",
+			},
+			"kyverno@prod": {
+				"policy-results-dir": "prod-results",
+			},
+			"ocm@staging": {
+				"namespace": "staging",
+			},
+		},
+	}
+	selections := PluginSelections(testOptions)
+
+	tests := []struct {
+		name           string
+		id             plugin.ID
+		wantSelections map[string]string
+	}{
+		{
+			name: "Valid/Base",
+			id:   "kyverno",
+			wantSelections: map[string]string{
+				"policy-dir":         "policies",
+				"policy-results-dir": "results",
+			},
+		},
+		{
+			name: "Valid/InstanceOverridesBase",
+			id:   "kyverno@prod",
+			wantSelections: map[string]string{
+				"policy-dir":         "policies",
+				"policy-results-dir": "prod-results",
+			},
+		},
+		{
+			name: "Valid/InstanceWithoutOverrides",
+			id:   "kyverno@staging",
+			wantSelections: map[string]string{
+				"policy-dir":         "policies",
+				"policy-results-dir": "results",
+			},
+		},
+		{
+			name: "Valid/InstanceWithoutBase",
+			id:   "ocm@staging",
+			wantSelections: map[string]string{
+				"namespace": "staging",
+			},
+		},
+		{
+			name: "Valid/NoSelections",
+			id:   "ocm@prod",
+		},
+	}
+	for _, c := range tests {
+		t.Run(c.name, func(t *testing.T) {
+			require.Equal(t, c.wantSelections, selections(c.id))
+		})
+	}
+
+	// Ensure the base selections are not modified
+	require.Equal(t, "results", testOptions.Plugins["kyverno"]["policy-results-dir"])
+}
@@ -23,6 +23,8 @@ import (
 	"github.com/spf13/cobra"
 	"github.com/spf13/pflag"
 	"github.com/spf13/viper"
+
+	"github.com/oscal-compass/compliance-to-policy-go/v2/plugin"
 )
 
 // Options with no defaults
@@ -45,16 +47,26 @@ func (c *ConfigError) Error() string {
 
 // Options define config options when for the CLI commands.
 type Options struct {
-	PluginDir         string                       `yaml:"plugin-dir" mapstructure:"plugin-dir"`
-	Name              string                       `yaml:"name" mapstructure:"name"`
-	Definition        string                       `yaml:"component-definition" mapstructure:"component-definition"`
-	Plan              string                       `yaml:"assessment-plan" mapstructure:"assessment-plan"`
-	Catalog           string                       `yaml:"catalog" mapstructure:"catalog"`
-	AssessmentResults string                       `yaml:"assessment-results" mapstructure:"assessment-results"`
-	Plugins           map[string]map[string]string `yaml:"plugins" mapstructure:"plugins"`
-	Output            string                       `yaml:"out" mapstructure:"out"`
-	Table             bool                         `yaml:"table" mapstructure:"table"`
-	AdvancedOptions   AdvancedOptions              `yaml:"advanced" mapstructure:"advanced"`
+	PluginDir         string                           `yaml:"plugin-dir" mapstructure:"plugin-dir"`
+	PluginPath        []string                         `yaml:"plugin-path" mapstructure:"plugin-path"`
+	Name              string                           `yaml:"name" mapstructure:"name"`
+	Definition        string                           `yaml:"component-definition" mapstructure:"component-definition"`
+	Plan              string                           `yaml:"assessment-plan" mapstructure:"assessment-plan"`
+	Catalog           string                           `yaml:"catalog" mapstructure:"catalog"`
+	AssessmentResults string                           `yaml:"assessment-results" mapstructure:"assessment-results"`
+	Plugins           map[string]map[string]string     `yaml:"plugins" mapstructure:"plugins"`
+	PluginAliases     map[string]string                `yaml:"plugin-aliases" mapstructure:"plugin-aliases"`
+	CallPolicies      map[string]plugin.CallPolicy     `yaml:"call-policies" mapstructure:"call-policies"`
+	Reattach          map[string]plugin.ReattachConfig `yaml:"reattach" mapstructure:"reattach"`
+	TrustedKeys       []string                         `yaml:"trusted-keys" mapstructure:"trusted-keys"`
+	RequireSigned     bool                             `yaml:"require-signed-plugins" mapstructure:"require-signed-plugins"`
+	EvidenceDir       string                           `yaml:"evidence-dir" mapstructure:"evidence-dir"`
+	Output            string                           `yaml:"out" mapstructure:"out"`
+	Table             bool                             `yaml:"table" mapstructure:"table"`
+	DryRun            bool                             `yaml:"dry-run" mapstructure:"dry-run"`
+	ResultsFiles      []string                         `yaml:"results-file" mapstructure:"results-file"`
+	EmbedEvidence     bool                             `yaml:"embed-evidence" mapstructure:"embed-evidence"`
+	AdvancedOptions   AdvancedOptions                  `yaml:"advanced" mapstructure:"advanced"`
 	logger            hclog.Logger
 }
 
@@ -63,6 +75,11 @@ type AdvancedOptions struct {
 	MaxConcurrency int `yaml:"max-concurrency" mapstructure:"max-concurrency"`
 	// MaxPluginTimeout is maximum time a plugin has to complete operations in minutes.
 	MaxPluginTimeout int `yaml:"max-plugin-timeout" mapstructure:"max-plugin-timeout"`
+	// ContinueOnError reports the checks of a failed plugin as errors in the
+	// assessment results instead of failing the command.
+	ContinueOnError bool `yaml:"continue-on-error" mapstructure:"continue-on-error"`
+	// RelaunchPlugins relaunches a crashed plugin once before failing.
+	RelaunchPlugins bool `yaml:"relaunch-plugins" mapstructure:"relaunch-plugins"`
 }
 
 // NewOptions returns an initialized Options struct.
@@ -118,4 +135,5 @@ func BindCommonFlags(fs *pflag.FlagSet) {
 func BindPluginFlags(fs *pflag.FlagSet) {
 	BindCommonFlags(fs)
 	fs.StringP("plugin-dir", "p", "c2p-plugins", "path to plugin directory. Defaults to `c2p-plugins`.")
+	fs.String("evidence-dir", "", "path to the directory where plugins store evidence.")
 }
@@ -18,13 +18,22 @@ package subcommands
 
 import (
 	"context"
-
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"text/tabwriter"
+
+	oscalTypes "github.com/defenseunicorns/go-oscal/src/types/oscal-1-1-3"
 	"github.com/hashicorp/go-hclog"
 	"github.com/spf13/cobra"
 
 	"github.com/oscal-compass/compliance-to-policy-go/v2/framework"
 	"github.com/oscal-compass/compliance-to-policy-go/v2/framework/actions"
+	"github.com/oscal-compass/compliance-to-policy-go/v2/internal/utils"
 	"github.com/oscal-compass/compliance-to-policy-go/v2/plugin"
+	"github.com/oscal-compass/compliance-to-policy-go/v2/policy"
 )
 
 func NewOSCAL2Policy(logger hclog.Logger) *cobra.Command {
@@ -41,14 +50,17 @@ func NewOSCAL2Policy(logger hclog.Logger) *cobra.Command {
 			if err := options.Validate(); err != nil {
 				return err
 			}
-			return runOSCAL2Policy(cmd.Context(), options)
+			return runOSCAL2Policy(cmd.Context(), cmd.OutOrStdout(), options)
 		},
 	}
-	BindPluginFlags(command.Flags())
+	fs := command.Flags()
+	fs.StringP("out", "o", "", "path to output directory for generated policy artifacts")
+	fs.Bool("dry-run", false, "validate the rules with each plugin and report all problems without generating policy artifacts")
+	BindPluginFlags(fs)
 	return command
 }
 
-func runOSCAL2Policy(ctx context.Context, option *Options) error {
+func runOSCAL2Policy(ctx context.Context, out io.Writer, option *Options) error {
 	frameworkConfig, err := Config(option)
 	if err != nil {
 		return err
@@ -59,7 +71,7 @@ func runOSCAL2Policy(ctx context.Context, option *Options) error {
 		return err
 	}
 
-	inputContext, err := Context(option, plan)
+	inputContext, err := Context(option, plan, actions.WithPluginAliases(frameworkConfig.PluginAliases))
 	if err != nil {
 		return err
 	}
@@ -73,10 +85,7 @@ func runOSCAL2Policy(ctx context.Context, option *Options) error {
 		return err
 	}
 
-	var configSelections framework.PluginConfig = func(pluginID plugin.ID) map[string]string {
-		return option.Plugins[pluginID.String()]
-	}
-	launchedPlugins, err := manager.LaunchPolicyPlugins(ctx, foundPlugins, configSelections)
+	launchedPlugins, err := manager.LaunchPolicyPlugins(ctx, foundPlugins, PluginSelections(option))
 	// Defer clean before returning an error to avoid unterminated processes
 	defer manager.Clean()
 	if err != nil {
@@ -86,10 +95,102 @@ func runOSCAL2Policy(ctx context.Context, option *Options) error {
 	pluginCtx, cancel := context.WithTimeout(ctx, maxTimeout(option))
 	defer cancel()
 
-	err = actions.GeneratePolicy(pluginCtx, inputContext, launchedPlugins)
+	if option.DryRun {
+		diagnosticsByProvider, err := actions.ValidatePolicy(pluginCtx, inputContext, launchedPlugins)
+		if err != nil {
+			return err
+		}
+		return printDiagnostics(out, diagnosticsByProvider)
+	}
+
+	artifactsByProvider, err := actions.GeneratePolicy(pluginCtx, inputContext, launchedPlugins)
 	if err != nil {
 		return err
 	}
 
-	return nil
+	for providerId, artifacts := range artifactsByProvider {
+		option.logger.Info(fmt.Sprintf("Plugin %s generated %d artifact(s)", providerId, len(artifacts)))
+		for _, artifact := range artifacts {
+			option.logger.Info(fmt.Sprintf("  %s (%s) sha256:%s", artifact.Path, artifact.MediaType, artifact.Checksum))
+		}
+	}
+
+	if option.Output == "" {
+		return nil
+	}
+	return writeArtifacts(option, *plan, artifactsByProvider)
+}
+
+// printDiagnostics writes the diagnostics of all plugins as a table and
+// returns an error if any problems were found.
+func printDiagnostics(out io.Writer, diagnosticsByProvider map[plugin.ID][]policy.Diagnostic) error {
+	providerIds := make([]plugin.ID, 0, len(diagnosticsByProvider))
+	var count int
+	for providerId, diagnostics := range diagnosticsByProvider {
+		providerIds = append(providerIds, providerId)
+		count += len(diagnostics)
+	}
+	if count == 0 {
+		_, err := fmt.Fprintln(out, "No problems found.")
+		return err
+	}
+	sort.Slice(providerIds, func(i, j int) bool { return providerIds[i] < providerIds[j] })
+
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	if _, err := fmt.Fprintln(w, "PLUGIN\tRULE\tCHECK\tPARAMETER\tTYPE\tMESSAGE"); err != nil {
+		return err
+	}
+	for _, providerId := range providerIds {
+		for _, d := range diagnosticsByProvider[providerId] {
+			if _, err := fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\n", providerId, d.RuleID, d.CheckID, d.Parameter, d.Type, d.Message); err != nil {
+				return err
+			}
+		}
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+	return fmt.Errorf("found %d problem(s) in the rules", count)
+}
+
+// writeArtifacts writes the inline content of all generated artifacts to the output directory
+// and records the artifacts as back-matter resources in a copy of the assessment plan.
+func writeArtifacts(option *Options, plan oscalTypes.AssessmentPlan, artifactsByProvider map[plugin.ID][]policy.Artifact) error {
+	for providerId, artifacts := range artifactsByProvider {
+		for _, artifact := range artifacts {
+			artifactPath := actions.ArtifactPath(providerId, artifact)
+			if !filepath.IsLocal(artifactPath) {
+				return fmt.Errorf("plugin %s returned artifact with invalid path %q", providerId, artifact.Path)
+			}
+			if artifact.Content == nil {
+				option.logger.Warn(fmt.Sprintf("Skipping artifact %s from plugin %s: no inline content", artifact.Path, providerId))
+				continue
+			}
+			dest := filepath.Join(option.Output, artifactPath)
+			if _, err := utils.MakeDir(filepath.Dir(dest)); err != nil {
+				return err
+			}
+			if err := os.WriteFile(dest, artifact.Content, 0600); err != nil {
+				return err
+			}
+		}
+	}
+
+	if resources := actions.ArtifactResources(artifactsByProvider); len(resources) > 0 {
+		var backMatter oscalTypes.BackMatter
+		var allResources []oscalTypes.Resource
+		if plan.BackMatter != nil {
+			backMatter = *plan.BackMatter
+			if backMatter.Resources != nil {
+				allResources = append(allResources, *backMatter.Resources...)
+			}
+		}
+		allResources = append(allResources, resources...)
+		backMatter.Resources = &allResources
+		plan.BackMatter = &backMatter
+	}
+
+	planPath := filepath.Join(option.Output, "assessment-plan.json")
+	option.logger.Info(fmt.Sprintf("Writing assessment plan with generated artifacts to %s.", planPath))
+	return utils.WriteObjToJsonFile(planPath, oscalTypes.OscalModels{AssessmentPlan: &plan})
 }
@@ -0,0 +1,39 @@
+/*
+ Copyright 2025 The OSCAL Compass Authors
+ SPDX-License-Identifier: Apache-2.0
+*/
+
+package subcommands
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/oscal-compass/compliance-to-policy-go/v2/plugin"
+	"github.com/oscal-compass/compliance-to-policy-go/v2/policy"
+)
+
+func TestPrintDiagnostics(t *testing.T) {
+	var out bytes.Buffer
+	require.NoError(t, printDiagnostics(&out, map[plugin.ID][]policy.Diagnostic{"kyverno": nil}))
+	require.Equal(t, "No problems found.\n", out.String())
+
+	out.Reset()
+	diagnosticsByProvider := map[plugin.ID][]policy.Diagnostic{
+		"ocm": {
+			{RuleID: "rule-2", Parameter: "param-1", Type: policy.DiagnosticMissingParameter, Message: "no value selected"},
+		},
+		"kyverno": {
+			{RuleID: "rule-1", CheckID: "check-1", Type: policy.DiagnosticUnknownCheck, Message: "no policy directory"},
+		},
+	}
+	err := printDiagnostics(&out, diagnosticsByProvider)
+	require.EqualError(t, err, "found 2 problem(s) in the rules")
+	want := `PLUGIN   RULE    CHECK    PARAMETER  TYPE               MESSAGE
+kyverno  rule-1  check-1             unknown-check      no policy directory
+ocm      rule-2           param-1    missing-parameter  no value selected
+`
+	require.Equal(t, want, out.String())
+}
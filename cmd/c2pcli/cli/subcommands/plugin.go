@@ -0,0 +1,450 @@
+/*
+ Copyright 2025 The OSCAL Compass Authors
+ SPDX-License-Identifier: Apache-2.0
+*/
+
+package subcommands
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+
+	"github.com/oscal-compass/compliance-to-policy-go/v2/framework"
+	"github.com/oscal-compass/compliance-to-policy-go/v2/plugin"
+)
+
+// pluginBinarySuffix is trimmed from the executable name
+// to derive the plugin ID on install (e.g. kyverno-plugin).
+const pluginBinarySuffix = "-plugin"
+
+// NewPlugin creates a new plugin command that groups plugin management commands
+func NewPlugin(logger hclog.Logger) *cobra.Command {
+	command := &cobra.Command{
+		Use:   "plugin",
+		Short: "Manage installed C2P plugins",
+		Long:  "List, describe, install and verify the plugins in the plugin directories",
+	}
+
+	command.AddCommand(
+		newPluginList(logger),
+		newPluginDescribe(logger),
+		newPluginInstall(logger),
+		newPluginVerify(logger),
+	)
+
+	return command
+}
+
+// bindPluginDirFlags binds flags for commands that search the plugin directories.
+func bindPluginDirFlags(fs *pflag.FlagSet) {
+	fs.StringP(ConfigPath, "c", "c2p-config.yaml", "path to the configuration for the C2P CLI.")
+	fs.StringP("plugin-dir", "p", "c2p-plugins", "path to plugin directory. Defaults to `c2p-plugins`.")
+}
+
+// pluginConfig returns the validated C2PConfig for the plugin directories.
+func pluginConfig(cmd *cobra.Command, options *Options) (*framework.C2PConfig, error) {
+	if err := options.Complete(cmd); err != nil {
+		return nil, err
+	}
+	c2pConfig, err := Config(options)
+	if err != nil {
+		return nil, err
+	}
+	if err := c2pConfig.Validate(); err != nil {
+		return nil, err
+	}
+	return c2pConfig, nil
+}
+
+func newPluginList(logger hclog.Logger) *cobra.Command {
+	options := NewOptions()
+	options.logger = logger
+	var pluginType string
+
+	command := &cobra.Command{
+		Use:   "list",
+		Short: "List the installed plugins.",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			c2pConfig, err := pluginConfig(cmd, options)
+			if err != nil {
+				return err
+			}
+			var findOpts []plugin.FindOption
+			if pluginType != "" {
+				findOpts = append(findOpts, plugin.WithPluginType(pluginType))
+			}
+			manifests, err := plugin.FindPluginsInPath(c2pConfig.SearchPath(), findOpts...)
+			if err != nil {
+				return err
+			}
+			return printPluginList(cmd.OutOrStdout(), manifests)
+		},
+	}
+
+	fs := command.Flags()
+	bindPluginDirFlags(fs)
+	fs.StringVar(&pluginType, "type", "", "only list plugins of the given type (e.g. pvp)")
+
+	return command
+}
+
+func printPluginList(out io.Writer, manifests plugin.Manifests) error {
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	if _, err := fmt.Fprintln(w, "ID\tVERSION\tTYPES\tDESCRIPTION\tEXECUTABLE"); err != nil {
+		return err
+	}
+	for _, id := range sortedIDs(manifests) {
+		manifest := manifests[id]
+		if _, err := fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n", id, manifest.Version, strings.Join(manifest.Types, ","), manifest.Description, manifest.ExecutablePath); err != nil {
+			return err
+		}
+	}
+	return w.Flush()
+}
+
+func newPluginDescribe(logger hclog.Logger) *cobra.Command {
+	options := NewOptions()
+	options.logger = logger
+
+	command := &cobra.Command{
+		Use:   "describe <id>",
+		Short: "Show the manifest metadata and configuration options of a plugin.",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			c2pConfig, err := pluginConfig(cmd, options)
+			if err != nil {
+				return err
+			}
+			id := plugin.ID(args[0])
+			manifests, err := plugin.FindPluginsInPath(c2pConfig.SearchPath(), plugin.WithProviderIds([]plugin.ID{id}))
+			if err != nil {
+				return err
+			}
+			return printPluginDescription(cmd.OutOrStdout(), manifests[id])
+		},
+	}
+
+	bindPluginDirFlags(command.Flags())
+
+	return command
+}
+
+func printPluginDescription(out io.Writer, manifest plugin.Manifest) error {
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	fields := [][2]string{
+		{"ID", manifest.ID.String()},
+		{"Description", manifest.Description},
+		{"Version", manifest.Version},
+		{"Types", strings.Join(manifest.Types, ", ")},
+		{"Executable", manifest.ExecutablePath},
+		{"SHA256", manifest.Checksum},
+	}
+	if callPolicy := formatCallPolicy(manifest.CallPolicy); callPolicy != "" {
+		fields = append(fields, [2]string{"Call Policy", callPolicy})
+	}
+	if manifest.Reattach != nil {
+		fields = append(fields, [2]string{"Reattach", formatReattach(*manifest.Reattach)})
+	}
+	for _, field := range fields {
+		if _, err := fmt.Fprintf(w, "%s:\t%s\n", field[0], field[1]); err != nil {
+			return err
+		}
+	}
+	if len(manifest.Configuration) == 0 {
+		return w.Flush()
+	}
+
+	if _, err := fmt.Fprintln(w, "\nConfiguration:\nNAME\tTYPE\tREQUIRED\tDEFAULT\tDESCRIPTION"); err != nil {
+		return err
+	}
+	for _, option := range manifest.Configuration {
+		optionType := option.Type
+		if optionType == "" {
+			optionType = plugin.OptionTypeString
+		}
+		if option.Type == plugin.OptionTypeEnum {
+			optionType = plugin.OptionType(fmt.Sprintf("%s(%s)", optionType, strings.Join(option.AllowedValues, "|")))
+		}
+		var defaultValue string
+		if option.Default != nil {
+			defaultValue = *option.Default
+			if option.Sensitive {
+				defaultValue = plugin.RedactedValue
+			}
+		}
+		if _, err := fmt.Fprintf(w, "%s\t%s\t%t\t%s\t%s\n", option.Name, optionType, option.Required, defaultValue, option.Description); err != nil {
+			return err
+		}
+	}
+	return w.Flush()
+}
+
+// formatCallPolicy returns the fields set in the call policy.
+func formatCallPolicy(callPolicy plugin.CallPolicy) string {
+	var fields []string
+	if callPolicy.Timeout != "" {
+		fields = append(fields, "timeout="+callPolicy.Timeout)
+	}
+	if callPolicy.MaxAttempts != 0 {
+		fields = append(fields, fmt.Sprintf("max-attempts=%d", callPolicy.MaxAttempts))
+	}
+	if callPolicy.InitialBackoff != "" {
+		fields = append(fields, "initial-backoff="+callPolicy.InitialBackoff)
+	}
+	if callPolicy.MaxBackoff != "" {
+		fields = append(fields, "max-backoff="+callPolicy.MaxBackoff)
+	}
+	if callPolicy.BackoffMultiplier != 0 {
+		fields = append(fields, fmt.Sprintf("backoff-multiplier=%g", callPolicy.BackoffMultiplier))
+	}
+	if len(callPolicy.RetryableCodes) > 0 {
+		fields = append(fields, "retryable-codes="+strings.Join(callPolicy.RetryableCodes, ","))
+	}
+	return strings.Join(fields, " ")
+}
+
+func newPluginInstall(logger hclog.Logger) *cobra.Command {
+	options := NewOptions()
+	options.logger = logger
+	var (
+		id       string
+		metadata plugin.Metadata
+	)
+
+	command := &cobra.Command{
+		Use:   "install <binary>",
+		Short: "Install a plugin executable and generate or update its manifest.",
+		Long: "Install copies the plugin executable to the plugin directory and writes the manifest with the executable checksum.\n" +
+			"An existing manifest is updated and keeps its configuration options. The plugin ID defaults to the executable name\n" +
+			"without the \"-plugin\" suffix.",
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := options.Complete(cmd); err != nil {
+				return err
+			}
+			if options.PluginDir == "" {
+				return &ConfigError{Option: "plugin-dir"}
+			}
+			metadata.ID = plugin.ID(id)
+			manifestPath, err := installPlugin(options.PluginDir, args[0], metadata, cmd.Flags().Changed)
+			if err != nil {
+				return err
+			}
+			options.logger.Info(fmt.Sprintf("Installed plugin manifest %s", manifestPath))
+			if _, err := os.Stat(manifestPath + plugin.SignatureSuffix); err == nil {
+				options.logger.Warn(fmt.Sprintf("The manifest signature %s is no longer valid and must be regenerated", manifestPath+plugin.SignatureSuffix))
+			}
+			return nil
+		},
+	}
+
+	fs := command.Flags()
+	fs.StringP(ConfigPath, "c", "c2p-config.yaml", "path to the configuration for the C2P CLI.")
+	fs.StringP("plugin-dir", "p", "c2p-plugins", "path to plugin directory to install to. Defaults to `c2p-plugins`.")
+	fs.StringVar(&id, "id", "", "plugin ID. Defaults to the executable name without the \"-plugin\" suffix.")
+	fs.StringVar(&metadata.Version, "version", "", "plugin version")
+	fs.StringVar(&metadata.Description, "description", "", "plugin description")
+	fs.StringSliceVar(&metadata.Types, "types", []string{plugin.PVPPluginName}, "plugin types implemented by the plugin")
+
+	return command
+}
+
+// installPlugin copies the executable to the plugin directory and writes the plugin manifest.
+// Metadata fields are only applied to an existing manifest if changed returns true for the flag.
+func installPlugin(pluginDir, executable string, metadata plugin.Metadata, changed func(name string) bool) (string, error) {
+	if metadata.ID == "" {
+		metadata.ID = plugin.ID(strings.TrimSuffix(filepath.Base(executable), pluginBinarySuffix))
+	}
+	if !metadata.ID.Validate() || metadata.ID.Instance() != "" {
+		return "", fmt.Errorf("invalid plugin id %q", metadata.ID)
+	}
+
+	if err := os.MkdirAll(pluginDir, 0750); err != nil {
+		return "", err
+	}
+	executableName := filepath.Base(executable)
+	installedPath := filepath.Join(pluginDir, executableName)
+	if err := copyExecutable(executable, installedPath); err != nil {
+		return "", fmt.Errorf("failed to install plugin executable: %w", err)
+	}
+	checksum, err := plugin.ComputeChecksum(installedPath)
+	if err != nil {
+		return "", err
+	}
+
+	manifestPath := filepath.Join(pluginDir, plugin.ManifestFileName(metadata.ID))
+	manifest := plugin.Manifest{Metadata: metadata}
+	existing, err := os.ReadFile(filepath.Clean(manifestPath))
+	switch {
+	case err == nil:
+		if err := json.Unmarshal(existing, &manifest); err != nil {
+			return "", fmt.Errorf("failed to read existing manifest %s: %w", manifestPath, err)
+		}
+		if manifest.ID != metadata.ID {
+			return "", fmt.Errorf("invalid plugin id %q in manifest %s", manifest.ID, manifestPath)
+		}
+		if changed("version") {
+			manifest.Version = metadata.Version
+		}
+		if changed("description") {
+			manifest.Description = metadata.Description
+		}
+		if changed("types") {
+			manifest.Types = metadata.Types
+		}
+	case !errors.Is(err, os.ErrNotExist):
+		return "", err
+	}
+	manifest.ExecutablePath = executableName
+	manifest.Checksum = checksum
+
+	data, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return "", err
+	}
+	if err := os.WriteFile(manifestPath, append(data, '\n'), 0600); err != nil {
+		return "", err
+	}
+	return manifestPath, nil
+}
+
+// copyExecutable copies the executable to the destination unless it is the same file.
+func copyExecutable(src, dst string) error {
+	srcInfo, err := os.Stat(src)
+	if err != nil {
+		return err
+	}
+	if !srcInfo.Mode().IsRegular() {
+		return fmt.Errorf("%s is not a file", src)
+	}
+	if dstInfo, err := os.Stat(dst); err == nil && os.SameFile(srcInfo, dstInfo) {
+		return nil
+	}
+
+	in, err := os.Open(filepath.Clean(src))
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+	// The plugin must be executable by the C2P CLI.
+	out, err := os.OpenFile(filepath.Clean(dst), os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0700) /* #nosec G302 */
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(out, in); err != nil {
+		_ = out.Close()
+		return err
+	}
+	return out.Close()
+}
+
+func newPluginVerify(logger hclog.Logger) *cobra.Command {
+	options := NewOptions()
+	options.logger = logger
+
+	command := &cobra.Command{
+		Use:   "verify [id...]",
+		Short: "Verify the executable paths, checksums and signatures of installed plugins.",
+		Long:  "Verify all installed plugins or the plugins with the given IDs. Signatures are verified if trusted keys are configured.",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			c2pConfig, err := pluginConfig(cmd, options)
+			if err != nil {
+				return err
+			}
+			verifier, err := c2pConfig.Verifier()
+			if err != nil {
+				return err
+			}
+			ids := make([]plugin.ID, 0, len(args))
+			for _, arg := range args {
+				ids = append(ids, plugin.ID(arg))
+			}
+			if len(ids) == 0 {
+				ids, err = plugin.FindPluginIDs(c2pConfig.SearchPath())
+				if err != nil {
+					return err
+				}
+				if len(ids) == 0 {
+					return plugin.ErrPluginsNotFound
+				}
+			}
+			return verifyPlugins(cmd.OutOrStdout(), c2pConfig.SearchPath(), verifier, ids)
+		},
+	}
+
+	bindPluginDirFlags(command.Flags())
+
+	return command
+}
+
+// formatReattach returns the address of an attached plugin for display.
+func formatReattach(reattach plugin.ReattachConfig) string {
+	address := reattach.Address
+	if reattach.File != "" {
+		address = "file " + reattach.File
+	} else if reattach.Network != "" {
+		address = reattach.Network + " " + address
+	}
+	if reattach.TLS != nil {
+		address += " (mTLS)"
+	}
+	return address
+}
+
+// verifyPlugins prints the verification result for each plugin and returns
+// an error if any plugin failed verification.
+func verifyPlugins(out io.Writer, searchPath []plugin.SearchDir, verifier *plugin.Verifier, ids []plugin.ID) error {
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	if _, err := fmt.Fprintln(w, "ID\tSTATUS\tDETAILS"); err != nil {
+		return err
+	}
+	var failed int
+	for _, id := range ids {
+		status, details := "OK", ""
+		manifests, err := plugin.FindPluginsInPath(searchPath, plugin.WithProviderIds([]plugin.ID{id}), plugin.WithVerifier(verifier))
+		if err == nil {
+			manifest := manifests[id]
+			if manifest.Reattach != nil {
+				details = formatReattach(*manifest.Reattach)
+			} else {
+				details = manifest.ExecutablePath
+				err = manifest.VerifyChecksum()
+			}
+		}
+		if err != nil {
+			failed++
+			status, details = "FAILED", strings.ReplaceAll(err.Error(), "\n", "; ")
+		}
+		if _, err := fmt.Fprintf(w, "%s\t%s\t%s\n", id, status, details); err != nil {
+			return err
+		}
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d of %d plugins failed verification", failed, len(ids))
+	}
+	return nil
+}
+
+func sortedIDs(manifests plugin.Manifests) []plugin.ID {
+	ids := make([]plugin.ID, 0, len(manifests))
+	for id := range manifests {
+		ids = append(ids, id)
+	}
+	slices.Sort(ids)
+	return ids
+}
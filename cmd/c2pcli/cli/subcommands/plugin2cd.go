@@ -0,0 +1,113 @@
+/*
+ Copyright 2025 The OSCAL Compass Authors
+ SPDX-License-Identifier: Apache-2.0
+*/
+
+package subcommands
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/defenseunicorns/go-oscal/src/pkg/uuid"
+	oscalTypes "github.com/defenseunicorns/go-oscal/src/types/oscal-1-1-3"
+	"github.com/hashicorp/go-hclog"
+	"github.com/oscal-compass/oscal-sdk-go/models"
+	"github.com/oscal-compass/oscal-sdk-go/validation"
+	"github.com/spf13/cobra"
+
+	"github.com/oscal-compass/compliance-to-policy-go/v2/framework"
+	"github.com/oscal-compass/compliance-to-policy-go/v2/framework/actions"
+	"github.com/oscal-compass/compliance-to-policy-go/v2/internal/utils"
+	"github.com/oscal-compass/compliance-to-policy-go/v2/plugin"
+	"github.com/oscal-compass/compliance-to-policy-go/v2/policy"
+)
+
+// NewPlugin2CD creates a new command that writes a component definition with
+// a validation component for the checks of a plugin.
+func NewPlugin2CD(logger hclog.Logger) *cobra.Command {
+	options := NewOptions()
+	options.logger = logger
+	var title string
+
+	command := &cobra.Command{
+		Use:   "plugin2cd <id>",
+		Short: "Create an OSCAL Component Definition with a validation component for the checks of a plugin.",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			c2pConfig, err := pluginConfig(cmd, options)
+			if err != nil {
+				return err
+			}
+			id := plugin.ID(args[0])
+			if title == "" {
+				title = id.String()
+			}
+			return runPlugin2CD(cmd.Context(), options, c2pConfig, id, title)
+		},
+	}
+
+	fs := command.Flags()
+	bindPluginDirFlags(fs)
+	fs.StringP("out", "o", "./component-definition.json", "path to output OSCAL Component Definition")
+	fs.StringVar(&title, "title", "", "title of the validation component. Defaults to the plugin ID.")
+
+	return command
+}
+
+func runPlugin2CD(ctx context.Context, option *Options, c2pConfig *framework.C2PConfig, id plugin.ID, title string) error {
+	manager, err := framework.NewPluginManager(c2pConfig)
+	if err != nil {
+		return err
+	}
+	foundPlugins, err := manager.FindRequestedPlugins([]plugin.ID{id})
+	if err != nil {
+		return err
+	}
+	launchedPlugins, err := manager.LaunchPolicyPlugins(ctx, foundPlugins, PluginSelections(option))
+	// Defer clean before returning an error to avoid unterminated processes
+	defer manager.Clean()
+	if err != nil {
+		return err
+	}
+
+	pluginCtx, cancel := context.WithTimeout(ctx, maxTimeout(option))
+	defer cancel()
+
+	lister, ok := launchedPlugins[id].(policy.CheckLister)
+	if !ok {
+		return fmt.Errorf("plugin %s does not support listing checks", id)
+	}
+	checks, err := lister.ListChecks(pluginCtx)
+	if err != nil {
+		if errors.Is(err, plugin.ErrNotImplemented) {
+			return fmt.Errorf("plugin %s does not support listing checks", id)
+		}
+		return fmt.Errorf("plugin %s: %w", id, err)
+	}
+	option.logger.Info(fmt.Sprintf("Plugin %s listed %d rule(s)", id, len(checks)))
+
+	component := actions.ValidationComponent(title, checks)
+	metadata := models.NewSampleMetadata()
+	metadata.Title = fmt.Sprintf("Component Definition for %s", title)
+	compDef := oscalTypes.ComponentDefinition{
+		UUID:       uuid.NewUUID(),
+		Metadata:   metadata,
+		Components: &[]oscalTypes.DefinedComponent{component},
+	}
+
+	validator := validation.NewSchemaValidator()
+	oscalModels := oscalTypes.OscalModels{
+		ComponentDefinition: &compDef,
+	}
+	if err := validator.Validate(oscalModels); err != nil {
+		return fmt.Errorf("validation error: %w", err)
+	}
+
+	option.logger.Info(fmt.Sprintf("Writing component definition to %s", option.Output))
+	if err := utils.WriteObjToJsonFile(option.Output, oscalModels); err != nil {
+		return fmt.Errorf("error writing component definition to file: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,101 @@
+/*
+ Copyright 2025 The OSCAL Compass Authors
+ SPDX-License-Identifier: Apache-2.0
+*/
+
+package subcommands
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/stretchr/testify/require"
+
+	"github.com/oscal-compass/compliance-to-policy-go/v2/plugin"
+)
+
+func TestPluginCommands(t *testing.T) {
+	pluginDir := filepath.Join(t.TempDir(), "plugins")
+	binary := filepath.Join(t.TempDir(), "testplugin-plugin")
+	require.NoError(t, os.WriteFile(binary, []byte("#!/bin/sh\n"), 0700)) // #nosec G306
+
+	runPlugin := func(args ...string) (string, error) {
+		command := NewPlugin(hclog.NewNullLogger())
+		var out bytes.Buffer
+		command.SetOut(&out)
+		command.SetErr(&out)
+		command.SetArgs(append(args, "--plugin-dir", pluginDir))
+		err := command.Execute()
+		return out.String(), err
+	}
+
+	_, err := runPlugin("install", binary, "--version", "0.0.1", "--description", "My test plugin")
+	require.NoError(t, err)
+	manifestPath := filepath.Join(pluginDir, "c2p-testplugin-manifest.json")
+	manifest := readTestManifest(t, manifestPath)
+	checksum, err := plugin.ComputeChecksum(binary)
+	require.NoError(t, err)
+	require.Equal(t, plugin.Metadata{
+		ID:          "testplugin",
+		Description: "My test plugin",
+		Version:     "0.0.1",
+		Types:       []string{"pvp"},
+	}, manifest.Metadata)
+	require.Equal(t, "testplugin-plugin", manifest.ExecutablePath)
+	require.Equal(t, checksum, manifest.Checksum)
+
+	// Updating the manifest keeps the configuration options
+	defaultValue := "."
+	manifest.Configuration = []plugin.ConfigurationOption{
+		{Name: "output-dir", Description: "The output directory", Default: &defaultValue},
+	}
+	writeTestManifest(t, manifestPath, manifest)
+	require.NoError(t, os.WriteFile(binary, []byte("#!/bin/sh\nexit 0\n"), 0700)) // #nosec G306
+	_, err = runPlugin("install", binary, "--version", "0.0.2")
+	require.NoError(t, err)
+	updated := readTestManifest(t, manifestPath)
+	require.Equal(t, "0.0.2", updated.Version)
+	require.Equal(t, "My test plugin", updated.Description)
+	require.Equal(t, manifest.Configuration, updated.Configuration)
+	require.NotEqual(t, checksum, updated.Checksum)
+
+	out, err := runPlugin("list")
+	require.NoError(t, err)
+	require.Contains(t, out, "testplugin  0.0.2    pvp    My test plugin")
+
+	out, err = runPlugin("describe", "testplugin")
+	require.NoError(t, err)
+	require.Contains(t, out, "SHA256:       "+updated.Checksum)
+	require.Contains(t, out, "output-dir  string  false     .        The output directory")
+
+	out, err = runPlugin("verify")
+	require.NoError(t, err)
+	require.Contains(t, out, "testplugin  OK")
+
+	installedPath := filepath.Join(pluginDir, "testplugin-plugin")
+	require.NoError(t, os.WriteFile(installedPath, []byte("replaced"), 0700)) // #nosec G306
+	out, err = runPlugin("verify", "testplugin")
+	require.EqualError(t, err, "1 of 1 plugins failed verification")
+	require.Contains(t, out, "testplugin  FAILED  plugin executable does not match the manifest checksum")
+
+	_, err = runPlugin("install", binary, "--id", "Test Plugin")
+	require.EqualError(t, err, "invalid plugin id \"Test Plugin\"")
+}
+
+func readTestManifest(t *testing.T, path string) plugin.Manifest {
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	var manifest plugin.Manifest
+	require.NoError(t, json.Unmarshal(data, &manifest))
+	return manifest
+}
+
+func writeTestManifest(t *testing.T, path string, manifest plugin.Manifest) {
+	data, err := json.Marshal(manifest)
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(path, data, 0600))
+}
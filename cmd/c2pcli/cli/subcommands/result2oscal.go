@@ -19,6 +19,7 @@ package subcommands
 import (
 	"context"
 	"fmt"
+	"strings"
 
 	oscalTypes "github.com/defenseunicorns/go-oscal/src/types/oscal-1-1-3"
 	"github.com/hashicorp/go-hclog"
@@ -29,6 +30,7 @@ import (
 	"github.com/oscal-compass/compliance-to-policy-go/v2/framework/actions"
 	"github.com/oscal-compass/compliance-to-policy-go/v2/internal/utils"
 	"github.com/oscal-compass/compliance-to-policy-go/v2/plugin"
+	"github.com/oscal-compass/compliance-to-policy-go/v2/policy"
 )
 
 func NewResult2OSCAL(logger hclog.Logger) *cobra.Command {
@@ -51,6 +53,9 @@ func NewResult2OSCAL(logger hclog.Logger) *cobra.Command {
 
 	fs := command.Flags()
 	fs.StringP("out", "o", "./assessment-results.json", "path to output OSCAL Assessment Results")
+	fs.StringSlice("results-file", nil, "path to saved policy results in JSON or YAML to report instead of launching plugins, "+
+		"optionally prefixed with the id of the plugin instance that produced them, e.g. kyverno@prod=results.json. Can be set multiple times.")
+	fs.Bool("embed-evidence", false, "embed the evidence files stored in --evidence-dir in the assessment results back-matter.")
 	BindPluginFlags(fs)
 
 	return command
@@ -66,39 +71,29 @@ func runResult2Policy(ctx context.Context, option *Options) error {
 	if err != nil {
 		return err
 	}
-	inputContext, err := Context(option, plan)
+	inputContext, err := Context(option, plan, actions.WithPluginAliases(frameworkConfig.PluginAliases))
 	if err != nil {
 		return err
 	}
 
-	manager, err := framework.NewPluginManager(frameworkConfig)
-	if err != nil {
-		return err
+	var reportOpts []actions.ReportOption
+	if option.EmbedEvidence {
+		if option.EvidenceDir == "" {
+			return fmt.Errorf("--embed-evidence requires --evidence-dir")
+		}
+		reportOpts = append(reportOpts, actions.WithEvidenceDir(option.EvidenceDir))
 	}
-	foundPlugins, err := manager.FindRequestedPlugins(inputContext.RequestedProviders())
-	if err != nil {
-		return err
-	}
-
-	var configSelections framework.PluginConfig = func(pluginID plugin.ID) map[string]string {
-		return option.Plugins[pluginID.String()]
-	}
-	launchedPlugins, err := manager.LaunchPolicyPlugins(ctx, foundPlugins, configSelections)
-	// Defer clean before returning an error to avoid unterminated processes
-	defer manager.Clean()
-	if err != nil {
-		return err
+	reporter := actions.NewReporter(inputContext, href, *plan, reportOpts...)
+	if len(option.ResultsFiles) > 0 {
+		err = addResultsFromFiles(ctx, reporter, inputContext, option)
+	} else {
+		err = addResultsFromPlugins(ctx, reporter, inputContext, frameworkConfig, option)
 	}
-
-	pluginCtx, cancel := context.WithTimeout(ctx, maxTimeout(option))
-	defer cancel()
-
-	results, err := actions.AggregateResults(pluginCtx, inputContext, launchedPlugins)
 	if err != nil {
 		return err
 	}
 
-	assessmentResults, err := actions.Report(ctx, inputContext, href, *plan, results)
+	assessmentResults, err := reporter.AssessmentResults()
 	if err != nil {
 		return err
 	}
@@ -121,3 +116,65 @@ func runResult2Policy(ctx context.Context, option *Options) error {
 	}
 	return nil
 }
+
+// addResultsFromPlugins launches the requested plugins and streams their results into the report.
+func addResultsFromPlugins(ctx context.Context, reporter *actions.Reporter, inputContext *actions.InputContext, frameworkConfig *framework.C2PConfig, option *Options) error {
+	manager, err := framework.NewPluginManager(frameworkConfig)
+	if err != nil {
+		return err
+	}
+	foundPlugins, err := manager.FindRequestedPlugins(inputContext.RequestedProviders())
+	if err != nil {
+		return err
+	}
+
+	launchedPlugins, err := manager.LaunchPolicyPlugins(ctx, foundPlugins, PluginSelections(option))
+	// Defer clean before returning an error to avoid unterminated processes
+	defer manager.Clean()
+	if err != nil {
+		return err
+	}
+
+	pluginCtx, cancel := context.WithTimeout(ctx, maxTimeout(option))
+	defer cancel()
+
+	// Stream results into the report as they are received to avoid
+	// holding every provider result in memory at once.
+	return actions.StreamResults(pluginCtx, inputContext, launchedPlugins, func(_ plugin.ID, result policy.PVPResult) error {
+		return reporter.AddResult(ctx, result)
+	})
+}
+
+// addResultsFromFiles adds the saved results of each results file to the report
+// without launching any plugins.
+func addResultsFromFiles(ctx context.Context, reporter *actions.Reporter, inputContext *actions.InputContext, option *Options) error {
+	var instances []string
+	for _, id := range inputContext.RequestedProviders() {
+		if id.Instance() != "" {
+			instances = append(instances, id.String())
+		}
+	}
+	for _, value := range option.ResultsFiles {
+		resultsFile, err := actions.ParseResultsFile(value)
+		if err != nil {
+			return err
+		}
+		if resultsFile.PluginID != "" {
+			if _, err := inputContext.ProviderTitle(resultsFile.PluginID); err != nil {
+				return fmt.Errorf("results file %s: %w", resultsFile.Path, err)
+			}
+		} else if len(instances) > 0 {
+			option.logger.Warn(fmt.Sprintf("Results file %s is not attributed to one of the plugin instances %s, observations without a %s property are merged across instances",
+				resultsFile.Path, strings.Join(instances, ", "), actions.PluginInstanceProp))
+		}
+		result, err := resultsFile.Load()
+		if err != nil {
+			return err
+		}
+		option.logger.Info(fmt.Sprintf("Adding %d observation(s) from %s", len(result.ObservationsByCheck), resultsFile.Path))
+		if err := reporter.AddResult(ctx, result); err != nil {
+			return fmt.Errorf("error adding results from %s: %w", resultsFile.Path, err)
+		}
+	}
+	return nil
+}
@@ -20,6 +20,7 @@ func NewTools(logger hclog.Logger) *cobra.Command {
 
 	command.AddCommand(
 		NewCD2AP(logger),
+		NewPlugin2CD(logger),
 	)
 
 	return command
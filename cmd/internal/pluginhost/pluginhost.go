@@ -0,0 +1,106 @@
+/*
+ Copyright 2025 The OSCAL Compass Authors
+ SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package pluginhost holds the use of the host services shared by the
+// bundled plugins. The host is optional, so a nil policy.Host is valid
+// for all functions.
+package pluginhost
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/hashicorp/go-hclog"
+
+	"github.com/oscal-compass/compliance-to-policy-go/v2/plugin"
+	"github.com/oscal-compass/compliance-to-policy-go/v2/policy"
+)
+
+// ReportProgress sends the progress message to the host if available.
+// It returns an error if the call was cancelled. Other errors are logged
+// because progress is only informational.
+func ReportProgress(ctx context.Context, host policy.Host, logger hclog.Logger, message string) error {
+	if host == nil {
+		return nil
+	}
+	if err := host.ReportProgress(ctx, policy.ProgressEvent{Message: message}); err != nil {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		logger.Debug(fmt.Sprintf("Failed to report progress: %v", err))
+	}
+	return nil
+}
+
+// ResolvePaths resolves the non-empty paths relative to the host
+// working directory. Paths are unchanged without a host.
+func ResolvePaths(ctx context.Context, host policy.Host, paths ...*string) error {
+	if host == nil {
+		return nil
+	}
+	for _, path := range paths {
+		if *path == "" {
+			continue
+		}
+		resolved, err := host.ResolvePath(ctx, *path)
+		if err != nil {
+			return fmt.Errorf("failed to resolve path %q: %w", *path, err)
+		}
+		*path = resolved
+	}
+	return nil
+}
+
+// WorkDir returns the tempDir or, if not set, a new temporary directory
+// named with the pattern that is removed by the returned cleanup function.
+func WorkDir(tempDir, pattern string, logger hclog.Logger) (string, func(), error) {
+	if tempDir != "" {
+		return tempDir, func() {}, nil
+	}
+	dir, err := os.MkdirTemp("", pattern)
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to create temporary directory: %w", err)
+	}
+	return dir, func() {
+		if err := os.RemoveAll(dir); err != nil {
+			logger.Debug(fmt.Sprintf("Failed to remove %s: %v", dir, err))
+		}
+	}, nil
+}
+
+// StoreEvidence stores the evidence of the observations with the host and
+// replaces it with links to the stored evidence. Evidence is kept in the
+// observation if the host cannot store it, e.g. without an evidence directory.
+// Evidence above plugin.MaxEvidenceSize is dropped, and so is kept evidence
+// once its total size exceeds the limit.
+func StoreEvidence(ctx context.Context, host policy.Host, logger hclog.Logger, result *policy.PVPResult) {
+	var keptSize int
+	for i := range result.ObservationsByCheck {
+		observation := &result.ObservationsByCheck[i]
+		var kept []policy.Evidence
+		for _, evidence := range observation.Evidence {
+			if len(evidence.Content) > plugin.MaxEvidenceSize {
+				logger.Warn(fmt.Sprintf("Dropping evidence %s of %d bytes, the limit is %d bytes", evidence.Name, len(evidence.Content), plugin.MaxEvidenceSize))
+				continue
+			}
+			if host != nil {
+				link, err := host.StoreEvidence(ctx, evidence)
+				if err == nil {
+					observation.RelevantEvidences = append(observation.RelevantEvidences, link)
+					continue
+				}
+				logger.Debug(fmt.Sprintf("Keeping evidence %s in the results: %v", evidence.Name, err))
+			}
+			if keptSize+len(evidence.Content) > plugin.MaxEvidenceSize {
+				logger.Warn(fmt.Sprintf("Dropping evidence %s, the evidence in the results exceeds %d bytes", evidence.Name, plugin.MaxEvidenceSize))
+				continue
+			}
+			keptSize += len(evidence.Content)
+			kept = append(kept, evidence)
+		}
+		observation.Evidence = kept
+	}
+}
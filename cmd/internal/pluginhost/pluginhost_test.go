@@ -0,0 +1,117 @@
+/*
+ Copyright 2025 The OSCAL Compass Authors
+ SPDX-License-Identifier: Apache-2.0
+*/
+
+package pluginhost
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/stretchr/testify/require"
+
+	"github.com/oscal-compass/compliance-to-policy-go/v2/plugin"
+	"github.com/oscal-compass/compliance-to-policy-go/v2/policy"
+	"github.com/oscal-compass/compliance-to-policy-go/v2/policy/policytest"
+)
+
+func TestReportProgress(t *testing.T) {
+	require.NoError(t, ReportProgress(context.Background(), nil, hclog.NewNullLogger(), "Collected results"))
+
+	host := &policytest.RecordingHost{}
+	require.NoError(t, ReportProgress(context.Background(), host, hclog.NewNullLogger(), "Collected results"))
+	require.Equal(t, []policy.ProgressEvent{{Message: "Collected results"}}, host.Progress)
+
+	// Only cancellation is returned, other errors are logged
+	require.NoError(t, ReportProgress(context.Background(), &failingHost{}, hclog.NewNullLogger(), "Collected results"))
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	require.ErrorIs(t, ReportProgress(ctx, &failingHost{}, hclog.NewNullLogger(), "Collected results"), context.Canceled)
+}
+
+func TestResolvePaths(t *testing.T) {
+	policyDir, outputDir := "policies", ""
+	require.NoError(t, ResolvePaths(context.Background(), nil, &policyDir))
+	require.Equal(t, "policies", policyDir)
+
+	require.NoError(t, ResolvePaths(context.Background(), &prefixHost{}, &policyDir, &outputDir))
+	require.Equal(t, "/work/policies", policyDir)
+	require.Empty(t, outputDir)
+
+	err := ResolvePaths(context.Background(), &failingHost{}, &policyDir)
+	require.EqualError(t, err, "failed to resolve path \"/work/policies\": host failed")
+}
+
+func TestWorkDir(t *testing.T) {
+	tempDir := t.TempDir()
+	dir, cleanup, err := WorkDir(tempDir, "c2p-test-", hclog.NewNullLogger())
+	require.NoError(t, err)
+	cleanup()
+	require.Equal(t, tempDir, dir)
+	require.DirExists(t, tempDir)
+
+	// A new temporary directory is removed by the cleanup
+	dir, cleanup, err = WorkDir("", "c2p-test-", hclog.NewNullLogger())
+	require.NoError(t, err)
+	require.DirExists(t, dir)
+	cleanup()
+	require.NoDirExists(t, dir)
+}
+
+func TestStoreEvidence(t *testing.T) {
+	small := policy.Evidence{Name: "small.yaml", Content: make([]byte, plugin.MaxEvidenceSize/2+1)}
+	large := policy.Evidence{Name: "large.yaml", Content: make([]byte, plugin.MaxEvidenceSize+1)}
+	newResult := func() policy.PVPResult {
+		return policy.PVPResult{ObservationsByCheck: []policy.ObservationByCheck{
+			{CheckID: "check-1", Evidence: []policy.Evidence{small, large}},
+			{CheckID: "check-2", Evidence: []policy.Evidence{small}},
+		}}
+	}
+
+	// Without a host, evidence is kept in the results up to the size limit
+	result := newResult()
+	StoreEvidence(context.Background(), nil, hclog.NewNullLogger(), &result)
+	require.Equal(t, []policy.Evidence{small}, result.ObservationsByCheck[0].Evidence)
+	require.Empty(t, result.ObservationsByCheck[1].Evidence)
+
+	// Evidence above the size limit is not sent to the host
+	host := &policytest.RecordingHost{}
+	result = newResult()
+	StoreEvidence(context.Background(), host, hclog.NewNullLogger(), &result)
+	require.Equal(t, []policy.Evidence{small, small}, host.Evidence)
+	require.Empty(t, result.ObservationsByCheck[0].Evidence)
+	require.Equal(t, []policy.Link{{Href: "evidence/small.yaml"}}, result.ObservationsByCheck[1].RelevantEvidences)
+
+	// Evidence is kept in the results if the host cannot store it
+	result = newResult()
+	StoreEvidence(context.Background(), &failingHost{}, hclog.NewNullLogger(), &result)
+	require.Equal(t, []policy.Evidence{small}, result.ObservationsByCheck[0].Evidence)
+	require.Empty(t, result.ObservationsByCheck[0].RelevantEvidences)
+}
+
+// prefixHost resolves paths relative to /work.
+type prefixHost struct {
+	policytest.RecordingHost
+}
+
+func (h *prefixHost) ResolvePath(_ context.Context, path string) (string, error) {
+	return "/work/" + path, nil
+}
+
+// failingHost fails all host services.
+type failingHost struct{}
+
+func (h *failingHost) StoreEvidence(context.Context, policy.Evidence) (policy.Link, error) {
+	return policy.Link{}, errors.New("host failed")
+}
+
+func (h *failingHost) ReportProgress(context.Context, policy.ProgressEvent) error {
+	return errors.New("host failed")
+}
+
+func (h *failingHost) ResolvePath(context.Context, string) (string, error) {
+	return "", errors.New("host failed")
+}
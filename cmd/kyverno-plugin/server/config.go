@@ -12,11 +12,16 @@ import (
 	"path/filepath"
 )
 
+// configurationOptions are the names of the options accepted by Configure.
+var configurationOptions = []string{"policy-dir", "policy-results-dir", "temp-dir", "output-dir", "attach-evidence"}
+
 type Config struct {
 	PoliciesDir      string `mapstructure:"policy-dir"`
 	PolicyResultsDir string `mapstructure:"policy-results-dir"`
 	TempDir          string `mapstructure:"temp-dir"`
 	OutputDir        string `mapstructure:"output-dir"`
+	// AttachEvidence attaches the policy report results to the observations as evidence.
+	AttachEvidence bool `mapstructure:"attach-evidence"`
 }
 
 func (c Config) Validate() error {
@@ -29,13 +29,21 @@ import (
 	"github.com/oscal-compass/compliance-to-policy-go/v2/internal/utils"
 )
 
+const (
+	titleAnnotation       = "policies.kyverno.io/title"
+	descriptionAnnotation = "policies.kyverno.io/description"
+	severityAnnotation    = "policies.kyverno.io/severity"
+)
+
 type PolicyResourceIndex struct {
-	Kind       string `json:"kind,omitempty"`
-	ApiVersion string `json:"apiVersion,omitempty"`
-	Name       string `json:"name,omitempty"`
-	Namespace  string `json:"namespace,omitempty"`
-	SrcPath    string `json:"srcPath,omitempty"`
-	HasContext bool   `json:"hasContext,omitempty"`
+	Kind        string `json:"kind,omitempty"`
+	ApiVersion  string `json:"apiVersion,omitempty"`
+	Name        string `json:"name,omitempty"`
+	Namespace   string `json:"namespace,omitempty"`
+	SrcPath     string `json:"srcPath,omitempty"`
+	HasContext  bool   `json:"hasContext,omitempty"`
+	Title       string `json:"title,omitempty"`
+	Description string `json:"description,omitempty"`
 }
 
 type FileLoader struct {
@@ -93,12 +101,15 @@ func (fl *FileLoader) LoadFromDirectory(dir string) error {
 func (fl *FileLoader) mapLoadedObject(unstObj *unstructured.Unstructured, path string) *PolicyResourceIndex {
 	kind, apiVersion, name := unstObj.GetKind(), unstObj.GetAPIVersion(), unstObj.GetName()
 	fl.logger.Info(fmt.Sprintf("load yaml %s: %s/%s/%s", path, kind, apiVersion, name))
+	annotations := unstObj.GetAnnotations()
 	return &PolicyResourceIndex{
-		ApiVersion: unstObj.GetAPIVersion(),
-		Kind:       unstObj.GetKind(),
-		Name:       name,
-		Namespace:  unstObj.GetNamespace(),
-		SrcPath:    path,
+		ApiVersion:  unstObj.GetAPIVersion(),
+		Kind:        unstObj.GetKind(),
+		Name:        name,
+		Namespace:   unstObj.GetNamespace(),
+		SrcPath:     path,
+		Title:       annotations[titleAnnotation],
+		Description: strings.TrimSpace(annotations[descriptionAnnotation]),
 	}
 }
 
@@ -121,9 +132,9 @@ func (fl *FileLoader) filterByGVKN(pri *PolicyResourceIndex, unstObj *unstructur
 func (fl *FileLoader) filterByAnnotation(pri *PolicyResourceIndex, unstObj *unstructured.Unstructured) *PolicyResourceIndex {
 	if pri != nil {
 		annotations := unstObj.GetAnnotations()
-		_, found := annotations["policies.kyverno.io/title"]
+		_, found := annotations[titleAnnotation]
 		if !found {
-			fl.logger.Info(fmt.Sprintf("  ignore %s due to missing 'policies.kyverno.io/title' annotation", pri.Name))
+			fl.logger.Info(fmt.Sprintf("  ignore %s due to missing '%s' annotation", pri.Name, titleAnnotation))
 			return nil
 		}
 	}
@@ -18,6 +18,9 @@ package server
 
 import (
 	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
 
 	"github.com/hashicorp/go-hclog"
 	cp "github.com/otiai10/copy"
@@ -61,3 +64,48 @@ func (c *Oscal2Policy) CopyAllTo(destDir string) error {
 	}
 	return nil
 }
+
+// Artifacts returns the policy artifacts generated for each rule in the policy.
+func (c *Oscal2Policy) Artifacts(pl policy.Policy) ([]policy.Artifact, error) {
+	var artifacts []policy.Artifact
+	for _, ruleObject := range pl {
+		var checkIDs []string
+		for _, check := range ruleObject.Checks {
+			checkIDs = append(checkIDs, check.ID)
+		}
+		ruleDir := filepath.Join(c.tempDir.GetTempDir(), ruleObject.Rule.ID)
+		err := filepath.WalkDir(ruleDir, func(path string, d fs.DirEntry, err error) error {
+			if err != nil || d.IsDir() {
+				return err
+			}
+			content, err := os.ReadFile(filepath.Clean(path))
+			if err != nil {
+				return err
+			}
+			relPath, err := filepath.Rel(c.tempDir.GetTempDir(), path)
+			if err != nil {
+				return err
+			}
+			artifact := policy.NewArtifact(filepath.ToSlash(relPath), mediaType(path), content)
+			artifact.RuleIDs = []string{ruleObject.Rule.ID}
+			artifact.CheckIDs = checkIDs
+			artifacts = append(artifacts, artifact)
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	return artifacts, nil
+}
+
+func mediaType(path string) string {
+	switch filepath.Ext(path) {
+	case ".yaml", ".yml":
+		return "application/yaml"
+	case ".json":
+		return "application/json"
+	default:
+		return "application/octet-stream"
+	}
+}
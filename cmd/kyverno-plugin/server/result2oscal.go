@@ -22,6 +22,7 @@ import (
 
 	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
 	typepolr "sigs.k8s.io/wg-policy-prototypes/policy-report/pkg/api/wgpolicyk8s.io/v1beta1"
+	"sigs.k8s.io/yaml"
 
 	"github.com/oscal-compass/compliance-to-policy-go/v2/internal/utils"
 	"github.com/oscal-compass/compliance-to-policy-go/v2/policy"
@@ -34,6 +35,8 @@ type ResultToOscal struct {
 	clusterPolicyReportList *typepolr.ClusterPolicyReportList
 	policyList              *kyvernov1.PolicyList
 	clusterPolicyList       *kyvernov1.ClusterPolicyList
+	// attachEvidence attaches the policy report results as evidence.
+	attachEvidence bool
 }
 
 type PolicyReportContainer struct {
@@ -107,6 +110,14 @@ func (r *ResultToOscal) GenerateResults() (policy.PVPResult, error) {
 		return policy.PVPResult{}, err
 	}
 
+	severities := make(map[string]policy.Severity)
+	for _, pol := range polList.Items {
+		severities[pol.Name] = policy.ParseSeverity(pol.GetAnnotations()[severityAnnotation])
+	}
+	for _, cpol := range cpolList.Items {
+		severities[cpol.Name] = policy.ParseSeverity(cpol.GetAnnotations()[severityAnnotation])
+	}
+
 	var observations []policy.ObservationByCheck
 	for _, rule := range r.policy {
 		for _, check := range rule.Checks {
@@ -122,6 +133,7 @@ func (r *ResultToOscal) GenerateResults() (policy.PVPResult, error) {
 				},
 				Collected: time.Now(),
 				Subjects:  []policy.Subject{},
+				Severity:  severities[name],
 			}
 			for _, prr := range prrs {
 				for _, resource := range prr.Subjects {
@@ -137,6 +149,18 @@ func (r *ResultToOscal) GenerateResults() (policy.PVPResult, error) {
 					observation.Subjects = append(observation.Subjects, subject)
 				}
 			}
+			if r.attachEvidence && len(prrs) > 0 {
+				content, err := yaml.Marshal(prrs)
+				if err != nil {
+					return policy.PVPResult{}, fmt.Errorf("failed to marshal policy report results for %s: %w", name, err)
+				}
+				observation.Evidence = append(observation.Evidence, policy.Evidence{
+					Name:        fmt.Sprintf("%s-policy-report-results.yaml", name),
+					Description: fmt.Sprintf("Policy report results of policy %s", name),
+					MediaType:   "application/yaml",
+					Content:     content,
+				})
+			}
 			observations = append(observations, observation)
 		}
 	}
@@ -151,10 +175,14 @@ func mapResults(result typepolr.PolicyResult) policy.Result {
 	switch result {
 	case "pass":
 		return policy.ResultPass
-	case "fail", "warn":
+	case "fail":
 		return policy.ResultFail
+	case "warn":
+		return policy.ResultWarning
 	case "error":
 		return policy.ResultError
+	case "skip":
+		return policy.ResultSkipped
 	default:
 		return policy.ResultInvalid
 	}
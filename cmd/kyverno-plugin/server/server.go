@@ -9,57 +9,179 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
 
 	"github.com/go-viper/mapstructure/v2"
 	"github.com/hashicorp/go-hclog"
+	"github.com/oscal-compass/oscal-sdk-go/extensions"
 
+	"github.com/oscal-compass/compliance-to-policy-go/v2/cmd/internal/pluginhost"
 	"github.com/oscal-compass/compliance-to-policy-go/v2/internal/utils"
 	"github.com/oscal-compass/compliance-to-policy-go/v2/logging"
 	"github.com/oscal-compass/compliance-to-policy-go/v2/policy"
 )
 
 var (
-	_      policy.Provider = (*Plugin)(nil)
-	logger hclog.Logger    = logging.NewPluginLogger()
+	_      policy.Provider     = (*Plugin)(nil)
+	_      policy.Describer    = (*Plugin)(nil)
+	_      policy.HostConsumer = (*Plugin)(nil)
+	_      policy.Validator    = (*Plugin)(nil)
+	_      policy.CheckLister  = (*Plugin)(nil)
+	logger hclog.Logger        = logging.NewPluginLogger()
 )
 
+// version is the plugin version reported by Describe. It must
+// match the version in the plugin manifest.
+const version = "0.0.1"
+
 func Logger() hclog.Logger {
 	return logger
 }
 
 type Plugin struct {
 	config Config
+	// host resolves paths and receives progress events
+	// if the host provides services.
+	host policy.Host
 }
 
 func NewPlugin() *Plugin {
 	return &Plugin{}
 }
 
-func (p *Plugin) Configure(_ context.Context, m map[string]string) error {
-	if err := mapstructure.Decode(m, &p.config); err != nil {
+func (p *Plugin) SetHost(host policy.Host) {
+	p.host = host
+}
+
+func (p *Plugin) Configure(ctx context.Context, m map[string]string) error {
+	if err := mapstructure.WeakDecode(m, &p.config); err != nil {
 		return errors.New("error decoding configuration")
 	}
+	// Relative paths are relative to the host working directory
+	if err := pluginhost.ResolvePaths(ctx, p.host, &p.config.PoliciesDir, &p.config.PolicyResultsDir, &p.config.TempDir, &p.config.OutputDir); err != nil {
+		return err
+	}
 	return p.config.Validate()
 }
 
-func (p *Plugin) Generate(_ context.Context, pl policy.Policy) error {
+func (p *Plugin) Describe(ctx context.Context) (policy.ProviderInfo, error) {
+	info := policy.ProviderInfo{
+		Version:              version,
+		Types:                []string{"pvp"},
+		ConfigurationOptions: configurationOptions,
+		Features:             []policy.Feature{policy.FeatureGenerate, policy.FeatureValidate, policy.FeatureListChecks},
+	}
+	// The checks are only known once the policy directory is configured
+	if p.config.PoliciesDir != "" {
+		checks, err := p.ListChecks(ctx)
+		if err != nil {
+			return policy.ProviderInfo{}, err
+		}
+		info.SupportedChecks = checks.CheckIDs()
+		info.SupportedParameters = checks.ParameterIDs()
+	}
+	return info, nil
+}
+
+// Validate reports the rules without a policy directory
+// under the configured policy-dir.
+func (p *Plugin) Validate(ctx context.Context, pl policy.Policy) ([]policy.Diagnostic, error) {
+	var diagnostics []policy.Diagnostic
+	for _, ruleObject := range pl {
+		ruleDir := filepath.Join(p.config.PoliciesDir, ruleObject.Rule.ID)
+		if info, err := os.Stat(ruleDir); err == nil && info.IsDir() {
+			continue
+		}
+		for _, check := range ruleObject.Checks {
+			diagnostics = append(diagnostics, policy.Diagnostic{
+				RuleID:  ruleObject.Rule.ID,
+				CheckID: check.ID,
+				Type:    policy.DiagnosticUnknownCheck,
+				Message: fmt.Sprintf("no policy directory found for rule %s at %s", ruleObject.Rule.ID, ruleDir),
+			})
+		}
+	}
+	return diagnostics, nil
+}
+
+// ListChecks returns a rule for each directory under the configured policy-dir
+// with a check for each Kyverno policy in the directory. The checks are
+// described by the policies.kyverno.io annotations.
+func (p *Plugin) ListChecks(ctx context.Context) (policy.Policy, error) {
+	if p.config.PoliciesDir == "" {
+		return nil, errors.New("policy-dir must be set to list checks")
+	}
+	loader := NewFileLoader()
+	if err := loader.LoadFromDirectory(p.config.PoliciesDir); err != nil {
+		return nil, err
+	}
+
+	var checks policy.Policy
+	ruleIdx := make(map[string]int)
+	for _, index := range loader.GetPolicyResourceIndice() {
+		relPath, err := filepath.Rel(p.config.PoliciesDir, index.SrcPath)
+		if err != nil {
+			return nil, err
+		}
+		// Policies are generated from the directory of each rule
+		ruleID, _, found := strings.Cut(filepath.ToSlash(relPath), "/")
+		if !found {
+			logger.Debug(fmt.Sprintf("Skipping policy %s outside of a rule directory", index.Name))
+			continue
+		}
+		idx, ok := ruleIdx[ruleID]
+		if !ok {
+			idx = len(checks)
+			ruleIdx[ruleID] = idx
+			checks = append(checks, extensions.RuleSet{
+				Rule: extensions.Rule{ID: ruleID, Description: index.Title},
+			})
+		}
+		description := index.Description
+		if description == "" {
+			description = index.Title
+		}
+		checks[idx].Checks = append(checks[idx].Checks, extensions.Check{ID: index.Name, Description: description})
+	}
+	return checks, nil
+}
+
+func (p *Plugin) Generate(ctx context.Context, pl policy.Policy) ([]policy.Artifact, error) {
 	logger.Debug(fmt.Sprintf("Using resources from %s", p.config.PoliciesDir))
-	tmpdir := utils.NewTempDirectory(p.config.TempDir)
-	composer := NewOscal2Policy(p.config.PoliciesDir, tmpdir)
+	tempDir, cleanup, err := pluginhost.WorkDir(p.config.TempDir, "c2p-kyverno-", logger)
+	if err != nil {
+		return nil, err
+	}
+	defer cleanup()
+	composer := NewOscal2Policy(p.config.PoliciesDir, utils.NewTempDirectory(tempDir))
 	if err := composer.Generate(pl); err != nil {
-		return err
+		return nil, err
+	}
+	if err := pluginhost.ReportProgress(ctx, p.host, logger, fmt.Sprintf("Generated policies for %d rules", len(pl))); err != nil {
+		return nil, err
 	}
 
 	if p.config.OutputDir != "" {
 		if err := composer.CopyAllTo(p.config.OutputDir); err != nil {
-			return err
+			return nil, err
 		}
 		logger.Debug(fmt.Sprintf("Copied outputs to %s", p.config.OutputDir))
 	}
-	return nil
+	return composer.Artifacts(pl)
 }
 
-func (p *Plugin) GetResults(_ context.Context, pl policy.Policy) (policy.PVPResult, error) {
+func (p *Plugin) GetResults(ctx context.Context, pl policy.Policy) (policy.PVPResult, error) {
 	results := NewResultToOscal(pl, p.config.PolicyResultsDir)
-	return results.GenerateResults()
+	results.attachEvidence = p.config.AttachEvidence
+	pvpResult, err := results.GenerateResults()
+	if err != nil {
+		return policy.PVPResult{}, err
+	}
+	pluginhost.StoreEvidence(ctx, p.host, logger, &pvpResult)
+	if err := pluginhost.ReportProgress(ctx, p.host, logger, fmt.Sprintf("Collected results for %d checks", len(pvpResult.ObservationsByCheck))); err != nil {
+		return policy.PVPResult{}, err
+	}
+	return pvpResult, nil
 }
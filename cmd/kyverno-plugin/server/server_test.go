@@ -8,6 +8,7 @@ package server
 import (
 	"context"
 	"os"
+	"strings"
 	"testing"
 
 	"github.com/oscal-compass/oscal-sdk-go/extensions"
@@ -19,6 +20,8 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/oscal-compass/compliance-to-policy-go/v2/internal/utils"
+	"github.com/oscal-compass/compliance-to-policy-go/v2/policy"
+	"github.com/oscal-compass/compliance-to-policy-go/v2/policy/policytest"
 )
 
 func TestOscal2Policy(t *testing.T) {
@@ -33,6 +36,17 @@ func TestOscal2Policy(t *testing.T) {
 	o2p := NewOscal2Policy(policyDir, tempDir)
 	err = o2p.Generate(policyExample)
 	assert.NoError(t, err, "Should not happen")
+
+	artifacts, err := o2p.Artifacts(policyExample)
+	require.NoError(t, err)
+	require.NotEmpty(t, artifacts)
+	for _, artifact := range artifacts {
+		require.Len(t, artifact.RuleIDs, 1)
+		require.True(t, strings.HasPrefix(artifact.Path, artifact.RuleIDs[0]+"/"))
+		require.Equal(t, "application/yaml", artifact.MediaType)
+		require.NotEmpty(t, artifact.Content)
+		require.Len(t, artifact.Checksum, 64)
+	}
 }
 
 func TestConfigure(t *testing.T) {
@@ -49,6 +63,131 @@ func TestConfigure(t *testing.T) {
 	require.NoError(t, err)
 }
 
+func TestValidate(t *testing.T) {
+	plugin := NewPlugin()
+	plugin.config.PoliciesDir = utils.PathFromInternalDirectory("./testdata/kyverno/policy-resources")
+	policyExample := createPolicy(t)
+	diagnostics, err := plugin.Validate(context.Background(), policyExample)
+	require.NoError(t, err)
+	require.Empty(t, diagnostics)
+
+	// All rules are reported when the policy directory is empty
+	plugin.config.PoliciesDir = t.TempDir()
+	diagnostics, err = plugin.Validate(context.Background(), policyExample)
+	require.NoError(t, err)
+	var wantCount int
+	for _, ruleObject := range policyExample {
+		wantCount += len(ruleObject.Checks)
+	}
+	require.Len(t, diagnostics, wantCount)
+	for _, diagnostic := range diagnostics {
+		require.Equal(t, policy.DiagnosticUnknownCheck, diagnostic.Type)
+		require.Contains(t, diagnostic.Message, diagnostic.RuleID)
+	}
+}
+
+func TestListChecks(t *testing.T) {
+	plugin := NewPlugin()
+	_, err := plugin.ListChecks(context.Background())
+	require.EqualError(t, err, "policy-dir must be set to list checks")
+
+	plugin.config.PoliciesDir = utils.PathFromInternalDirectory("./testdata/kyverno/policy-resources")
+	checks, err := plugin.ListChecks(context.Background())
+	require.NoError(t, err)
+	require.Len(t, checks, 1)
+	require.Equal(t, "allowed-base-images", checks[0].Rule.ID)
+	require.Equal(t, "Allowed Base Images", checks[0].Rule.Description)
+	require.Len(t, checks[0].Checks, 1)
+	require.Equal(t, "allowed-base-images", checks[0].Checks[0].ID)
+	require.True(t, strings.HasPrefix(checks[0].Checks[0].Description, "Building images which specify a base"))
+}
+
+func TestDescribe(t *testing.T) {
+	plugin := NewPlugin()
+	info, err := plugin.Describe(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, version, info.Version)
+	require.Equal(t, []string{"pvp"}, info.Types)
+	require.Contains(t, info.ConfigurationOptions, "policy-dir")
+	require.Empty(t, info.SupportedChecks)
+
+	plugin.config.PoliciesDir = utils.PathFromInternalDirectory("./testdata/kyverno/policy-resources")
+	info, err = plugin.Describe(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, []string{"allowed-base-images"}, info.SupportedChecks)
+}
+
+func TestGenerateResults(t *testing.T) {
+	results := NewResultToOscal(createPolicy(t), utils.PathFromInternalDirectory("./testdata/kyverno/policy-reports"))
+	pvpResult, err := results.GenerateResults()
+	require.NoError(t, err)
+	// Evidence is only attached when enabled
+	for _, observation := range pvpResult.ObservationsByCheck {
+		require.Empty(t, observation.Evidence)
+	}
+
+	results.attachEvidence = true
+	pvpResult, err = results.GenerateResults()
+	require.NoError(t, err)
+
+	severities := make(map[string]policy.Severity)
+	evidence := make(map[string][]policy.Evidence)
+	for _, observation := range pvpResult.ObservationsByCheck {
+		severities[observation.CheckID] = observation.Severity
+		evidence[observation.CheckID] = observation.Evidence
+	}
+	// The severity is read from the policy annotation
+	require.Equal(t, policy.SeverityMedium, severities["allowed-base-images"])
+	// The policy report results are attached as evidence
+	require.Len(t, evidence["allowed-base-images"], 1)
+	require.Equal(t, "allowed-base-images-policy-report-results.yaml", evidence["allowed-base-images"][0].Name)
+	require.Contains(t, string(evidence["allowed-base-images"][0].Content), "policy: allowed-base-images")
+}
+
+func TestHostServices(t *testing.T) {
+	host := &policytest.RecordingHost{}
+	plugin := NewPlugin()
+	plugin.SetHost(host)
+	require.NoError(t, plugin.Configure(context.Background(), map[string]string{
+		"policy-dir":         utils.PathFromInternalDirectory("./testdata/kyverno/policy-resources"),
+		"policy-results-dir": utils.PathFromInternalDirectory("./testdata/kyverno/policy-reports"),
+		"attach-evidence":    "true",
+	}))
+
+	// Evidence is stored with the host and linked from the observation
+	result, err := plugin.GetResults(context.Background(), createPolicy(t))
+	require.NoError(t, err)
+	require.Len(t, host.Evidence, 1)
+	require.Equal(t, "allowed-base-images-policy-report-results.yaml", host.Evidence[0].Name)
+	for _, observation := range result.ObservationsByCheck {
+		require.Empty(t, observation.Evidence)
+		if observation.CheckID == "allowed-base-images" {
+			require.Equal(t, []policy.Link{{Description: host.Evidence[0].Description, Href: "evidence/" + host.Evidence[0].Name}}, observation.RelevantEvidences)
+		}
+	}
+
+	// Artifacts are returned to the host without a temp-dir or output-dir
+	artifacts, err := plugin.Generate(context.Background(), createPolicy(t))
+	require.NoError(t, err)
+	require.NotEmpty(t, artifacts)
+	require.NotEmpty(t, host.Progress)
+}
+
+func TestConformance(t *testing.T) {
+	policytest.Run(t, policytest.Config{
+		NewProvider: func(t *testing.T) policy.Provider {
+			return NewPlugin()
+		},
+		Configuration: map[string]string{
+			"policy-dir":         utils.PathFromInternalDirectory("./testdata/kyverno/policy-resources"),
+			"policy-results-dir": utils.PathFromInternalDirectory("./testdata/kyverno/policy-reports"),
+			"temp-dir":           t.TempDir(),
+			"output-dir":         t.TempDir(),
+		},
+		Policy: createPolicy(t),
+	})
+}
+
 func createPolicy(t *testing.T) []extensions.RuleSet {
 	cdPath := utils.PathFromInternalDirectory("./testdata/kyverno/component-definition.json")
 
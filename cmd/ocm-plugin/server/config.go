@@ -12,6 +12,9 @@ import (
 	"path/filepath"
 )
 
+// configurationOptions are the names of the options accepted by Configure.
+var configurationOptions = []string{"policy-dir", "policy-results-dir", "temp-dir", "output-dir", "namespace", "policy-set-name", "attach-evidence"}
+
 type Config struct {
 	PoliciesDir      string `mapstructure:"policy-dir"`
 	PolicyResultsDir string `mapstructure:"policy-results-dir"`
@@ -19,6 +22,8 @@ type Config struct {
 	OutputDir        string `mapstructure:"output-dir"`
 	Namespace        string `mapstructure:"namespace"`
 	PolicySetName    string `mapstructure:"policy-set-name"`
+	// AttachEvidence attaches the policy status to the observations as evidence.
+	AttachEvidence bool `mapstructure:"attach-evidence"`
 	// TODO: support with most complex configuration options
 	clusterSelectors map[string]string `mapstructure:"cluster-selectors"`
 }
@@ -17,8 +17,15 @@ limitations under the License.
 package server
 
 import (
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	typepolr "sigs.k8s.io/wg-policy-prototypes/policy-report/pkg/api/wgpolicyk8s.io/v1beta1"
+	sigyaml "sigs.k8s.io/yaml"
 
 	typeconfigpolicy "github.com/oscal-compass/compliance-to-policy-go/v2/internal/types/configurationpolicy"
 	typepolicy "github.com/oscal-compass/compliance-to-policy-go/v2/internal/types/policy"
@@ -65,27 +72,47 @@ func mapToPolicyResult(complianceState typepolicy.ComplianceState) policy.Result
 }
 
 // Severity : low, medium, high, or critical
-// PolicyResultSeverity has one of the following values:
+// policy.Severity has one of the following values:
 //   - critical
 //   - high
 //   - low
 //   - medium
 //   - info
-func mapToSeverity(severity typeconfigpolicy.Severity) typepolr.PolicyResultSeverity {
+//   - unspecified when the severity is not set
+func mapToSeverity(severity typeconfigpolicy.Severity) policy.Severity {
 	switch severity {
+	case "":
+		return policy.SeverityUnspecified
 	case "low":
-		return "low"
+		return policy.SeverityLow
 	case "medium":
-		return "medium"
+		return policy.SeverityMedium
 	case "high":
-		return "high"
+		return policy.SeverityHigh
 	case "critical":
-		return "critical"
+		return policy.SeverityCritical
 	default:
-		return "info"
+		return policy.SeverityInfo
 	}
 }
 
+// policySeverity returns the highest severity of the ConfigurationPolicy
+// templates of the policy.
+func policySeverity(pol typepolicy.Policy) policy.Severity {
+	severity := policy.SeverityUnspecified
+	for _, policyTemplate := range pol.Spec.PolicyTemplates {
+		var configPolicy typeconfigpolicy.ConfigurationPolicy
+		if err := sigyaml.Unmarshal(policyTemplate.ObjectDefinition.Raw, &configPolicy); err != nil {
+			continue
+		}
+		if configPolicy.Kind != "ConfigurationPolicy" {
+			continue
+		}
+		severity = max(severity, mapToSeverity(configPolicy.Spec.Severity))
+	}
+	return severity
+}
+
 func mapToTimestamp(details typepolicy.DetailsPerTemplate) metav1.Timestamp {
 	if len(details.History) > 0 {
 		return *details.History[0].LastTimestamp.ProtoTime()
@@ -145,3 +172,35 @@ func findPolicyReportByNamespaceName(policyReports []*typepolr.PolicyReport, nam
 	}
 	return nil
 }
+
+// templateParameterRegexp matches the parameter key read from a ConfigMap
+// by a hub template, e.g. {{hub fromConfigMap "c2p" "oscal-parameters" "replicas" hub}}.
+var templateParameterRegexp = regexp.MustCompile(`fromConfigMap\s+"[^"]*"\s+"[^"]*"\s+"([^"]+)"`)
+
+// templateParameters returns the sorted parameter keys read by the hub
+// templates in the manifests under dir.
+func templateParameters(dir string) ([]string, error) {
+	found := map[string]struct{}{}
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		for _, match := range templateParameterRegexp.FindAllStringSubmatch(string(content), -1) {
+			found[match[1]] = struct{}{}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	parameters := make([]string, 0, len(found))
+	for parameter := range found {
+		parameters = append(parameters, parameter)
+	}
+	sort.Strings(parameters)
+	return parameters, nil
+}
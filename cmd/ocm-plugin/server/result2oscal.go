@@ -44,6 +44,8 @@ type ResultToOscal struct {
 	namespace          string
 	policySetName      string
 	placementDecisions []*typeplacementdecision.PlacementDecision
+	// attachEvidence attaches the policy status as evidence.
+	attachEvidence bool
 }
 
 type Reason struct {
@@ -154,7 +156,9 @@ func (r *ResultToOscal) GenerateResults() (provider.PVPResult, error) {
 			}
 
 			var subjects []provider.Subject
+			severity := provider.SeverityUnspecified
 			if policy != nil {
+				severity = policySeverity(*policy)
 				reasons := r.GenerateReasonsFromRawPolicies(*policy)
 				for _, reason := range reasons {
 					clusterName := "N/A"
@@ -202,6 +206,19 @@ func (r *ResultToOscal) GenerateResults() (provider.PVPResult, error) {
 				Props:       props,
 				Subjects:    subjects,
 				Collected:   time.Now(),
+				Severity:    severity,
+			}
+			if r.attachEvidence && policy != nil {
+				content, err := sigyaml.Marshal(policy.Status)
+				if err != nil {
+					return provider.PVPResult{}, fmt.Errorf("failed to marshal status of policy %s: %w", policyId, err)
+				}
+				observation.Evidence = append(observation.Evidence, provider.Evidence{
+					Name:        fmt.Sprintf("%s-status.yaml", policyId),
+					Description: fmt.Sprintf("Status of policy %s", policyId),
+					MediaType:   "application/yaml",
+					Content:     content,
+				})
 			}
 			observations = append(observations, observation)
 		}
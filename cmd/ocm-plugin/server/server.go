@@ -8,22 +8,35 @@ package server
 import (
 	"context"
 	"errors"
+	"fmt"
 	"os"
+	"path/filepath"
 	"strings"
 
 	"github.com/go-viper/mapstructure/v2"
 	"github.com/hashicorp/go-hclog"
+	"github.com/oscal-compass/oscal-sdk-go/extensions"
 
+	"github.com/oscal-compass/compliance-to-policy-go/v2/cmd/internal/pluginhost"
+	pgtype "github.com/oscal-compass/compliance-to-policy-go/v2/internal/types/policygenerator"
 	"github.com/oscal-compass/compliance-to-policy-go/v2/internal/utils"
 	"github.com/oscal-compass/compliance-to-policy-go/v2/logging"
 	"github.com/oscal-compass/compliance-to-policy-go/v2/policy"
 )
 
 var (
-	_      policy.Provider = (*Plugin)(nil)
-	logger hclog.Logger    = logging.NewPluginLogger()
+	_      policy.Provider     = (*Plugin)(nil)
+	_      policy.Describer    = (*Plugin)(nil)
+	_      policy.HostConsumer = (*Plugin)(nil)
+	_      policy.Validator    = (*Plugin)(nil)
+	_      policy.CheckLister  = (*Plugin)(nil)
+	logger hclog.Logger        = logging.NewPluginLogger()
 )
 
+// version is the plugin version reported by Describe. It must
+// match the version in the plugin manifest.
+const version = "0.0.1"
+
 func Logger() hclog.Logger {
 	return logger
 }
@@ -31,6 +44,9 @@ func Logger() hclog.Logger {
 type Plugin struct {
 	config             Config
 	policyGeneratorDir string
+	// host resolves paths, stores evidence and receives
+	// progress events if the host provides services.
+	host policy.Host
 }
 
 func NewPlugin() *Plugin {
@@ -39,48 +55,179 @@ func NewPlugin() *Plugin {
 	}
 }
 
-func (p *Plugin) Configure(_ context.Context, m map[string]string) error {
-	if err := mapstructure.Decode(m, &p.config); err != nil {
+func (p *Plugin) SetHost(host policy.Host) {
+	p.host = host
+}
+
+func (p *Plugin) Configure(ctx context.Context, m map[string]string) error {
+	if err := mapstructure.WeakDecode(m, &p.config); err != nil {
 		return errors.New("error decoding configuration")
 	}
+	// Relative paths are relative to the host working directory
+	if err := pluginhost.ResolvePaths(ctx, p.host, &p.config.PoliciesDir, &p.config.PolicyResultsDir, &p.config.TempDir, &p.config.OutputDir); err != nil {
+		return err
+	}
 	return p.config.Validate()
 }
 
-func (p *Plugin) Generate(_ context.Context, pl policy.Policy) error {
-	tmpdir := utils.NewTempDirectory(p.config.TempDir)
-	composer := NewComposerByTempDirectory(p.config.PoliciesDir, tmpdir)
+func (p *Plugin) Describe(ctx context.Context) (policy.ProviderInfo, error) {
+	info := policy.ProviderInfo{
+		Version:              version,
+		Types:                []string{"pvp"},
+		ConfigurationOptions: configurationOptions,
+		Features:             []policy.Feature{policy.FeatureGenerate, policy.FeatureValidate, policy.FeatureListChecks},
+	}
+	// The checks are only known once the policy directory is configured
+	if p.config.PoliciesDir != "" {
+		checks, err := p.ListChecks(ctx)
+		if err != nil {
+			return policy.ProviderInfo{}, err
+		}
+		info.SupportedChecks = checks.CheckIDs()
+		info.SupportedParameters = checks.ParameterIDs()
+	}
+	return info, nil
+}
+
+// Validate reports the checks without a policy-generator.yaml under the
+// configured policy-dir and the rule parameters without a selected value.
+func (p *Plugin) Validate(_ context.Context, pl policy.Policy) ([]policy.Diagnostic, error) {
+	var diagnostics []policy.Diagnostic
+	for _, ruleObject := range pl {
+		for _, prm := range ruleObject.Rule.Parameters {
+			if prm.Value == "" {
+				diagnostics = append(diagnostics, policy.Diagnostic{
+					RuleID:    ruleObject.Rule.ID,
+					Parameter: prm.ID,
+					Type:      policy.DiagnosticMissingParameter,
+					Message:   fmt.Sprintf("no value selected for parameter %s", prm.ID),
+				})
+			}
+		}
+		for _, check := range ruleObject.Checks {
+			manifestPath := filepath.Join(p.config.PoliciesDir, check.ID, "policy-generator.yaml")
+			if _, err := os.Stat(manifestPath); err != nil {
+				diagnostics = append(diagnostics, policy.Diagnostic{
+					RuleID:  ruleObject.Rule.ID,
+					CheckID: check.ID,
+					Type:    policy.DiagnosticUnknownCheck,
+					Message: fmt.Sprintf("no policy generator manifest found for check %s at %s", check.ID, manifestPath),
+				})
+			}
+		}
+	}
+	return diagnostics, nil
+}
+
+// ListChecks returns a rule and check for each directory with a valid policy-generator.yaml
+// under the configured policy-dir. The rule parameters are the keys read from the
+// parameters ConfigMap in the policy templates.
+func (p *Plugin) ListChecks(_ context.Context) (policy.Policy, error) {
+	if p.config.PoliciesDir == "" {
+		return nil, errors.New("policy-dir must be set to list checks")
+	}
+	entries, err := os.ReadDir(p.config.PoliciesDir)
+	if err != nil {
+		return nil, err
+	}
+	var checks policy.Policy
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+		checkDir := filepath.Join(p.config.PoliciesDir, entry.Name())
+		var policyGeneratorManifest pgtype.PolicyGenerator
+		if err := utils.LoadYamlFileToObject(filepath.Join(checkDir, "policy-generator.yaml"), &policyGeneratorManifest); err != nil {
+			logger.Debug(fmt.Sprintf("Skipping %s: %v", checkDir, err))
+			continue
+		}
+		parameterIDs, err := templateParameters(checkDir)
+		if err != nil {
+			return nil, err
+		}
+		var parameters []extensions.Parameter
+		for _, parameterID := range parameterIDs {
+			parameters = append(parameters, extensions.Parameter{ID: parameterID})
+		}
+		// Checks are mapped to the policy directories, so each
+		// check is listed under a rule with the same ID.
+		checks = append(checks, extensions.RuleSet{
+			Rule:   extensions.Rule{ID: entry.Name(), Parameters: parameters},
+			Checks: []extensions.Check{{ID: entry.Name()}},
+		})
+	}
+	return checks, nil
+}
+
+func (p *Plugin) Generate(ctx context.Context, pl policy.Policy) ([]policy.Artifact, error) {
+	tempDir, cleanup, err := pluginhost.WorkDir(p.config.TempDir, "c2p-ocm-", logger)
+	if err != nil {
+		return nil, err
+	}
+	defer cleanup()
+	composer := NewComposerByTempDirectory(p.config.PoliciesDir, utils.NewTempDirectory(tempDir))
 	if err := composer.ComposeByPolicies(pl, p.config); err != nil {
-		return err
+		return nil, err
 	}
 	policySet, err := composer.GeneratePolicySet()
 	if err != nil {
-		return err
+		return nil, err
+	}
+	if err := pluginhost.ReportProgress(ctx, p.host, logger, fmt.Sprintf("Generated policy set for %d rules", len(pl))); err != nil {
+		return nil, err
+	}
+
+	var ruleIDs, checkIDs []string
+	for _, ruleObject := range pl {
+		ruleIDs = append(ruleIDs, ruleObject.Rule.ID)
+		for _, check := range ruleObject.Checks {
+			checkIDs = append(checkIDs, check.ID)
+		}
 	}
 
+	var artifacts []policy.Artifact
 	for _, resource := range (*policySet).Resources() {
 		name := resource.GetName()
 		kind := resource.GetKind()
 		namespace := resource.GetNamespace()
 		yamlByte, err := resource.AsYAML()
 		if err != nil {
-			return err
+			return nil, err
 		}
 		fnamesTokens := []string{kind, namespace, name}
 		fname := strings.Join(fnamesTokens, ".") + ".yaml"
-		if err := os.WriteFile(p.config.OutputDir+"/"+fname, yamlByte, 0600); err != nil {
-			return err
+		if p.config.OutputDir != "" {
+			if err := os.WriteFile(filepath.Join(p.config.OutputDir, fname), yamlByte, 0600); err != nil {
+				return nil, err
+			}
 		}
+
+		// Resources are composed into a single policy set, so
+		// each artifact originates from all rules in the policy.
+		artifact := policy.NewArtifact(fname, "application/yaml", yamlByte)
+		artifact.RuleIDs = ruleIDs
+		artifact.CheckIDs = checkIDs
+		artifacts = append(artifacts, artifact)
 	}
 
 	if p.policyGeneratorDir != "" {
 		if err := composer.CopyAllTo(p.policyGeneratorDir); err != nil {
-			return err
+			return nil, err
 		}
 	}
-	return nil
+	return artifacts, nil
 }
 
-func (p *Plugin) GetResults(_ context.Context, pl policy.Policy) (policy.PVPResult, error) {
+func (p *Plugin) GetResults(ctx context.Context, pl policy.Policy) (policy.PVPResult, error) {
 	results := NewResultToOscal(pl, p.config.PolicyResultsDir, p.config.Namespace, p.config.PolicySetName)
-	return results.GenerateResults()
+	results.attachEvidence = p.config.AttachEvidence
+	pvpResult, err := results.GenerateResults()
+	if err != nil {
+		return policy.PVPResult{}, err
+	}
+	pluginhost.StoreEvidence(ctx, p.host, logger, &pvpResult)
+	if err := pluginhost.ReportProgress(ctx, p.host, logger, fmt.Sprintf("Collected results for %d checks", len(pvpResult.ObservationsByCheck))); err != nil {
+		return policy.PVPResult{}, err
+	}
+	return pvpResult, nil
 }
@@ -7,7 +7,9 @@ package server
 
 import (
 	"context"
+	"fmt"
 	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
@@ -22,6 +24,7 @@ import (
 
 	"github.com/oscal-compass/compliance-to-policy-go/v2/internal/utils"
 	"github.com/oscal-compass/compliance-to-policy-go/v2/policy"
+	"github.com/oscal-compass/compliance-to-policy-go/v2/policy/policytest"
 )
 
 func TestOscal2Policy(t *testing.T) {
@@ -41,7 +44,12 @@ func TestOscal2Policy(t *testing.T) {
 	plugin.config.TempDir = tempDir.GetTempDir()
 	plugin.config.OutputDir = tmpOutputDir
 	plugin.config.PolicyResultsDir = tmpOutputDir
-	require.NoError(t, plugin.Generate(context.Background(), testPolicy))
+	artifacts, err := plugin.Generate(context.Background(), testPolicy)
+	require.NoError(t, err)
+	require.NotEmpty(t, artifacts)
+	for _, artifact := range artifacts {
+		require.FileExists(t, filepath.Join(tmpOutputDir, artifact.Path))
+	}
 }
 
 func TestResult2Oscal(t *testing.T) {
@@ -51,6 +59,7 @@ func TestResult2Oscal(t *testing.T) {
 	assert.NoError(t, err, "Should not happen")
 	testPolicy := createPolicy(t)
 	reporter := NewResultToOscal(testPolicy, policyResultsDir, "c2p", "Managed Kubernetes")
+	reporter.attachEvidence = true
 	results, err := reporter.GenerateResults()
 	assert.NoError(t, err, "Should not happen")
 	expected := policy.PVPResult{
@@ -60,6 +69,7 @@ func TestResult2Oscal(t *testing.T) {
 				Description: "Observation of policy policy-high-scan",
 				CheckID:     "policy-high-scan",
 				Methods:     []string{"TEST-AUTOMATED"},
+				Severity:    policy.SeverityHigh,
 				Subjects: []policy.Subject{
 					{
 						Title:      "Cluster Name: cluster1",
@@ -82,12 +92,20 @@ func TestResult2Oscal(t *testing.T) {
 						Value: "test_configuration_check",
 					},
 				},
+				Evidence: []policy.Evidence{
+					{
+						Name:        "policy-high-scan-status.yaml",
+						Description: "Status of policy policy-high-scan",
+						MediaType:   "application/yaml",
+					},
+				},
 			},
 			{
 				Title:       "test_proxy_check",
 				Description: "Observation of policy policy-deployment",
 				CheckID:     "policy-deployment",
 				Methods:     []string{"TEST-AUTOMATED"},
+				Severity:    policy.SeverityLow,
 				Subjects: []policy.Subject{
 					{
 						Title:      "Cluster Name: cluster1",
@@ -110,12 +128,20 @@ func TestResult2Oscal(t *testing.T) {
 						Value: "test_proxy_check",
 					},
 				},
+				Evidence: []policy.Evidence{
+					{
+						Name:        "policy-deployment-status.yaml",
+						Description: "Status of policy policy-deployment",
+						MediaType:   "application/yaml",
+					},
+				},
 			},
 			{
 				Title:       "test_rbac_check",
 				Description: "Observation of policy policy-disallowed-roles",
 				CheckID:     "policy-disallowed-roles",
 				Methods:     []string{"TEST-AUTOMATED"},
+				Severity:    policy.SeverityHigh,
 				Subjects: []policy.Subject{
 					{
 						Title:      "Cluster Name: cluster1",
@@ -133,7 +159,15 @@ func TestResult2Oscal(t *testing.T) {
 					},
 				},
 				Props: []policy.Property{
-					{Name: "assessment-rule-id", Value: "test_rbac_check"}}},
+					{Name: "assessment-rule-id", Value: "test_rbac_check"}},
+				Evidence: []policy.Evidence{
+					{
+						Name:        "policy-disallowed-roles-status.yaml",
+						Description: "Status of policy policy-disallowed-roles",
+						MediaType:   "application/yaml",
+					},
+				},
+			},
 		},
 	}
 	diff := cmp.Diff(expected, results,
@@ -141,11 +175,92 @@ func TestResult2Oscal(t *testing.T) {
 		cmpopts.IgnoreFields(policy.Subject{}, "EvaluatedOn"),
 		cmpopts.IgnoreFields(policy.Subject{}, "ResourceID"),
 		cmpopts.IgnoreFields(policy.Subject{}, "Reason"),
+		cmpopts.IgnoreFields(policy.Evidence{}, "Content"),
 		cmpopts.SortSlices(func(i, j policy.ObservationByCheck) bool {
 			return i.Title < j.Title
 		}),
 	)
 	require.Equal(t, diff, "")
+	for _, observation := range results.ObservationsByCheck {
+		require.Contains(t, string(observation.Evidence[0].Content), "clustername: cluster1")
+	}
+}
+
+func TestValidate(t *testing.T) {
+	plugin := NewPlugin()
+	plugin.config.PoliciesDir = utils.PathFromInternalDirectory("./testdata/ocm/policies")
+	testPolicy := createPolicy(t)
+	// Parameter values are selected by the settings of the assessment plan
+	for _, ruleObject := range testPolicy {
+		for i := range ruleObject.Rule.Parameters {
+			ruleObject.Rule.Parameters[i].Value = "1"
+		}
+	}
+	diagnostics, err := plugin.Validate(context.Background(), testPolicy)
+	require.NoError(t, err)
+	require.Empty(t, diagnostics)
+
+	testPolicy = policy.Policy{
+		{
+			Rule: extensions.Rule{
+				ID:         "test_rule",
+				Parameters: []extensions.Parameter{{ID: "test_param"}},
+			},
+			Checks: []extensions.Check{{ID: "policy-high-scan"}, {ID: "not-exist"}},
+		},
+	}
+	diagnostics, err = plugin.Validate(context.Background(), testPolicy)
+	require.NoError(t, err)
+	wantDiagnostics := []policy.Diagnostic{
+		{
+			RuleID:    "test_rule",
+			Parameter: "test_param",
+			Type:      policy.DiagnosticMissingParameter,
+			Message:   "no value selected for parameter test_param",
+		},
+		{
+			RuleID:  "test_rule",
+			CheckID: "not-exist",
+			Type:    policy.DiagnosticUnknownCheck,
+			Message: fmt.Sprintf("no policy generator manifest found for check not-exist at %s", filepath.Join(plugin.config.PoliciesDir, "not-exist", "policy-generator.yaml")),
+		},
+	}
+	require.Equal(t, wantDiagnostics, diagnostics)
+}
+
+func TestListChecks(t *testing.T) {
+	plugin := NewPlugin()
+	plugin.config.PoliciesDir = utils.PathFromInternalDirectory("./testdata/ocm/policies")
+	checks, err := plugin.ListChecks(context.Background())
+	require.NoError(t, err)
+	require.NotEmpty(t, checks)
+
+	checksByID := map[string]extensions.RuleSet{}
+	for _, ruleSet := range checks {
+		require.Len(t, ruleSet.Checks, 1)
+		require.Equal(t, ruleSet.Rule.ID, ruleSet.Checks[0].ID)
+		checksByID[ruleSet.Rule.ID] = ruleSet
+	}
+	require.Contains(t, checksByID, "policy-high-scan")
+	require.Empty(t, checksByID["policy-high-scan"].Rule.Parameters)
+	// Parameters are read from the hub templates
+	require.Equal(t, []extensions.Parameter{{ID: "minimum_nginx_deployment_replicas"}}, checksByID["policy-nginx-deployment"].Rule.Parameters)
+}
+
+func TestDescribe(t *testing.T) {
+	plugin := NewPlugin()
+	info, err := plugin.Describe(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, version, info.Version)
+	require.Equal(t, []string{"pvp"}, info.Types)
+	require.Contains(t, info.ConfigurationOptions, "policy-set-name")
+	require.Empty(t, info.SupportedChecks)
+
+	plugin.config.PoliciesDir = utils.PathFromInternalDirectory("./testdata/ocm/policies")
+	info, err = plugin.Describe(context.Background())
+	require.NoError(t, err)
+	require.Contains(t, info.SupportedChecks, "policy-high-scan")
+	require.Contains(t, info.SupportedParameters, "minimum_nginx_deployment_replicas")
 }
 
 func TestConfigure(t *testing.T) {
@@ -168,6 +283,31 @@ func TestConfigure(t *testing.T) {
 	require.NoError(t, err)
 }
 
+func TestHostServices(t *testing.T) {
+	host := &policytest.RecordingHost{}
+	plugin := NewPlugin()
+	plugin.SetHost(host)
+	require.NoError(t, plugin.Configure(context.Background(), map[string]string{
+		"policy-results-dir": utils.PathFromInternalDirectory("./testdata/ocm/policy-results"),
+		"namespace":          "c2p",
+		"policy-set-name":    "Managed Kubernetes",
+		"attach-evidence":    "true",
+	}))
+
+	// Evidence is stored with the host and linked from the observations
+	result, err := plugin.GetResults(context.Background(), createPolicy(t))
+	require.NoError(t, err)
+	require.NotEmpty(t, host.Evidence)
+	var links []policy.Link
+	for _, observation := range result.ObservationsByCheck {
+		require.Empty(t, observation.Evidence)
+		links = append(links, observation.RelevantEvidences...)
+	}
+	require.Len(t, links, len(host.Evidence))
+	require.Equal(t, "evidence/"+host.Evidence[0].Name, links[0].Href)
+	require.Len(t, host.Progress, 1)
+}
+
 func createPolicy(t *testing.T) []extensions.RuleSet {
 	cdPath := utils.PathFromInternalDirectory("./testdata/ocm/component-definition.json")
 
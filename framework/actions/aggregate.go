@@ -9,6 +9,8 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"sync"
+	"time"
 
 	"github.com/oscal-compass/oscal-sdk-go/settings"
 	"golang.org/x/sync/errgroup"
@@ -18,16 +20,55 @@ import (
 	"github.com/oscal-compass/compliance-to-policy-go/v2/policy"
 )
 
+// ResultHandler processes a partial policy.PVPResult received from the provider
+// with the given plugin.ID.
+type ResultHandler func(plugin.ID, policy.PVPResult) error
+
 // AggregateResults action identifies policy configuration for each provider in the given pluginSet to execute the GetResults() method
 // each policy.Provider.
 //
+// See StreamResults for how failed providers are handled.
+//
 // The rule set passed to each plugin can be configured with compliance specific settings based on the InputContext.
 func AggregateResults(ctx context.Context, inputContext *InputContext, pluginSet map[plugin.ID]policy.Provider) ([]policy.PVPResult, error) {
-	log := logging.GetLogger("aggregator")
+	resultsByProvider := make(map[plugin.ID]*policy.PVPResult)
+	err := StreamResults(ctx, inputContext, pluginSet, func(providerId plugin.ID, partial policy.PVPResult) error {
+		result, ok := resultsByProvider[providerId]
+		if !ok {
+			result = &policy.PVPResult{}
+			resultsByProvider[providerId] = result
+		}
+		result.ObservationsByCheck = append(result.ObservationsByCheck, partial.ObservationsByCheck...)
+		result.Links = append(result.Links, partial.Links...)
+		return nil
+	})
 
 	var allResults []policy.PVPResult
-	resultChan := make(chan policy.PVPResult, len(pluginSet))
+	for _, result := range resultsByProvider {
+		allResults = append(allResults, *result)
+	}
+	if err != nil {
+		return allResults, err
+	}
+	return allResults, nil
+}
+
+// StreamResults action identifies policy configuration for each provider in the given pluginSet and passes
+// results to the handler as they are received.
+//
+// Providers implementing policy.ResultStreamer send results incrementally. All other providers, or plugins
+// that do not support streaming, fall back to the GetResults() method. Calls to the handler are serialized.
+//
+// When InputContext.ContinueOnError is set, a provider that fails to return results does not fail the action.
+// Instead, each of its checks without an observation is reported with a policy.ResultError subject and
+// the provider error in the PluginErrorProp.
+//
+// The rule set passed to each plugin can be configured with compliance specific settings based on the InputContext
+// and is sent with the InputContext.Metadata of its rules.
+func StreamResults(ctx context.Context, inputContext *InputContext, pluginSet map[plugin.ID]policy.Provider, handler ResultHandler) error {
+	log := logging.GetLogger("aggregator")
 
+	var mu sync.Mutex
 	eg, egCtx := errgroup.WithContext(ctx)
 	eg.SetLimit(inputContext.MaxConcurrency)
 	for providerId, policyPlugin := range pluginSet {
@@ -54,29 +95,101 @@ func AggregateResults(ctx context.Context, inputContext *InputContext, pluginSet
 					return fmt.Errorf("failed to get rule sets for component %s: %w", componentTitle, err)
 				}
 
-				pluginResults, err := policyPlugin.GetResults(egCtx, appliedRuleSet)
-				if err != nil {
+				// Track the checks with observations and errors returned by the handler
+				// to only report the remaining checks of a failed provider.
+				observed := make(map[string]struct{})
+				var handlerErr error
+				send := func(partial policy.PVPResult) error {
+					if providerId.Instance() != "" {
+						addInstanceProp(providerId, &partial)
+					}
+					mu.Lock()
+					defer mu.Unlock()
+					for _, obs := range partial.ObservationsByCheck {
+						observed[obs.CheckID] = struct{}{}
+					}
+					handlerErr = handler(providerId, partial)
+					return handlerErr
+				}
+
+				err = getResults(egCtx, inputContext.withMetadata(policyPlugin, appliedRuleSet), appliedRuleSet, send)
+				if err == nil || handlerErr != nil || !inputContext.ContinueOnError {
 					return err
 				}
-				resultChan <- pluginResults
-				return nil
+				log.Error(fmt.Sprintf("failed to get results for provider %s, reporting checks as errors: %v", providerId, err))
+				return send(errorResult(providerId, appliedRuleSet, observed, err))
 			})
 		}(providerId, policyPlugin)
 	}
 
-	go func() {
-		_ = eg.Wait()
-		close(resultChan)
-	}()
+	return eg.Wait()
+}
 
-	for result := range resultChan {
-		allResults = append(allResults, result)
+// getResults streams results from the provider if supported, otherwise
+// the unary GetResults() is used.
+func getResults(ctx context.Context, provider policy.Provider, appliedRuleSet policy.Policy, handler policy.ResultHandler) error {
+	if streamer, ok := provider.(policy.ResultStreamer); ok {
+		err := streamer.StreamResults(ctx, appliedRuleSet, handler)
+		if !errors.Is(err, plugin.ErrNotImplemented) {
+			return err
+		}
+		logging.GetLogger("aggregator").Debug("Provider does not support streaming results, falling back to GetResults")
+	}
+	pluginResults, err := provider.GetResults(ctx, appliedRuleSet)
+	if err != nil {
+		return err
 	}
+	return handler(pluginResults)
+}
 
-	// Calling Wait() again to avoid data races
-	if err := eg.Wait(); err != nil {
-		return allResults, err
+// errorResult creates observations with a policy.ResultError subject for each check
+// in the rule set without an observation. The provider is the subject of each observation
+// and the provider error is the reason. The error is also recorded in the PluginErrorProp.
+func errorResult(providerId plugin.ID, appliedRuleSet policy.Policy, observed map[string]struct{}, providerErr error) policy.PVPResult {
+	now := time.Now()
+	var result policy.PVPResult
+	for _, ruleSet := range appliedRuleSet {
+		for _, check := range ruleSet.Checks {
+			if _, ok := observed[check.ID]; ok {
+				continue
+			}
+			observed[check.ID] = struct{}{}
+			result.ObservationsByCheck = append(result.ObservationsByCheck, policy.ObservationByCheck{
+				Title:       check.ID,
+				Description: check.Description,
+				CheckID:     check.ID,
+				Methods:     []string{"AUTOMATED"},
+				Collected:   now,
+				Subjects: []policy.Subject{
+					{
+						Title:       providerId.String(),
+						Type:        Resource,
+						ResourceID:  providerId.String(),
+						Result:      policy.ResultError,
+						EvaluatedOn: now,
+						Reason:      providerErr.Error(),
+					},
+				},
+				Props: []policy.Property{
+					{
+						Name:  PluginErrorProp,
+						Value: fmt.Sprintf("plugin %s failed: %v", providerId, providerErr),
+					},
+				},
+			})
+		}
 	}
+	return result
+}
 
-	return allResults, nil
+// addInstanceProp identifies the plugin instance that produced each observation
+// in the result.
+func addInstanceProp(providerId plugin.ID, result *policy.PVPResult) {
+	for i := range result.ObservationsByCheck {
+		prop := policy.Property{
+			Name:  PluginInstanceProp,
+			Value: providerId.String(),
+		}
+		result.ObservationsByCheck[i].Props = append(result.ObservationsByCheck[i].Props, prop)
+	}
 }
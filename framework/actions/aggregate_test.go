@@ -97,6 +97,180 @@ func TestAggregateResults(t *testing.T) {
 	require.Len(t, gotResults, 1)
 }
 
+func TestStreamResults(t *testing.T) {
+	inputContext := inputContextHelper(t)
+	testSettings := settings.NewSettings(map[string]struct{}{"etcd_key_file": {}}, map[string]string{"file_name": "my_file"})
+	inputContext.Settings = testSettings
+
+	partials := []policy.PVPResult{
+		{ObservationsByCheck: []policy.ObservationByCheck{{Title: "Example 1", CheckID: "test-check-1"}}},
+		{ObservationsByCheck: []policy.ObservationByCheck{{Title: "Example 2", CheckID: "test-check-2"}}},
+	}
+
+	tests := []struct {
+		name      string
+		provider  policy.Provider
+		wantCount int
+		wantError string
+	}{
+		{
+			name: "Valid/Streaming",
+			provider: func() policy.Provider {
+				p := new(streamingProvider)
+				p.On("StreamResults", mock.Anything).Return(partials, nil)
+				return p
+			}(),
+			wantCount: 2,
+		},
+		{
+			name: "Valid/FallbackNotImplemented",
+			provider: func() policy.Provider {
+				p := new(streamingProvider)
+				p.On("StreamResults", mock.Anything).Return([]policy.PVPResult{}, plugin.ErrNotImplemented)
+				p.On("GetResults", mock.Anything).Return(partials[0], nil)
+				return p
+			}(),
+			wantCount: 1,
+		},
+		{
+			name: "Valid/NonStreamingProvider",
+			provider: func() policy.Provider {
+				p := new(policyProvider)
+				p.On("GetResults", mock.Anything).Return(partials[0], nil)
+				return p
+			}(),
+			wantCount: 1,
+		},
+		{
+			name: "Invalid/StreamError",
+			provider: func() policy.Provider {
+				p := new(streamingProvider)
+				p.On("StreamResults", mock.Anything).Return(partials[:1], errors.New("stream failed"))
+				return p
+			}(),
+			wantError: "stream failed",
+		},
+	}
+
+	for _, c := range tests {
+		t.Run(c.name, func(t *testing.T) {
+			pluginSet := map[plugin.ID]policy.Provider{
+				"mypvpvalidator": c.provider,
+			}
+			var received []policy.PVPResult
+			err := StreamResults(context.TODO(), inputContext, pluginSet, func(id plugin.ID, result policy.PVPResult) error {
+				require.Equal(t, plugin.ID("mypvpvalidator"), id)
+				received = append(received, result)
+				return nil
+			})
+			if c.wantError != "" {
+				require.EqualError(t, err, c.wantError)
+				return
+			}
+			require.NoError(t, err)
+			require.Len(t, received, c.wantCount)
+		})
+	}
+}
+
+func TestStreamResults_ContinueOnError(t *testing.T) {
+	inputContext := inputContextHelper(t)
+	inputContext.Settings = settings.NewSettings(map[string]struct{}{"etcd_key_file": {}, "etcd_cert_file": {}}, map[string]string{})
+	inputContext.ContinueOnError = true
+
+	tests := []struct {
+		name          string
+		partials      []policy.PVPResult
+		handlerErr    error
+		wantErrChecks []string
+		wantError     string
+	}{
+		{
+			name:          "Valid/NoResults",
+			wantErrChecks: []string{"etcd_cert_file", "etcd_key_file"},
+		},
+		{
+			name: "Valid/PartialResults",
+			partials: []policy.PVPResult{
+				{ObservationsByCheck: []policy.ObservationByCheck{{Title: "etcd_key_file", CheckID: "etcd_key_file"}}},
+			},
+			wantErrChecks: []string{"etcd_cert_file"},
+		},
+		{
+			name: "Invalid/HandlerError",
+			partials: []policy.PVPResult{
+				{ObservationsByCheck: []policy.ObservationByCheck{{Title: "etcd_key_file", CheckID: "etcd_key_file"}}},
+			},
+			handlerErr: errors.New("handler failed"),
+			wantError:  "handler failed",
+		},
+	}
+
+	for _, c := range tests {
+		t.Run(c.name, func(t *testing.T) {
+			provider := new(streamingProvider)
+			provider.On("StreamResults", mock.Anything).Return(c.partials, errors.New("stream failed"))
+			pluginSet := map[plugin.ID]policy.Provider{
+				"mypvpvalidator": provider,
+			}
+
+			var errChecks []string
+			err := StreamResults(context.TODO(), inputContext, pluginSet, func(id plugin.ID, result policy.PVPResult) error {
+				if c.handlerErr != nil {
+					return c.handlerErr
+				}
+				for _, obs := range result.ObservationsByCheck {
+					if len(obs.Subjects) == 1 && obs.Subjects[0].Result == policy.ResultError {
+						require.Equal(t, "stream failed", obs.Subjects[0].Reason)
+						require.Contains(t, obs.Props, policy.Property{Name: PluginErrorProp, Value: "plugin mypvpvalidator failed: stream failed"})
+						errChecks = append(errChecks, obs.CheckID)
+					}
+				}
+				return nil
+			})
+			if c.wantError != "" {
+				require.EqualError(t, err, c.wantError)
+				return
+			}
+			require.NoError(t, err)
+			sort.Strings(errChecks)
+			require.Equal(t, c.wantErrChecks, errChecks)
+		})
+	}
+}
+
+func TestStreamResults_Instances(t *testing.T) {
+	inputContext := NewContext(
+		map[plugin.ID]string{
+			"kyverno@prod":    "MyPVPValidator",
+			"kyverno@staging": "MyPVPValidator",
+			"kyverno":         "MyPVPValidator",
+		},
+		inputContextHelper(t).Store(),
+	)
+	inputContext.Settings = settings.NewSettings(map[string]struct{}{"etcd_key_file": {}}, map[string]string{})
+
+	pluginSet := make(map[plugin.ID]policy.Provider)
+	for id := range inputContext.requestedProviders {
+		provider := new(policyProvider)
+		result := policy.PVPResult{
+			ObservationsByCheck: []policy.ObservationByCheck{{Title: "etcd_key_file", CheckID: "etcd_key_file"}},
+		}
+		provider.On("GetResults", mock.Anything).Return(result, nil)
+		pluginSet[id] = provider
+	}
+
+	propsById := make(map[plugin.ID][]policy.Property)
+	err := StreamResults(context.TODO(), inputContext, pluginSet, func(id plugin.ID, result policy.PVPResult) error {
+		propsById[id] = result.ObservationsByCheck[0].Props
+		return nil
+	})
+	require.NoError(t, err)
+	require.Equal(t, []policy.Property{{Name: PluginInstanceProp, Value: "kyverno@prod"}}, propsById["kyverno@prod"])
+	require.Equal(t, []policy.Property{{Name: PluginInstanceProp, Value: "kyverno@staging"}}, propsById["kyverno@staging"])
+	require.Empty(t, propsById["kyverno"])
+}
+
 func TestAggregateResults_Multi(t *testing.T) {
 	testDataPath := utils.PathFromInternalDirectory("./testdata/oscal/component-definition-heterogeneous.json")
 	file, err := os.Open(testDataPath)
@@ -174,6 +348,8 @@ func TestAggregateResults_Multi(t *testing.T) {
 		providerTestObj.On("GetResults", policy.Policy{ocmRule}).Return(wantResults, nil)
 		providerTestObj3 := new(policyProvider)
 		providerTestObj3.On("GetResults", policy.Policy{kyvernoRule}).Return(policy.PVPResult{}, errors.New("failed"))
+		// Delay the failure so the healthy provider is not skipped by the cancellation.
+		providerTestObj3.delay = 100 * time.Millisecond
 
 		pluginSet := map[plugin.ID]policy.Provider{
 			"ocm":     providerTestObj,
@@ -190,6 +366,44 @@ func TestAggregateResults_Multi(t *testing.T) {
 		providerTestObj3.AssertExpectations(t)
 	})
 
+	t.Run("Continue On Error", func(t *testing.T) {
+		providerTestObj := new(policyProvider)
+		providerTestObj.On("GetResults", policy.Policy{ocmRule}).Return(wantResults, nil)
+		providerTestObj3 := new(policyProvider)
+		providerTestObj3.On("GetResults", policy.Policy{kyvernoRule}).Return(policy.PVPResult{}, errors.New("failed"))
+
+		pluginSet := map[plugin.ID]policy.Provider{
+			"ocm":     providerTestObj,
+			"kyverno": providerTestObj3,
+		}
+
+		tolerantContext := *inputContext
+		tolerantContext.ContinueOnError = true
+		gotResults, err := AggregateResults(context.Background(), &tolerantContext, pluginSet)
+		require.NoError(t, err)
+		require.Len(t, gotResults, 2)
+
+		var errorObservations []policy.ObservationByCheck
+		for _, result := range gotResults {
+			for _, obs := range result.ObservationsByCheck {
+				if obs.CheckID == "allowed-base-images" {
+					errorObservations = append(errorObservations, obs)
+				}
+			}
+		}
+		require.Len(t, errorObservations, 1)
+		require.Len(t, errorObservations[0].Subjects, 1)
+		subject := errorObservations[0].Subjects[0]
+		require.Equal(t, policy.ResultError, subject.Result)
+		require.Equal(t, "failed", subject.Reason)
+		require.Equal(t, "kyverno", subject.ResourceID)
+		require.Equal(t, Resource, subject.Type)
+		require.Equal(t, []policy.Property{{Name: PluginErrorProp, Value: "plugin kyverno failed: failed"}}, errorObservations[0].Props)
+
+		providerTestObj.AssertExpectations(t)
+		providerTestObj3.AssertExpectations(t)
+	})
+
 	t.Run("Cancellation Error", func(t *testing.T) {
 		providerTestObj := new(policyProvider)
 		// Mock a successful call that will be cancelled
@@ -228,12 +442,12 @@ func (p *policyProvider) Configure(_ context.Context, option map[string]string)
 	return args.Error(0)
 }
 
-func (p *policyProvider) Generate(_ context.Context, policyRules policy.Policy) error {
+func (p *policyProvider) Generate(_ context.Context, policyRules policy.Policy) ([]policy.Artifact, error) {
 	sort.SliceStable(policyRules, func(i, j int) bool {
 		return policyRules[i].Rule.ID > policyRules[j].Rule.ID
 	})
 	args := p.Called(policyRules)
-	return args.Error(0)
+	return args.Get(0).([]policy.Artifact), args.Error(1)
 }
 
 func (p *policyProvider) GetResults(ctx context.Context, policyRules policy.Policy) (policy.PVPResult, error) {
@@ -249,3 +463,19 @@ func (p *policyProvider) GetResults(ctx context.Context, policyRules policy.Poli
 		return args.Get(0).(policy.PVPResult), args.Error(1)
 	}
 }
+
+// streamingProvider is a mocked implementation of policy.Provider
+// that supports policy.ResultStreamer.
+type streamingProvider struct {
+	policyProvider
+}
+
+func (p *streamingProvider) StreamResults(_ context.Context, policyRules policy.Policy, handler policy.ResultHandler) error {
+	args := p.Called(policyRules)
+	for _, result := range args.Get(0).([]policy.PVPResult) {
+		if err := handler(result); err != nil {
+			return err
+		}
+	}
+	return args.Error(1)
+}
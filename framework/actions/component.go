@@ -0,0 +1,91 @@
+/*
+ Copyright 2025 The OSCAL Compass Authors
+ SPDX-License-Identifier: Apache-2.0
+*/
+
+package actions
+
+import (
+	"fmt"
+
+	"github.com/defenseunicorns/go-oscal/src/pkg/uuid"
+	oscalTypes "github.com/defenseunicorns/go-oscal/src/types/oscal-1-1-3"
+	"github.com/oscal-compass/oscal-sdk-go/extensions"
+
+	"github.com/oscal-compass/compliance-to-policy-go/v2/internal/utils"
+	"github.com/oscal-compass/compliance-to-policy-go/v2/policy"
+)
+
+// ValidationComponent creates an OSCAL validation component with the given title from the checks
+// listed by a policy.CheckLister.
+//
+// Each check is written as a rule set of properties grouped by remarks, the format read by
+// rules.MemoryStore. The parameters of a rule are only written with the first check of the rule.
+func ValidationComponent(title string, checks policy.Policy) oscalTypes.DefinedComponent {
+	var props []oscalTypes.Property
+	var ruleSetIdx int
+	addRuleSet := func(rule extensions.Rule, check *extensions.Check, withParameters bool) {
+		remarks := fmt.Sprintf("rule_set_%d", ruleSetIdx)
+		ruleSetIdx++
+		addProp := func(name, value string) {
+			props = append(props, oscalTypes.Property{
+				Name:    name,
+				Value:   value,
+				Ns:      extensions.TrestleNameSpace,
+				Remarks: remarks,
+			})
+		}
+		addProp(extensions.RuleIdProp, rule.ID)
+		if rule.Description != "" {
+			addProp(extensions.RuleDescriptionProp, rule.Description)
+		}
+		if check != nil {
+			addProp(extensions.CheckIdProp, check.ID)
+			addProp(extensions.CheckDescriptionProp, checkDescription(*check))
+		}
+		if !withParameters {
+			return
+		}
+		for i, parameter := range rule.Parameters {
+			// Multiple parameters are distinguished by a numerical suffix
+			suffix := ""
+			if len(rule.Parameters) > 1 {
+				suffix = fmt.Sprintf("_%d", i+1)
+			}
+			addProp(extensions.ParameterIdProp+suffix, parameter.ID)
+			if parameter.Description != "" {
+				addProp(extensions.ParameterDescriptionProp+suffix, parameter.Description)
+			}
+			if parameter.Value != "" {
+				addProp(extensions.ParameterDefaultProp+suffix, parameter.Value)
+			}
+		}
+	}
+
+	for _, ruleSet := range checks {
+		if len(ruleSet.Checks) == 0 {
+			addRuleSet(ruleSet.Rule, nil, true)
+			continue
+		}
+		for i := range ruleSet.Checks {
+			addRuleSet(ruleSet.Rule, &ruleSet.Checks[i], i == 0)
+		}
+	}
+
+	return oscalTypes.DefinedComponent{
+		UUID:        uuid.NewUUID(),
+		Type:        "validation",
+		Title:       title,
+		Description: fmt.Sprintf("Validation component for %s", title),
+		Props:       utils.NilIfEmpty(&props),
+	}
+}
+
+// checkDescription returns the check description or the check ID
+// if the check has no description.
+func checkDescription(check extensions.Check) string {
+	if check.Description != "" {
+		return check.Description
+	}
+	return check.ID
+}
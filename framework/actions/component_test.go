@@ -0,0 +1,63 @@
+/*
+ Copyright 2025 The OSCAL Compass Authors
+ SPDX-License-Identifier: Apache-2.0
+*/
+
+package actions
+
+import (
+	"context"
+	"sort"
+	"testing"
+
+	"github.com/oscal-compass/oscal-sdk-go/extensions"
+	"github.com/oscal-compass/oscal-sdk-go/models/components"
+	"github.com/oscal-compass/oscal-sdk-go/rules"
+	"github.com/stretchr/testify/require"
+
+	"github.com/oscal-compass/compliance-to-policy-go/v2/policy"
+)
+
+func TestValidationComponent(t *testing.T) {
+	checks := policy.Policy{
+		{
+			Rule: extensions.Rule{
+				ID:          "etcd_cert_file",
+				Description: "Ensure that the --cert-file argument is set as appropriate",
+				Parameters: []extensions.Parameter{
+					{ID: "file_name", Description: "Certificate file name", Value: "etcd.crt"},
+					{ID: "file_owner", Description: "Certificate file owner"},
+				},
+			},
+			Checks: []extensions.Check{
+				{ID: "etcd_cert_file", Description: "Check the etcd certificate file"},
+				{ID: "etcd_cert_file_owner"},
+			},
+		},
+		{
+			Rule:   extensions.Rule{ID: "etcd_key_file"},
+			Checks: []extensions.Check{{ID: "etcd_key_file"}},
+		},
+	}
+
+	component := ValidationComponent("Kyverno", checks)
+	require.Equal(t, "validation", component.Type)
+	require.Equal(t, "Kyverno", component.Title)
+	require.NotNil(t, component.Props)
+
+	// The component can be read back as rule sets
+	store := rules.NewMemoryStore()
+	require.NoError(t, store.IndexAll([]components.Component{components.NewDefinedComponentAdapter(component)}))
+	ruleSets, err := store.FindByComponent(context.TODO(), "Kyverno")
+	require.NoError(t, err)
+	sort.Slice(ruleSets, func(i, j int) bool { return ruleSets[i].Rule.ID < ruleSets[j].Rule.ID })
+	for _, ruleSet := range ruleSets {
+		sort.Slice(ruleSet.Checks, func(i, j int) bool { return ruleSet.Checks[i].ID < ruleSet.Checks[j].ID })
+		sort.Slice(ruleSet.Rule.Parameters, func(i, j int) bool { return ruleSet.Rule.Parameters[i].ID < ruleSet.Rule.Parameters[j].ID })
+	}
+
+	// Checks without a description use the check ID
+	checks[0].Checks[1].Description = "etcd_cert_file_owner"
+	checks[1].Checks[0].Description = "etcd_key_file"
+	require.Equal(t, []extensions.RuleSet(checks), ruleSets)
+}
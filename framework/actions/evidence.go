@@ -0,0 +1,170 @@
+/*
+ Copyright 2025 The OSCAL Compass Authors
+ SPDX-License-Identifier: Apache-2.0
+*/
+
+package actions
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io/fs"
+	"mime"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/defenseunicorns/go-oscal/src/pkg/uuid"
+	oscalTypes "github.com/defenseunicorns/go-oscal/src/types/oscal-1-1-3"
+	"github.com/oscal-compass/oscal-sdk-go/extensions"
+
+	"github.com/oscal-compass/compliance-to-policy-go/v2/logging"
+	"github.com/oscal-compass/compliance-to-policy-go/v2/policy"
+)
+
+// evidenceHashAlgorithm is the OSCAL hash algorithm of embedded evidence.
+const evidenceHashAlgorithm = "SHA-256"
+
+// EvidenceHashProp is the back-matter resource property with the SHA-256
+// hash of the embedded evidence.
+const EvidenceHashProp = "evidence-sha256"
+
+// evidenceResources holds the back-matter resources for the evidence
+// embedded in the assessment results.
+type evidenceResources struct {
+	resources []oscalTypes.Resource
+	// maps the name and sha256 hash of the evidence to the
+	// index of the resource to embed identical evidence once.
+	indexByKey map[string]int
+}
+
+func newEvidenceResources() *evidenceResources {
+	return &evidenceResources{indexByKey: make(map[string]int)}
+}
+
+// add embeds the evidence as a back-matter resource and returns a relevant
+// evidence that references the resource. The href is the original location
+// of the evidence, if any, and is linked from the resource.
+func (e *evidenceResources) add(evidence policy.Evidence, href string) oscalTypes.RelevantEvidence {
+	sum := sha256.Sum256(evidence.Content)
+	hash := hex.EncodeToString(sum[:])
+	description := evidence.Description
+	if description == "" {
+		description = evidence.Name
+	}
+
+	key := evidence.Name + "/" + hash
+	index, ok := e.indexByKey[key]
+	if !ok {
+		index = len(e.resources)
+		e.indexByKey[key] = index
+		e.resources = append(e.resources, oscalTypes.Resource{
+			UUID:        uuid.NewUUID(),
+			Title:       evidence.Name,
+			Description: evidence.Description,
+			Props: &[]oscalTypes.Property{
+				{
+					Name:  EvidenceHashProp,
+					Value: hash,
+					Ns:    extensions.TrestleNameSpace,
+				},
+			},
+			Base64: &oscalTypes.Base64{
+				Filename:  evidence.Name,
+				MediaType: evidence.MediaType,
+				Value:     base64.StdEncoding.EncodeToString(evidence.Content),
+			},
+		})
+	}
+	resource := &e.resources[index]
+	if href != "" && !hasResourceLink(resource, href) {
+		rlinks := []oscalTypes.ResourceLink{}
+		if resource.Rlinks != nil {
+			rlinks = *resource.Rlinks
+		}
+		rlinks = append(rlinks, oscalTypes.ResourceLink{
+			Href:      href,
+			MediaType: evidence.MediaType,
+			Hashes: &[]oscalTypes.Hash{
+				{
+					Algorithm: evidenceHashAlgorithm,
+					Value:     hash,
+				},
+			},
+		})
+		resource.Rlinks = &rlinks
+	}
+	return oscalTypes.RelevantEvidence{
+		Description: description,
+		Href:        "#" + resource.UUID,
+	}
+}
+
+func hasResourceLink(resource *oscalTypes.Resource, href string) bool {
+	if resource.Rlinks == nil {
+		return false
+	}
+	for _, rlink := range *resource.Rlinks {
+		if rlink.Href == href {
+			return true
+		}
+	}
+	return false
+}
+
+// loadLinkedEvidence reads the evidence file of the link if it is in the
+// evidence directory. Relative links are resolved against the evidence
+// directory. Links to other locations, including symbolic links that
+// point outside the evidence directory, are not read. Links to missing
+// files are kept with a warning.
+func loadLinkedEvidence(evidenceDir string, link oscalTypes.RelevantEvidence) (policy.Evidence, bool, error) {
+	if evidenceDir == "" || link.Href == "" || strings.HasPrefix(link.Href, "#") || strings.Contains(link.Href, "://") {
+		return policy.Evidence{}, false, nil
+	}
+	dir, err := filepath.Abs(evidenceDir)
+	if err != nil {
+		return policy.Evidence{}, false, err
+	}
+	resolvedDir, err := filepath.EvalSymlinks(dir)
+	if err != nil {
+		return policy.Evidence{}, false, fmt.Errorf("failed to resolve evidence directory %s: %w", evidenceDir, err)
+	}
+	path := filepath.FromSlash(link.Href)
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(dir, path)
+	}
+	// Check the link before resolving it to not touch other locations.
+	if !withinDir(dir, path) && !withinDir(resolvedDir, path) {
+		return policy.Evidence{}, false, nil
+	}
+	resolved, err := filepath.EvalSymlinks(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		logging.GetLogger("reporter").Warn(fmt.Sprintf("keeping link to missing evidence %s", link.Href))
+		return policy.Evidence{}, false, nil
+	}
+	if err != nil {
+		return policy.Evidence{}, false, fmt.Errorf("failed to read evidence %s: %w", link.Href, err)
+	}
+	if !withinDir(resolvedDir, resolved) {
+		return policy.Evidence{}, false, nil
+	}
+	content, err := os.ReadFile(resolved)
+	if err != nil {
+		return policy.Evidence{}, false, fmt.Errorf("failed to read evidence %s: %w", link.Href, err)
+	}
+	return policy.Evidence{
+		Name:        filepath.Base(path),
+		Description: link.Description,
+		MediaType:   mime.TypeByExtension(filepath.Ext(path)),
+		Content:     content,
+	}, true, nil
+}
+
+// withinDir returns whether the path is in the directory.
+func withinDir(dir, path string) bool {
+	rel, err := filepath.Rel(dir, path)
+	return err == nil && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator))
+}
@@ -9,22 +9,33 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"path"
+	"sort"
+	"sync"
 
+	"github.com/defenseunicorns/go-oscal/src/pkg/uuid"
+	oscalTypes "github.com/defenseunicorns/go-oscal/src/types/oscal-1-1-3"
+	"github.com/oscal-compass/oscal-sdk-go/extensions"
 	"github.com/oscal-compass/oscal-sdk-go/settings"
 	"golang.org/x/sync/errgroup"
 
+	"github.com/oscal-compass/compliance-to-policy-go/v2/internal/utils"
 	"github.com/oscal-compass/compliance-to-policy-go/v2/logging"
 	"github.com/oscal-compass/compliance-to-policy-go/v2/plugin"
 	"github.com/oscal-compass/compliance-to-policy-go/v2/policy"
 )
 
 // GeneratePolicy action identifies policy configuration for each provider in the given pluginSet to execute the Generate() method
-// each policy.Provider.
+// each policy.Provider. The generated artifacts are returned by provider.
 //
-// The rule set passed to each plugin can be configured with compliance specific settings based on the InputContext.
-func GeneratePolicy(ctx context.Context, inputContext *InputContext, pluginSet map[plugin.ID]policy.Provider) error {
+// The rule set passed to each plugin can be configured with compliance specific settings based on the InputContext
+// and is sent with the InputContext.Metadata of its rules.
+func GeneratePolicy(ctx context.Context, inputContext *InputContext, pluginSet map[plugin.ID]policy.Provider) (map[plugin.ID][]policy.Artifact, error) {
 	log := logging.GetLogger("generator")
 
+	var mu sync.Mutex
+	artifactsByProvider := make(map[plugin.ID][]policy.Artifact)
+
 	eg, egCtx := errgroup.WithContext(ctx)
 	eg.SetLimit(inputContext.MaxConcurrency)
 	for providerId, policyPlugin := range pluginSet {
@@ -49,13 +60,146 @@ func GeneratePolicy(ctx context.Context, inputContext *InputContext, pluginSet m
 				if err != nil {
 					return fmt.Errorf("failed to get rule sets for component %s: %w", componentTitle, err)
 				}
-				if err := policyPlugin.Generate(egCtx, appliedRuleSet); err != nil {
+				artifacts, err := inputContext.withMetadata(policyPlugin, appliedRuleSet).Generate(egCtx, appliedRuleSet)
+				if err != nil {
 					return fmt.Errorf("plugin %s: %w", providerId, err)
 				}
+				mu.Lock()
+				artifactsByProvider[providerId] = artifacts
+				mu.Unlock()
 				return nil
 			})
 		}(providerId, policyPlugin)
 	}
 
-	return eg.Wait()
+	if err := eg.Wait(); err != nil {
+		return artifactsByProvider, err
+	}
+	return artifactsByProvider, nil
+}
+
+// ValidatePolicy action runs the Validate() method of each policy.Validator in the given pluginSet
+// with the same rule sets as GeneratePolicy. The diagnostics are returned by provider, so all problems
+// can be reported before any policy artifacts are generated.
+//
+// Providers that do not implement policy.Validator are skipped.
+func ValidatePolicy(ctx context.Context, inputContext *InputContext, pluginSet map[plugin.ID]policy.Provider) (map[plugin.ID][]policy.Diagnostic, error) {
+	log := logging.GetLogger("validator")
+
+	var mu sync.Mutex
+	diagnosticsByProvider := make(map[plugin.ID][]policy.Diagnostic)
+
+	eg, egCtx := errgroup.WithContext(ctx)
+	eg.SetLimit(inputContext.MaxConcurrency)
+	for providerId, policyPlugin := range pluginSet {
+		func(providerId plugin.ID, provider policy.Provider) {
+			eg.Go(func() error {
+				select {
+				case <-egCtx.Done():
+					return fmt.Errorf("%s skipped due to context cancellation/timeout: %w", providerId.String(), egCtx.Err())
+				default:
+				}
+				validator, ok := provider.(policy.Validator)
+				if !ok {
+					log.Warn(fmt.Sprintf("skipping %s provider: validation is not supported", providerId))
+					return nil
+				}
+				componentTitle, err := inputContext.ProviderTitle(providerId)
+				if err != nil {
+					if errors.Is(err, ErrMissingProvider) {
+						log.Warn(fmt.Sprintf("skipping %s provider: missing validation component", providerId))
+						return nil
+					}
+					return err
+				}
+				log.Debug(fmt.Sprintf("Validating policy for provider %s", providerId))
+
+				appliedRuleSet, err := settings.ApplyToComponent(ctx, componentTitle, inputContext.Store(), inputContext.Settings)
+				if err != nil {
+					return fmt.Errorf("failed to get rule sets for component %s: %w", componentTitle, err)
+				}
+				if bound, ok := inputContext.withMetadata(provider, appliedRuleSet).(policy.Validator); ok {
+					validator = bound
+				}
+				diagnostics, err := validator.Validate(egCtx, appliedRuleSet)
+				if err != nil {
+					if errors.Is(err, plugin.ErrNotImplemented) {
+						log.Warn(fmt.Sprintf("skipping %s provider: validation is not supported", providerId))
+						return nil
+					}
+					return fmt.Errorf("plugin %s: %w", providerId, err)
+				}
+				mu.Lock()
+				diagnosticsByProvider[providerId] = diagnostics
+				mu.Unlock()
+				return nil
+			})
+		}(providerId, policyPlugin)
+	}
+
+	if err := eg.Wait(); err != nil {
+		return diagnosticsByProvider, err
+	}
+	return diagnosticsByProvider, nil
+}
+
+// ArtifactPath returns the path of the artifact relative to the output directory
+// with the artifacts for each provider grouped by plugin.ID.
+func ArtifactPath(providerId plugin.ID, artifact policy.Artifact) string {
+	return path.Join(providerId.String(), artifact.Path)
+}
+
+// ArtifactResources converts the generated artifacts into OSCAL back-matter resources.
+// Each resource links to the artifact path with the artifact SHA-256 hash and references
+// the originating rules and checks as properties.
+func ArtifactResources(artifactsByProvider map[plugin.ID][]policy.Artifact) []oscalTypes.Resource {
+	providerIds := make([]plugin.ID, 0, len(artifactsByProvider))
+	for providerId := range artifactsByProvider {
+		providerIds = append(providerIds, providerId)
+	}
+	sort.Slice(providerIds, func(i, j int) bool { return providerIds[i] < providerIds[j] })
+
+	var resources []oscalTypes.Resource
+	for _, providerId := range providerIds {
+		for _, artifact := range artifactsByProvider[providerId] {
+			rlink := oscalTypes.ResourceLink{
+				Href:      ArtifactPath(providerId, artifact),
+				MediaType: artifact.MediaType,
+			}
+			if artifact.Checksum != "" {
+				rlink.Hashes = &[]oscalTypes.Hash{
+					{
+						Algorithm: "SHA-256",
+						Value:     artifact.Checksum,
+					},
+				}
+			}
+
+			var props []oscalTypes.Property
+			for _, ruleId := range artifact.RuleIDs {
+				props = append(props, oscalTypes.Property{
+					Name:  extensions.AssessmentRuleIdProp,
+					Value: ruleId,
+					Ns:    extensions.TrestleNameSpace,
+				})
+			}
+			for _, checkId := range artifact.CheckIDs {
+				props = append(props, oscalTypes.Property{
+					Name:  extensions.AssessmentCheckIdProp,
+					Value: checkId,
+					Ns:    extensions.TrestleNameSpace,
+				})
+			}
+
+			resource := oscalTypes.Resource{
+				UUID:        uuid.NewUUID(),
+				Title:       artifact.Path,
+				Description: fmt.Sprintf("Policy artifact generated by %s", providerId),
+				Rlinks:      &[]oscalTypes.ResourceLink{rlink},
+				Props:       utils.NilIfEmpty(&props),
+			}
+			resources = append(resources, resource)
+		}
+	}
+	return resources
 }
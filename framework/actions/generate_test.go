@@ -9,6 +9,7 @@ import (
 	"context"
 	"testing"
 
+	oscalTypes "github.com/defenseunicorns/go-oscal/src/types/oscal-1-1-3"
 	"github.com/oscal-compass/oscal-sdk-go/settings"
 	"github.com/stretchr/testify/require"
 
@@ -20,8 +21,11 @@ func TestGeneratePolicy(t *testing.T) {
 	inputContext := inputContextHelper(t)
 
 	// Create pluginSet
+	wantArtifacts := []policy.Artifact{
+		policy.NewArtifact("etcd_cert_file.yaml", "application/yaml", []byte("test")),
+	}
 	providerTestObj := new(policyProvider)
-	providerTestObj.On("Generate", policy.Policy{expectedCertFileRule}).Return(nil)
+	providerTestObj.On("Generate", policy.Policy{expectedCertFileRule}).Return(wantArtifacts, nil)
 	pluginSet := map[plugin.ID]policy.Provider{
 		"mypvpvalidator": providerTestObj,
 	}
@@ -29,7 +33,80 @@ func TestGeneratePolicy(t *testing.T) {
 	testSettings := settings.NewSettings(map[string]struct{}{"etcd_cert_file": {}}, map[string]string{})
 	inputContext.Settings = testSettings
 
-	err := GeneratePolicy(context.TODO(), inputContext, pluginSet)
+	artifactsByProvider, err := GeneratePolicy(context.TODO(), inputContext, pluginSet)
+	require.NoError(t, err)
+	providerTestObj.AssertExpectations(t)
+	require.Equal(t, map[plugin.ID][]policy.Artifact{"mypvpvalidator": wantArtifacts}, artifactsByProvider)
+}
+
+func TestValidatePolicy(t *testing.T) {
+	inputContext := inputContextHelper(t)
+	inputContext.Settings = settings.NewSettings(map[string]struct{}{"etcd_cert_file": {}}, map[string]string{})
+
+	wantDiagnostics := []policy.Diagnostic{
+		{
+			RuleID:  "etcd_cert_file",
+			CheckID: "etcd_cert_file",
+			Type:    policy.DiagnosticUnknownCheck,
+			Message: "no policy found for check etcd_cert_file",
+		},
+	}
+	validatorTestObj := new(validatingProvider)
+	validatorTestObj.On("Validate", policy.Policy{expectedCertFileRule}).Return(wantDiagnostics, nil)
+	// Providers without validation support are skipped
+	providerTestObj := new(policyProvider)
+	pluginSet := map[plugin.ID]policy.Provider{
+		"mypvpvalidator":      validatorTestObj,
+		"mypvpvalidator@prod": providerTestObj,
+	}
+
+	diagnosticsByProvider, err := ValidatePolicy(context.TODO(), inputContext, pluginSet)
 	require.NoError(t, err)
+	validatorTestObj.AssertExpectations(t)
 	providerTestObj.AssertExpectations(t)
+	require.Equal(t, map[plugin.ID][]policy.Diagnostic{"mypvpvalidator": wantDiagnostics}, diagnosticsByProvider)
+
+	// Providers that report validation as not implemented are skipped
+	notImplementedObj := new(validatingProvider)
+	notImplementedObj.On("Validate", policy.Policy{expectedCertFileRule}).Return([]policy.Diagnostic(nil), plugin.ErrNotImplemented)
+	diagnosticsByProvider, err = ValidatePolicy(context.TODO(), inputContext, map[plugin.ID]policy.Provider{"mypvpvalidator": notImplementedObj})
+	require.NoError(t, err)
+	require.Empty(t, diagnosticsByProvider)
+}
+
+func TestArtifactResources(t *testing.T) {
+	artifact := policy.NewArtifact("etcd/policy.yaml", "application/yaml", []byte("test"))
+	artifact.RuleIDs = []string{"etcd_cert_file"}
+	artifact.CheckIDs = []string{"etcd_cert_file"}
+	artifactsByProvider := map[plugin.ID][]policy.Artifact{
+		"mypvpvalidator": {artifact},
+	}
+
+	resources := ArtifactResources(artifactsByProvider)
+	require.Len(t, resources, 1)
+	require.Equal(t, "etcd/policy.yaml", resources[0].Title)
+
+	rlinks := *resources[0].Rlinks
+	require.Len(t, rlinks, 1)
+	require.Equal(t, "mypvpvalidator/etcd/policy.yaml", rlinks[0].Href)
+	require.Equal(t, "application/yaml", rlinks[0].MediaType)
+	wantHashes := []oscalTypes.Hash{
+		{
+			Algorithm: "SHA-256",
+			Value:     "9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08",
+		},
+	}
+	require.Equal(t, wantHashes, *rlinks[0].Hashes)
+	require.Len(t, *resources[0].Props, 2)
+}
+
+// validatingProvider is a mocked implementation of policy.Provider
+// that supports policy.Validator.
+type validatingProvider struct {
+	policyProvider
+}
+
+func (p *validatingProvider) Validate(_ context.Context, policyRules policy.Policy) ([]policy.Diagnostic, error) {
+	args := p.Called(policyRules)
+	return args.Get(0).([]policy.Diagnostic), args.Error(1)
 }
@@ -10,15 +10,22 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/oscal-compass/oscal-sdk-go/extensions"
 	"github.com/oscal-compass/oscal-sdk-go/models/components"
 	"github.com/oscal-compass/oscal-sdk-go/rules"
 	"github.com/oscal-compass/oscal-sdk-go/settings"
 
 	"github.com/oscal-compass/compliance-to-policy-go/v2/plugin"
+	"github.com/oscal-compass/compliance-to-policy-go/v2/policy"
 )
 
 const pluginComponentType = "validation"
 
+// PluginIDProp is the property on a validation component that explicitly
+// defines the plugin ID for the component. This takes precedence over the
+// component title.
+const PluginIDProp = "plugin-id"
+
 var ErrMissingProvider = errors.New("missing title for provider")
 
 // InputContext is used to configure action behavior from parsed OSCAL documents.
@@ -35,8 +42,14 @@ type InputContext struct {
 	rulesStore rules.Store
 	// Settings define adjustable rule settings parsed from framework-specific implementation
 	Settings settings.Settings
+	// Metadata is the rule metadata sent to each policy.Provider
+	// for the applied rule sets of each call.
+	Metadata policy.Metadata
 	// action concurrency
 	MaxConcurrency int
+	// ContinueOnError reports the checks of a failed provider with the
+	// provider error instead of failing the action.
+	ContinueOnError bool
 }
 
 func NewContext(providers map[plugin.ID]string, store rules.Store) *InputContext {
@@ -47,15 +60,47 @@ func NewContext(providers map[plugin.ID]string, store rules.Store) *InputContext
 	}
 }
 
+type contextOptions struct {
+	pluginAliases map[string]plugin.ID
+}
+
+// ContextOption configures how an InputContext is created from OSCAL Components.
+type ContextOption func(options *contextOptions)
+
+// WithPluginAliases maps validation component titles to plugin IDs. Titles are
+// matched case-insensitively.
+func WithPluginAliases(aliases map[string]plugin.ID) ContextOption {
+	return func(options *contextOptions) {
+		for title, id := range aliases {
+			options.pluginAliases[normalizeTitle(title)] = id
+		}
+	}
+}
+
 // NewContextFromComponents returns an InputContext for the given OSCAL Components.
-func NewContextFromComponents(components []components.Component) (*InputContext, error) {
+//
+// The plugin ID for each validation component is resolved in the following order:
+//  1. The value of the PluginIDProp property on the component
+//  2. The plugin alias for the component title set with WithPluginAliases
+//  3. The normalized component title
+func NewContextFromComponents(components []components.Component, opts ...ContextOption) (*InputContext, error) {
+	options := &contextOptions{
+		pluginAliases: make(map[string]plugin.ID),
+	}
+	for _, opt := range opts {
+		opt(options)
+	}
+
 	requestedProviders := make(map[plugin.ID]string)
 	for _, comp := range components {
 		if comp.Type() == pluginComponentType {
-			pluginId, err := GetPluginIDFromComponent(comp)
+			pluginId, err := getPluginID(comp, options.pluginAliases)
 			if err != nil {
 				return nil, err
 			}
+			if title, ok := requestedProviders[pluginId]; ok && title != comp.Title() {
+				return nil, fmt.Errorf("validation components %q and %q resolve to the same plugin id %s", title, comp.Title(), pluginId)
+			}
 			requestedProviders[pluginId] = comp.Title()
 		}
 	}
@@ -63,7 +108,9 @@ func NewContextFromComponents(components []components.Component) (*InputContext,
 	if err != nil {
 		return nil, err
 	}
-	return NewContext(requestedProviders, store), nil
+	inputContext := NewContext(requestedProviders, store)
+	inputContext.Metadata = NewMetadataFromComponents(components)
+	return inputContext, nil
 }
 
 // RequestedProviders returns the provider ids requested in the parsed input.
@@ -84,6 +131,12 @@ func (t *InputContext) ProviderTitle(providerId plugin.ID) (string, error) {
 	return title, nil
 }
 
+// withMetadata returns the policy.Provider with the rule Metadata
+// for the applied rule sets.
+func (t *InputContext) withMetadata(provider policy.Provider, appliedRuleSet []extensions.RuleSet) policy.Provider {
+	return plugin.WithMetadata(provider, RuleSetMetadata(t.Metadata, appliedRuleSet))
+}
+
 // Store returns the underlying rules.Store with indexed RuleSets.
 func (t *InputContext) Store() rules.Store {
 	return t.rulesStore
@@ -101,13 +154,35 @@ func DefaultStore(allComponents []components.Component) (*rules.MemoryStore, err
 }
 
 // GetPluginIDFromComponent returns the normalized plugin identifier defined by the OSCAL Component
-// of type "validation".
+// of type "validation". The PluginIDProp property takes precedence over the component title.
 func GetPluginIDFromComponent(component components.Component) (plugin.ID, error) {
+	return getPluginID(component, nil)
+}
+
+func getPluginID(component components.Component, aliases map[string]plugin.ID) (plugin.ID, error) {
+	for _, prop := range component.Props() {
+		if prop.Name != PluginIDProp {
+			continue
+		}
+		id := plugin.ID(strings.TrimSpace(prop.Value))
+		if !id.Validate() {
+			return "", fmt.Errorf("invalid plugin id %s in %s property", id, PluginIDProp)
+		}
+		return id, nil
+	}
+
 	title := strings.TrimSpace(component.Title())
 	if title == "" {
 		return "", fmt.Errorf("component is missing a title")
 	}
 
+	if id, ok := aliases[normalizeTitle(title)]; ok {
+		if !id.Validate() {
+			return "", fmt.Errorf("invalid plugin id %s in alias for %s", id, title)
+		}
+		return id, nil
+	}
+
 	title = strings.ToLower(title)
 	id := plugin.ID(title)
 	if !id.Validate() {
@@ -115,3 +190,7 @@ func GetPluginIDFromComponent(component components.Component) (plugin.ID, error)
 	}
 	return id, nil
 }
+
+func normalizeTitle(title string) string {
+	return strings.ToLower(strings.TrimSpace(title))
+}
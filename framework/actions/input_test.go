@@ -50,6 +50,42 @@ func TestGetPluginIDFromComponent(t *testing.T) {
 			expected:  "myplugin",
 			wantError: "",
 		},
+		{
+			name: "Valid/Instance",
+			component: oscalTypes.DefinedComponent{
+				Title: "MyPlugin@Prod",
+			},
+			expected:  "myplugin@prod",
+			wantError: "",
+		},
+		{
+			name: "Valid/PluginIDProp",
+			component: oscalTypes.DefinedComponent{
+				Title: "Kyverno Prod Cluster",
+				Props: &[]oscalTypes.Property{
+					{
+						Name:  PluginIDProp,
+						Value: "kyverno@prod",
+					},
+				},
+			},
+			expected:  "kyverno@prod",
+			wantError: "",
+		},
+		{
+			name: "Invalid/PluginIDProp",
+			component: oscalTypes.DefinedComponent{
+				Title: "myplugin",
+				Props: &[]oscalTypes.Property{
+					{
+						Name:  PluginIDProp,
+						Value: "My Plugin",
+					},
+				},
+			},
+			expected:  "",
+			wantError: "invalid plugin id My Plugin in plugin-id property",
+		},
 		{
 			name: "Invalid/PluginNotMatchPattern",
 			component: oscalTypes.DefinedComponent{
@@ -82,6 +118,80 @@ func TestGetPluginIDFromComponent(t *testing.T) {
 	}
 }
 
+func TestNewContextFromComponents_PluginAliases(t *testing.T) {
+	newComponent := func(title string, props ...oscalTypes.Property) components.Component {
+		comp := oscalTypes.DefinedComponent{
+			Title: title,
+			Type:  pluginComponentType,
+		}
+		if len(props) > 0 {
+			comp.Props = &props
+		}
+		return components.NewDefinedComponentAdapter(comp)
+	}
+
+	tests := []struct {
+		name          string
+		components    []components.Component
+		aliases       map[string]plugin.ID
+		wantProviders map[plugin.ID]string
+		wantError     string
+	}{
+		{
+			name: "Valid/Aliases",
+			components: []components.Component{
+				newComponent("Kyverno Prod Cluster"),
+				newComponent("Kyverno Staging Cluster"),
+				newComponent("ocm"),
+			},
+			aliases: map[string]plugin.ID{
+				"kyverno prod cluster":    "kyverno@prod",
+				"Kyverno Staging Cluster": "kyverno@staging",
+			},
+			wantProviders: map[plugin.ID]string{
+				"kyverno@prod":    "Kyverno Prod Cluster",
+				"kyverno@staging": "Kyverno Staging Cluster",
+				"ocm":             "ocm",
+			},
+		},
+		{
+			name: "Valid/PropTakesPrecedence",
+			components: []components.Component{
+				newComponent("Kyverno Prod Cluster", oscalTypes.Property{Name: PluginIDProp, Value: "kyverno"}),
+			},
+			aliases: map[string]plugin.ID{
+				"Kyverno Prod Cluster": "kyverno@prod",
+			},
+			wantProviders: map[plugin.ID]string{
+				"kyverno": "Kyverno Prod Cluster",
+			},
+		},
+		{
+			name: "Invalid/DuplicatePluginID",
+			components: []components.Component{
+				newComponent("Kyverno Prod Cluster"),
+				newComponent("kyverno"),
+			},
+			aliases: map[string]plugin.ID{
+				"Kyverno Prod Cluster": "kyverno",
+			},
+			wantError: "validation components \"Kyverno Prod Cluster\" and \"kyverno\" resolve to the same plugin id kyverno",
+		},
+	}
+
+	for _, c := range tests {
+		t.Run(c.name, func(t *testing.T) {
+			inputContext, err := NewContextFromComponents(c.components, WithPluginAliases(c.aliases))
+			if c.wantError != "" {
+				require.EqualError(t, err, c.wantError)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, c.wantProviders, inputContext.requestedProviders)
+		})
+	}
+}
+
 // inputContextHelper to support other testing in the package
 func inputContextHelper(t *testing.T) *InputContext {
 	testDataPath := utils.PathFromInternalDirectory("./testdata/oscal/component-definition-test.json")
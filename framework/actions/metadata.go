@@ -0,0 +1,223 @@
+/*
+ Copyright 2025 The OSCAL Compass Authors
+ SPDX-License-Identifier: Apache-2.0
+*/
+
+package actions
+
+import (
+	"regexp"
+	"slices"
+	"strings"
+
+	oscalTypes "github.com/defenseunicorns/go-oscal/src/types/oscal-1-1-3"
+	"github.com/oscal-compass/oscal-sdk-go/extensions"
+	"github.com/oscal-compass/oscal-sdk-go/models/components"
+
+	"github.com/oscal-compass/compliance-to-policy-go/v2/policy"
+)
+
+// ParameterAlternativesProp is the property name for the comma-separated
+// allowed values of a rule parameter.
+const ParameterAlternativesProp = "Parameter_Value_Alternatives"
+
+// parameterPropRegexp matches the parameter properties with an optional
+// numerical suffix, e.g. Parameter_Id_1.
+var parameterPropRegexp = regexp.MustCompile(`^(Parameter_.*?)(_\d+)?$`)
+
+// NewMetadataFromComponents returns the rule Metadata from the rule set properties of the
+// OSCAL Components that are not part of extensions.RuleSet.
+//
+// Properties are grouped into rule sets by remarks. Additional properties in a rule set
+// with a Check_Id are added to the check and all other additional properties are added to
+// the rule. The ParameterAlternativesProp values are added to the parameter with the same
+// numerical suffix.
+func NewMetadataFromComponents(allComponents []components.Component) policy.Metadata {
+	metadata := make(policy.Metadata)
+	for _, component := range allComponents {
+		for _, props := range groupRuleSetProps(component.Props()) {
+			addRuleSetMetadata(metadata, props)
+		}
+	}
+	if len(metadata) == 0 {
+		return nil
+	}
+	return metadata
+}
+
+// groupRuleSetProps returns the properties grouped by remarks in
+// the order they are defined.
+func groupRuleSetProps(props []oscalTypes.Property) [][]oscalTypes.Property {
+	var groups [][]oscalTypes.Property
+	groupIdx := make(map[string]int)
+	for _, prop := range props {
+		if prop.Remarks == "" {
+			continue
+		}
+		idx, ok := groupIdx[prop.Remarks]
+		if !ok {
+			idx = len(groups)
+			groupIdx[prop.Remarks] = idx
+			groups = append(groups, nil)
+		}
+		groups[idx] = append(groups[idx], prop)
+	}
+	return groups
+}
+
+func addRuleSetMetadata(metadata policy.Metadata, props []oscalTypes.Property) {
+	var ruleID, checkID string
+	parameterIDs := make(map[string]string)
+	alternatives := make(map[string][]string)
+	var additional []policy.Property
+	for _, prop := range props {
+		switch prop.Name {
+		case extensions.RuleIdProp:
+			ruleID = prop.Value
+			continue
+		case extensions.CheckIdProp:
+			checkID = prop.Value
+			continue
+		case extensions.RuleDescriptionProp, extensions.CheckDescriptionProp:
+			continue
+		}
+		if match := parameterPropRegexp.FindStringSubmatch(prop.Name); match != nil {
+			switch match[1] {
+			case extensions.ParameterIdProp:
+				parameterIDs[match[2]] = prop.Value
+			case ParameterAlternativesProp:
+				alternatives[match[2]] = splitAlternatives(prop.Value)
+			}
+			continue
+		}
+		additional = append(additional, policy.Property{Name: prop.Name, Value: prop.Value})
+	}
+	if ruleID == "" {
+		return
+	}
+
+	ruleMetadata := metadata[ruleID]
+	if checkID != "" && len(additional) > 0 {
+		if ruleMetadata.CheckProps == nil {
+			ruleMetadata.CheckProps = make(map[string][]policy.Property)
+		}
+		ruleMetadata.CheckProps[checkID] = append(ruleMetadata.CheckProps[checkID], additional...)
+	} else {
+		ruleMetadata.Props = append(ruleMetadata.Props, additional...)
+	}
+	for suffix, values := range alternatives {
+		parameterID, ok := parameterIDs[suffix]
+		if !ok {
+			continue
+		}
+		if ruleMetadata.Parameters == nil {
+			ruleMetadata.Parameters = make(map[string]policy.ParameterMetadata)
+		}
+		parameterMetadata := ruleMetadata.Parameters[parameterID]
+		parameterMetadata.Alternatives = values
+		ruleMetadata.Parameters[parameterID] = parameterMetadata
+	}
+	if ruleMetadata.Props == nil && ruleMetadata.CheckProps == nil && ruleMetadata.Parameters == nil {
+		return
+	}
+	metadata[ruleID] = ruleMetadata
+}
+
+// AddActivityParameterValues adds the selected Values of the rule parameters in the OSCAL
+// Activities of an Assessment Plan to the Metadata and returns it.
+//
+// Activities map to rules by title. A parameter is set by the properties of the activity with
+// the extensions.TestParameterClass and the parameter ID as name. A multi-value parameter
+// has one property for each value.
+func AddActivityParameterValues(metadata policy.Metadata, activities []oscalTypes.Activity) policy.Metadata {
+	for _, activity := range activities {
+		if activity.Props == nil {
+			continue
+		}
+		values := make(map[string][]string)
+		var parameterIDs []string
+		for _, prop := range extensions.FindAllProps(*activity.Props, extensions.WithClass(extensions.TestParameterClass)) {
+			if _, ok := values[prop.Name]; !ok {
+				parameterIDs = append(parameterIDs, prop.Name)
+			}
+			values[prop.Name] = append(values[prop.Name], prop.Value)
+		}
+		if len(parameterIDs) == 0 {
+			continue
+		}
+		if metadata == nil {
+			metadata = make(policy.Metadata)
+		}
+		ruleMetadata := metadata[activity.Title]
+		if ruleMetadata.Parameters == nil {
+			ruleMetadata.Parameters = make(map[string]policy.ParameterMetadata)
+		}
+		for _, parameterID := range parameterIDs {
+			parameterMetadata := ruleMetadata.Parameters[parameterID]
+			parameterMetadata.Values = values[parameterID]
+			ruleMetadata.Parameters[parameterID] = parameterMetadata
+		}
+		metadata[activity.Title] = ruleMetadata
+	}
+	return metadata
+}
+
+// RuleSetMetadata returns the Metadata for the rules, checks and parameters of the applied
+// rule sets, e.g. from settings.ApplyToComponent, or nil if there is none.
+//
+// The Values of each parameter are set from the selected value of the applied rule set. The
+// Values from the metadata are only kept if they include the selected value, so a value
+// selected by the settings is not overridden by the values of another source.
+func RuleSetMetadata(metadata policy.Metadata, appliedRuleSet []extensions.RuleSet) policy.Metadata {
+	applied := make(policy.Metadata)
+	for _, ruleSet := range appliedRuleSet {
+		ruleMetadata := metadata[ruleSet.Rule.ID]
+		appliedMetadata := policy.RuleMetadata{
+			Props: ruleMetadata.Props,
+		}
+		for _, check := range ruleSet.Checks {
+			props, ok := ruleMetadata.CheckProps[check.ID]
+			if !ok {
+				continue
+			}
+			if appliedMetadata.CheckProps == nil {
+				appliedMetadata.CheckProps = make(map[string][]policy.Property)
+			}
+			appliedMetadata.CheckProps[check.ID] = props
+		}
+		for _, parameter := range ruleSet.Rule.Parameters {
+			parameterMetadata := ruleMetadata.Parameters[parameter.ID]
+			if !slices.Contains(parameterMetadata.Values, parameter.Value) {
+				parameterMetadata.Values = nil
+				if parameter.Value != "" {
+					parameterMetadata.Values = []string{parameter.Value}
+				}
+			}
+			if parameterMetadata.Alternatives == nil && parameterMetadata.Values == nil {
+				continue
+			}
+			if appliedMetadata.Parameters == nil {
+				appliedMetadata.Parameters = make(map[string]policy.ParameterMetadata)
+			}
+			appliedMetadata.Parameters[parameter.ID] = parameterMetadata
+		}
+		if appliedMetadata.Props == nil && appliedMetadata.CheckProps == nil && appliedMetadata.Parameters == nil {
+			continue
+		}
+		applied[ruleSet.Rule.ID] = appliedMetadata
+	}
+	if len(applied) == 0 {
+		return nil
+	}
+	return applied
+}
+
+func splitAlternatives(value string) []string {
+	var values []string
+	for _, v := range strings.Split(value, ",") {
+		if v = strings.TrimSpace(v); v != "" {
+			values = append(values, v)
+		}
+	}
+	return values
+}
@@ -0,0 +1,202 @@
+/*
+ Copyright 2025 The OSCAL Compass Authors
+ SPDX-License-Identifier: Apache-2.0
+*/
+
+package actions
+
+import (
+	"context"
+	"testing"
+
+	oscalTypes "github.com/defenseunicorns/go-oscal/src/types/oscal-1-1-3"
+	"github.com/oscal-compass/oscal-sdk-go/extensions"
+	"github.com/oscal-compass/oscal-sdk-go/models/components"
+	"github.com/oscal-compass/oscal-sdk-go/settings"
+	"github.com/stretchr/testify/require"
+
+	"github.com/oscal-compass/compliance-to-policy-go/v2/plugin"
+	"github.com/oscal-compass/compliance-to-policy-go/v2/policy"
+)
+
+func TestNewMetadataFromComponents(t *testing.T) {
+	prop := func(name, value, remarks string) oscalTypes.Property {
+		return oscalTypes.Property{Name: name, Value: value, Ns: extensions.TrestleNameSpace, Remarks: remarks}
+	}
+	component := oscalTypes.DefinedComponent{
+		Title: "Kyverno",
+		Type:  "validation",
+		Props: &[]oscalTypes.Property{
+			prop(extensions.RuleIdProp, "etcd_cert_file", "rule_set_0"),
+			prop(extensions.RuleDescriptionProp, "Ensure that the --cert-file argument is set", "rule_set_0"),
+			prop("Rule_Severity", "high", "rule_set_0"),
+			prop(extensions.ParameterIdProp+"_1", "file_name", "rule_set_0"),
+			prop(ParameterAlternativesProp+"_1", "etcd.crt, server.crt,", "rule_set_0"),
+			prop(extensions.ParameterIdProp+"_2", "file_owner", "rule_set_0"),
+			prop(extensions.RuleIdProp, "etcd_cert_file", "rule_set_1"),
+			prop(extensions.CheckIdProp, "etcd_cert_file", "rule_set_1"),
+			prop(extensions.CheckDescriptionProp, "Check the etcd certificate file", "rule_set_1"),
+			prop("Remediation", "Set --cert-file", "rule_set_1"),
+			prop(extensions.RuleIdProp, "etcd_key_file", "rule_set_2"),
+			prop(extensions.CheckIdProp, "etcd_key_file", "rule_set_2"),
+			// Properties without remarks are not part of a rule set
+			{Name: "Framework_Short_Name", Value: "cis"},
+		},
+	}
+
+	metadata := NewMetadataFromComponents([]components.Component{components.NewDefinedComponentAdapter(component)})
+	want := policy.Metadata{
+		"etcd_cert_file": {
+			Props: []policy.Property{{Name: "Rule_Severity", Value: "high"}},
+			CheckProps: map[string][]policy.Property{
+				"etcd_cert_file": {{Name: "Remediation", Value: "Set --cert-file"}},
+			},
+			Parameters: map[string]policy.ParameterMetadata{
+				"file_name": {Alternatives: []string{"etcd.crt", "server.crt"}},
+			},
+		},
+	}
+	require.Equal(t, want, metadata)
+
+	require.Nil(t, NewMetadataFromComponents(nil))
+}
+
+func TestAddActivityParameterValues(t *testing.T) {
+	parameterProp := func(name, value string) oscalTypes.Property {
+		return oscalTypes.Property{Name: name, Value: value, Ns: extensions.TrestleNameSpace, Class: extensions.TestParameterClass}
+	}
+	activities := []oscalTypes.Activity{
+		{
+			Title: "etcd_key_file",
+			Props: &[]oscalTypes.Property{
+				{Name: "method", Value: "TEST"},
+				parameterProp("file_name", "etcd.key"),
+				parameterProp("file_name", "server.key"),
+				parameterProp("file_owner", "etcd"),
+			},
+		},
+		{Title: "etcd_cert_file"},
+	}
+	metadata := policy.Metadata{
+		"etcd_key_file": {
+			Parameters: map[string]policy.ParameterMetadata{
+				"file_name": {Alternatives: []string{"etcd.key", "server.key", "other.key"}},
+			},
+		},
+	}
+
+	want := policy.Metadata{
+		"etcd_key_file": {
+			Parameters: map[string]policy.ParameterMetadata{
+				"file_name": {
+					Alternatives: []string{"etcd.key", "server.key", "other.key"},
+					Values:       []string{"etcd.key", "server.key"},
+				},
+				"file_owner": {Values: []string{"etcd"}},
+			},
+		},
+	}
+	require.Equal(t, want, AddActivityParameterValues(metadata, activities))
+	require.Nil(t, AddActivityParameterValues(nil, activities[1:]))
+}
+
+func TestRuleSetMetadata(t *testing.T) {
+	metadata := policy.Metadata{
+		"etcd_key_file": {
+			Props: []policy.Property{{Name: "Rule_Severity", Value: "high"}},
+			CheckProps: map[string][]policy.Property{
+				"etcd_key_file":   {{Name: "Remediation", Value: "Set --key-file"}},
+				"etcd_key_file_2": {{Name: "Remediation", Value: "Set --key-file again"}},
+			},
+			Parameters: map[string]policy.ParameterMetadata{
+				"file_name": {
+					Alternatives: []string{"etcd.key", "server.key", "other.key"},
+					Values:       []string{"etcd.key", "server.key"},
+				},
+			},
+		},
+		"etcd_cert_file": {
+			Props: []policy.Property{{Name: "Rule_Severity", Value: "low"}},
+		},
+	}
+	ruleSet := func(value string) extensions.RuleSet {
+		return extensions.RuleSet{
+			Rule: extensions.Rule{
+				ID:         "etcd_key_file",
+				Parameters: []extensions.Parameter{{ID: "file_name", Value: value}, {ID: "file_owner", Value: "etcd"}},
+			},
+			Checks: []extensions.Check{{ID: "etcd_key_file"}},
+		}
+	}
+
+	// Only the metadata of the applied rules, checks and parameters is returned
+	want := policy.Metadata{
+		"etcd_key_file": {
+			Props: []policy.Property{{Name: "Rule_Severity", Value: "high"}},
+			CheckProps: map[string][]policy.Property{
+				"etcd_key_file": {{Name: "Remediation", Value: "Set --key-file"}},
+			},
+			Parameters: map[string]policy.ParameterMetadata{
+				"file_name": {
+					Alternatives: []string{"etcd.key", "server.key", "other.key"},
+					Values:       []string{"etcd.key", "server.key"},
+				},
+				"file_owner": {Values: []string{"etcd"}},
+			},
+		},
+	}
+	require.Equal(t, want, RuleSetMetadata(metadata, []extensions.RuleSet{ruleSet("server.key")}))
+
+	// A value selected by the settings replaces the values of the metadata
+	applied := RuleSetMetadata(metadata, []extensions.RuleSet{ruleSet("other.key")})
+	require.Equal(t, []string{"other.key"}, applied["etcd_key_file"].Parameters["file_name"].Values)
+
+	require.Nil(t, RuleSetMetadata(metadata, nil))
+	require.Nil(t, RuleSetMetadata(nil, []extensions.RuleSet{{Rule: extensions.Rule{ID: "etcd_key_file"}}}))
+}
+
+func TestGeneratePolicy_Metadata(t *testing.T) {
+	inputContext := inputContextHelper(t)
+	inputContext.Metadata = policy.Metadata{
+		"etcd_key_file": {
+			Parameters: map[string]policy.ParameterMetadata{
+				"file_name": {Values: []string{"etcd.key", "server.key"}},
+			},
+		},
+		"etcd_cert_file": {
+			Props: []policy.Property{{Name: "Rule_Severity", Value: "high"}},
+		},
+	}
+	rules := map[string]struct{}{"etcd_key_file": {}}
+
+	provider := &metadataProvider{}
+	pluginSet := map[plugin.ID]policy.Provider{"mypvpvalidator": provider}
+
+	inputContext.Settings = settings.NewSettings(rules, map[string]string{"file_name": "server.key"})
+	_, err := GeneratePolicy(context.TODO(), inputContext, pluginSet)
+	require.NoError(t, err)
+	require.Equal(t, policy.Metadata{
+		"etcd_key_file": {
+			Parameters: map[string]policy.ParameterMetadata{
+				"file_name": {Values: []string{"etcd.key", "server.key"}},
+			},
+		},
+	}, provider.metadata)
+
+	// The metadata reflects the values selected by the settings
+	inputContext.Settings = settings.NewSettings(rules, map[string]string{"file_name": "other.key"})
+	_, err = GeneratePolicy(context.TODO(), inputContext, pluginSet)
+	require.NoError(t, err)
+	require.Equal(t, []string{"other.key"}, provider.metadata["etcd_key_file"].Parameters["file_name"].Values)
+}
+
+// metadataProvider records the Metadata sent with Generate.
+type metadataProvider struct {
+	policyProvider
+	metadata policy.Metadata
+}
+
+func (p *metadataProvider) Generate(ctx context.Context, _ policy.Policy) ([]policy.Artifact, error) {
+	p.metadata = policy.MetadataFromContext(ctx)
+	return nil, nil
+}
@@ -13,6 +13,7 @@ import (
 
 	"github.com/defenseunicorns/go-oscal/src/pkg/uuid"
 	oscalTypes "github.com/defenseunicorns/go-oscal/src/types/oscal-1-1-3"
+	"github.com/hashicorp/go-hclog"
 	"github.com/oscal-compass/oscal-sdk-go/extensions"
 	"github.com/oscal-compass/oscal-sdk-go/rules"
 	"github.com/oscal-compass/oscal-sdk-go/transformers"
@@ -27,33 +28,198 @@ const (
 	Resource      = "resource"
 )
 
+// PluginInstanceProp is the observation property identifying the
+// named plugin instance (e.g. kyverno@prod) that produced the observation.
+const PluginInstanceProp = "plugin-instance"
+
+// PluginErrorProp is the observation property with the error of a plugin
+// that failed to return results for the check.
+const PluginErrorProp = "plugin-error"
+
+const (
+	// SeverityProp is the observation and subject property for the
+	// severity reported by the plugin.
+	SeverityProp = "severity"
+	// RemediationProp is the observation and subject property for the
+	// remediation guidance reported by the plugin.
+	RemediationProp = "remediation"
+)
+
 var validSubjectTypes = []string{InventoryItem, Resource}
 
+// ReportOption configures how an Assessment Results is generated.
+type ReportOption func(options *reportOptions)
+
+type reportOptions struct {
+	evidenceDir string
+}
+
+// WithEvidenceDir embeds the relevant evidence files in the given directory, e.g. the
+// evidence stored with the policy.Host, in the back-matter of the Assessment Results.
+// Relevant evidence links to other locations are kept as they are.
+func WithEvidenceDir(dir string) ReportOption {
+	return func(options *reportOptions) {
+		options.evidenceDir = dir
+	}
+}
+
 // Report action generates an Assessment Results from an Assessment Plan and Context.
-func Report(ctx context.Context, inputContext *InputContext, planHref string, plan oscalTypes.AssessmentPlan, results []policy.PVPResult) (*oscalTypes.AssessmentResults, error) {
-	log := logging.GetLogger("reporter")
-	log.Info(fmt.Sprintf("generating assessments results for plan %s", planHref))
+//
+// The policy.Evidence of each observation is embedded in the back-matter as a resource
+// with base64 content and a SHA-256 hash in the EvidenceHashProp, and referenced from
+// the relevant evidence of the observation.
+func Report(ctx context.Context, inputContext *InputContext, planHref string, plan oscalTypes.AssessmentPlan, results []policy.PVPResult, opts ...ReportOption) (*oscalTypes.AssessmentResults, error) {
+	reporter := NewReporter(inputContext, planHref, plan, opts...)
+	for _, result := range results {
+		if err := reporter.AddResult(ctx, result); err != nil {
+			return nil, err
+		}
+	}
+	return reporter.AssessmentResults()
+}
+
+// observationKey identifies the OSCAL Observation for a check
+// from a plugin instance.
+type observationKey struct {
+	instance string
+	checkID  string
+}
+
+// newObservationKey returns the key of the observation. Observations
+// without a PluginInstanceProp have an empty instance.
+func newObservationKey(observationByCheck policy.ObservationByCheck) observationKey {
+	key := observationKey{checkID: observationByCheck.CheckID}
+	for _, prop := range observationByCheck.Props {
+		if prop.Name == PluginInstanceProp {
+			key.instance = prop.Value
+			break
+		}
+	}
+	return key
+}
+
+// Reporter incrementally generates an Assessment Results from an Assessment Plan and Context.
+// Results can be added as they are received from providers with AddResult.
+type Reporter struct {
+	inputContext *InputContext
+	planHref     string
+	plan         oscalTypes.AssessmentPlan
+	log          hclog.Logger
 
 	// for each PVPResult.Observation create an OSCAL Observation
-	oscalObservations := make([]oscalTypes.Observation, 0)
-	oscalFindings := make([]oscalTypes.Finding, 0)
-	store := inputContext.Store()
+	oscalObservations []oscalTypes.Observation
+
+	// maps plugin instances and check IDs to the index of the
+	// OSCAL Observation to merge observations for the same check
+	// received in separate results from the same plugin instance.
+	observationsByCheck map[observationKey]int
 
 	// Maps resourceIds from observation subjects to subject UUIDs
 	// to avoid duplicating subjects for a single resource.
 	// This is passed to toOscalObservation to maintain a global
 	// state across results.
-	subjectUuidMap := make(map[string]string)
+	subjectUuidMap map[string]string
 
 	// maps inventory items to subject UUIDs
-	invItemMap := make(map[string]oscalTypes.InventoryItem)
+	invItemMap map[string]oscalTypes.InventoryItem
 
 	// maps resource items to subject UUIDs
-	resourceItemMap := make(map[string]oscalTypes.Resource)
+	resourceItemMap map[string]oscalTypes.Resource
+
+	// back-matter resources for embedded evidence
+	evidence    *evidenceResources
+	evidenceDir string
+}
+
+// NewReporter returns a Reporter for the given Assessment Plan and Context.
+func NewReporter(inputContext *InputContext, planHref string, plan oscalTypes.AssessmentPlan, opts ...ReportOption) *Reporter {
+	options := &reportOptions{}
+	for _, opt := range opts {
+		opt(options)
+	}
+	log := logging.GetLogger("reporter")
+	log.Info(fmt.Sprintf("generating assessments results for plan %s", planHref))
+	return &Reporter{
+		evidence:            newEvidenceResources(),
+		evidenceDir:         options.evidenceDir,
+		inputContext:        inputContext,
+		planHref:            planHref,
+		plan:                plan,
+		log:                 log,
+		oscalObservations:   make([]oscalTypes.Observation, 0),
+		observationsByCheck: make(map[observationKey]int),
+		subjectUuidMap:      make(map[string]string),
+		invItemMap:          make(map[string]oscalTypes.InventoryItem),
+		resourceItemMap:     make(map[string]oscalTypes.Resource),
+	}
+}
+
+// AddResult converts the observations in the given (partial) PVPResult and
+// adds them to the report.
+func (r *Reporter) AddResult(ctx context.Context, result policy.PVPResult) error {
+	store := r.inputContext.Store()
+	for _, observationByCheck := range result.ObservationsByCheck {
+		rule, err := store.GetByCheckID(ctx, observationByCheck.CheckID)
+		if err != nil {
+			if !errors.Is(err, rules.ErrRuleNotFound) {
+				return fmt.Errorf("failed to convert observation for check %v: %w", observationByCheck.CheckID, err)
+			} else {
+				r.log.Warn(fmt.Sprintf("skipping observation for check %v: %v", observationByCheck.CheckID, err))
+				continue
+			}
+		}
+		obs, err := toOscalObservation(observationByCheck, rule, &r.subjectUuidMap)
+		if err != nil {
+			return fmt.Errorf("failed to convert observation for check %v: %w", observationByCheck.CheckID, err)
+		}
+		if err := r.embedEvidence(&obs, observationByCheck.Evidence); err != nil {
+			return fmt.Errorf("failed to embed evidence for check %v: %w", observationByCheck.CheckID, err)
+		}
+		key := newObservationKey(observationByCheck)
+		if idx, ok := r.observationsByCheck[key]; ok {
+			mergeObservation(&r.oscalObservations[idx], obs)
+		} else {
+			r.observationsByCheck[key] = len(r.oscalObservations)
+			r.oscalObservations = append(r.oscalObservations, obs)
+		}
+
+		if obs.Subjects != nil {
+			for _, subject := range *obs.Subjects {
+
+				// Create a new InventoryItem or Resource for this subject if one doesn't already exist
+				switch subject.Type {
+				case InventoryItem:
+					_, ok := r.invItemMap[subject.SubjectUuid]
+					if ok {
+						r.log.Debug(fmt.Sprintf("inventory item already exists for subject %s", subject.SubjectUuid))
+					} else {
+						invItem := generateInventoryItem(&subject)
+						r.log.Debug(fmt.Sprintf("creating new inventory item for subject %s", subject.SubjectUuid))
+						r.invItemMap[subject.SubjectUuid] = invItem
+					}
+				case Resource:
+					_, ok := r.resourceItemMap[subject.SubjectUuid]
+					if ok {
+						r.log.Debug(fmt.Sprintf("resource %s already exists for subject", subject.SubjectUuid))
+					} else {
+						resource := generateResource(&subject)
+						r.log.Debug(fmt.Sprintf("creating new resource for subject %s", subject.SubjectUuid))
+						r.resourceItemMap[subject.SubjectUuid] = resource
+					}
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// AssessmentResults generates the Assessment Results with findings from all added results.
+func (r *Reporter) AssessmentResults() (*oscalTypes.AssessmentResults, error) {
+	oscalFindings := make([]oscalTypes.Finding, 0)
 
 	// Get all the control mappings based on the assessment plan activities
 	rulesByControls := make(map[string][]string)
-	for _, act := range *plan.LocalDefinitions.Activities {
+	for _, act := range *r.plan.LocalDefinitions.Activities {
 		var controlSet []string
 		if act.RelatedControls != nil {
 			controls := act.RelatedControls.ControlSelections
@@ -67,54 +233,7 @@ func Report(ctx context.Context, inputContext *InputContext, planHref string, pl
 		rulesByControls[act.Title] = controlSet
 	}
 
-	// Process into observations
-	for _, result := range results {
-		for _, observationByCheck := range result.ObservationsByCheck {
-			rule, err := store.GetByCheckID(ctx, observationByCheck.CheckID)
-			if err != nil {
-				if !errors.Is(err, rules.ErrRuleNotFound) {
-					return nil, fmt.Errorf("failed to convert observation for check %v: %w", observationByCheck.CheckID, err)
-				} else {
-					log.Warn(fmt.Sprintf("skipping observation for check %v: %v", observationByCheck.CheckID, err))
-					continue
-				}
-			}
-			obs, err := toOscalObservation(observationByCheck, rule, &subjectUuidMap)
-			if err != nil {
-				return nil, fmt.Errorf("failed to convert observation for check %v: %w", observationByCheck.CheckID, err)
-			}
-			oscalObservations = append(oscalObservations, obs)
-
-			if obs.Subjects != nil {
-				for _, subject := range *obs.Subjects {
-
-					// Create a new InventoryItem or Resource for this subject if one doesn't already exist
-					switch subject.Type {
-					case InventoryItem:
-						_, ok := invItemMap[subject.SubjectUuid]
-						if ok {
-							log.Debug(fmt.Sprintf("inventory item already exists for subject %s", subject.SubjectUuid))
-						} else {
-							invItem := generateInventoryItem(&subject)
-							log.Debug(fmt.Sprintf("creating new inventory item for subject %s", subject.SubjectUuid))
-							invItemMap[subject.SubjectUuid] = invItem
-						}
-					case Resource:
-						_, ok := resourceItemMap[subject.SubjectUuid]
-						if ok {
-							log.Debug(fmt.Sprintf("resource %s already exists for subject", subject.SubjectUuid))
-						} else {
-							resource := generateResource(&subject)
-							log.Debug(fmt.Sprintf("creating new resource for subject %s", subject.SubjectUuid))
-							resourceItemMap[subject.SubjectUuid] = resource
-						}
-					}
-				}
-			}
-		}
-	}
-
-	assessmentResults, err := transformers.AssessmentPlanToAssessmentResults(plan, planHref, oscalObservations...)
+	assessmentResults, err := transformers.AssessmentPlanToAssessmentResults(r.plan, r.planHref, r.oscalObservations...)
 	if err != nil {
 		return nil, err
 	}
@@ -123,6 +242,10 @@ func Report(ctx context.Context, inputContext *InputContext, planHref string, pl
 	if len(assessmentResults.Results) != 1 {
 		return nil, errors.New("bug: assessment results should only have one result")
 	}
+	if assessmentResults.Results[0].Observations != nil {
+		observations := r.expandObservations(*assessmentResults.Results[0].Observations)
+		assessmentResults.Results[0].Observations = &observations
+	}
 
 	// Create findings after initial observations are added to ensure only observations
 	// in-scope of the plan are checked for failure.
@@ -140,22 +263,22 @@ func Report(ctx context.Context, inputContext *InputContext, planHref string, pl
 		}
 
 		// Check if findings should be generated for this observation
-		if shouldGenerateFindings(obs) {
-			oscalFindings, err = generateFindings(oscalFindings, obs, targets)
+		if status := observationFindingStatus(obs); status != findingNone {
+			oscalFindings, err = generateFindings(oscalFindings, obs, targets, status)
 			if err != nil {
 				return nil, fmt.Errorf("failed to create finding for check: %w", err)
 			}
-			log.Info(fmt.Sprintf("generated finding for rule %s", rule.Value))
+			r.log.Info(fmt.Sprintf("generated finding for rule %s", rule.Value))
 		}
 	}
 
 	assessmentResults.Results[0].Findings = utils.NilIfEmpty(&oscalFindings)
 
 	// If inventory items were created then add to result
-	if len(invItemMap) > 0 {
-		invItems := make([]oscalTypes.InventoryItem, 0, len(invItemMap))
+	if len(r.invItemMap) > 0 {
+		invItems := make([]oscalTypes.InventoryItem, 0, len(r.invItemMap))
 
-		for _, invItem := range invItemMap {
+		for _, invItem := range r.invItemMap {
 			invItems = append(invItems, invItem)
 		}
 
@@ -165,19 +288,75 @@ func Report(ctx context.Context, inputContext *InputContext, planHref string, pl
 		assessmentResults.Results[0].LocalDefinitions = &localDefs
 	}
 
-	// If resources were created then add to result
-	if len(resourceItemMap) > 0 {
+	// If resources were created or evidence was embedded then add to result
+	if len(r.resourceItemMap) > 0 || len(r.evidence.resources) > 0 {
 		backmatter := oscalTypes.BackMatter{}
-		resources := make([]oscalTypes.Resource, 0, len(resourceItemMap))
-		for _, r := range resourceItemMap {
-			resources = append(resources, r)
+		resources := make([]oscalTypes.Resource, 0, len(r.resourceItemMap)+len(r.evidence.resources))
+		for _, res := range r.resourceItemMap {
+			resources = append(resources, res)
 		}
+		resources = append(resources, r.evidence.resources...)
 		backmatter.Resources = &resources
 		assessmentResults.BackMatter = &backmatter
 	}
 	return assessmentResults, nil
 }
 
+// expandObservations replaces each in-scope observation returned by the transformer
+// with the observations for the same check from all plugin instances, as the
+// transformer keeps a single observation for each check.
+func (r *Reporter) expandObservations(observations []oscalTypes.Observation) []oscalTypes.Observation {
+	indicesByCheck := make(map[string][]int)
+	for i, obs := range r.oscalObservations {
+		if check, found := extensions.GetTrestleProp(extensions.AssessmentCheckIdProp, utils.ValueOrEmpty(obs.Props)); found {
+			indicesByCheck[check.Value] = append(indicesByCheck[check.Value], i)
+		}
+	}
+	expanded := make([]oscalTypes.Observation, 0, len(observations))
+	for _, obs := range observations {
+		check, found := extensions.GetTrestleProp(extensions.AssessmentCheckIdProp, utils.ValueOrEmpty(obs.Props))
+		indices := indicesByCheck[check.Value]
+		if !found || len(indices) == 0 {
+			expanded = append(expanded, obs)
+			continue
+		}
+		for _, idx := range indices {
+			instanceObs := r.oscalObservations[idx]
+			if instanceObs.Origins == nil {
+				instanceObs.Origins = obs.Origins
+			}
+			expanded = append(expanded, instanceObs)
+		}
+		delete(indicesByCheck, check.Value)
+	}
+	return expanded
+}
+
+// embedEvidence adds the evidence content of an observation and the relevant evidence
+// files in the evidence directory to the back-matter and references them from the
+// relevant evidence of the OSCAL Observation.
+func (r *Reporter) embedEvidence(obs *oscalTypes.Observation, evidence []policy.Evidence) error {
+	relevantEvidence := utils.ValueOrEmpty(obs.RelevantEvidence)
+	for i, link := range relevantEvidence {
+		linked, found, err := loadLinkedEvidence(r.evidenceDir, link)
+		if err != nil {
+			return err
+		}
+		if found {
+			relevantEvidence[i] = r.evidence.add(linked, link.Href)
+		}
+	}
+	for _, e := range evidence {
+		if len(e.Content) == 0 {
+			r.log.Warn(fmt.Sprintf("skipping evidence %s without content", e.Name))
+			continue
+		}
+		relevantEvidence = append(relevantEvidence, r.evidence.add(e, ""))
+	}
+	obs.RelevantEvidence = utils.NilIfEmpty(&relevantEvidence)
+	return nil
+}
+
 // Generate an OSCAL Inventory Item from a given Subject reference
 func generateInventoryItem(subject *oscalTypes.SubjectReference) oscalTypes.InventoryItem {
 
@@ -220,39 +399,101 @@ func generateResource(subject *oscalTypes.SubjectReference) oscalTypes.Resource
 	return resource
 }
 
-// shouldGenerateFindings determines if findings should be generated for an observation
-// - Observations without subjects generate findings unless waived at observation level
-// - Observations with subjects only generate findings if they have non-waived subjects with failures
+// findingStatus is the status of the finding for the observations of a control.
+type findingStatus int
+
+const (
+	// findingNone is used when no finding is generated.
+	findingNone findingStatus = iota
+	// findingReview is used when subjects were skipped or must be assessed manually.
+	findingReview
+	// findingError is used when subjects could not be assessed.
+	findingError
+	// findingFail is used when subjects failed or results are missing.
+	findingFail
+)
+
+// objectiveStatus returns the OSCAL status of a finding. OSCAL only allows the satisfied and
+// not-satisfied states, so findings that need review are not-satisfied with the "other" reason.
+func (s findingStatus) objectiveStatus() oscalTypes.ObjectiveStatus {
+	switch s {
+	case findingReview:
+		return oscalTypes.ObjectiveStatus{
+			State:   "not-satisfied",
+			Reason:  "other",
+			Remarks: "Needs review: rules were skipped or must be assessed manually.",
+		}
+	case findingError:
+		return oscalTypes.ObjectiveStatus{
+			State:   "not-satisfied",
+			Reason:  "other",
+			Remarks: "Could not be assessed: rules returned errors.",
+		}
+	}
+	return oscalTypes.ObjectiveStatus{
+		State:  "not-satisfied",
+		Reason: "fail",
+	}
+}
+
+// findingStatusOf returns the findingStatus of the OSCAL status of a finding.
+// Unknown statuses are treated as findingFail.
+func findingStatusOf(objectiveStatus oscalTypes.ObjectiveStatus) findingStatus {
+	for _, s := range []findingStatus{findingReview, findingError} {
+		if objectiveStatus == s.objectiveStatus() {
+			return s
+		}
+	}
+	return findingFail
+}
+
+// observationFindingStatus determines the status of the finding for an observation
+// - Observations without subjects fail unless waived at observation level
+// - Observations with subjects only generate findings for their non-waived subjects
+// - Passed and not-applicable subjects do not generate findings
+// - Skipped and manual subjects are reported for review unless another subject failed or errored
+// - Errored subjects are reported as not assessed unless another subject failed
 // - Waived subjects are skipped (matching template logic where waived subjects don't count as failures)
-func shouldGenerateFindings(obs oscalTypes.Observation) bool {
+func observationFindingStatus(obs oscalTypes.Observation) findingStatus {
 	// Check if observation-level waived (for observations without subjects)
 	if obs.Props != nil {
 		waived, found := extensions.GetTrestleProp(extensions.WaivedRulesProperty, *obs.Props)
 		if found && waived.Value == "true" {
-			return false
+			return findingNone
 		}
 	}
 
 	if obs.Subjects == nil {
 		// Generate findings by default for observations without subjects
 		// This handles the case where an activity was in scope but no results were received
-		return true
+		return findingFail
 	}
 
+	status := findingNone
 	for _, subject := range *obs.Subjects {
-		if subject.Props != nil {
-			waived, found := extensions.GetTrestleProp(extensions.WaivedRulesProperty, *subject.Props)
-			if found && waived.Value == "true" {
-				continue
-			}
+		if subject.Props == nil {
+			continue
+		}
+		waived, found := extensions.GetTrestleProp(extensions.WaivedRulesProperty, *subject.Props)
+		if found && waived.Value == "true" {
+			continue
 		}
 
 		result, found := extensions.GetTrestleProp("result", *subject.Props)
-		if found && result.Value != policy.ResultPass.String() {
-			return true
+		if !found {
+			continue
+		}
+		switch result.Value {
+		case policy.ResultPass.String(), policy.ResultNotApplicable.String():
+		case policy.ResultSkipped.String(), policy.ResultManual.String():
+			status = max(status, findingReview)
+		case policy.ResultError.String():
+			status = max(status, findingError)
+		default:
+			return findingFail
 		}
 	}
-	return false
+	return status
 }
 
 // getFindingForTarget returns an existing finding that matches the targetId if one exists in findings
@@ -266,8 +507,9 @@ func getFindingForTarget(findings []oscalTypes.Finding, targetId string) *oscalT
 	return nil
 }
 
-// Generate OSCAL Findings for all non-passing controls in the OSCAL Observation
-func generateFindings(findings []oscalTypes.Finding, observation oscalTypes.Observation, targets []string) ([]oscalTypes.Finding, error) {
+// Generate OSCAL Findings for all non-passing controls in the OSCAL Observation. Existing findings take
+// the status of the observation if it is more severe, e.g. findings that need review fail with the observation.
+func generateFindings(findings []oscalTypes.Finding, observation oscalTypes.Observation, targets []string, status findingStatus) ([]oscalTypes.Finding, error) {
 	for _, targetId := range targets {
 		finding := getFindingForTarget(findings, targetId)
 		if finding == nil { // if an empty finding was returned, create a new one and append to findings
@@ -281,13 +523,14 @@ func generateFindings(findings []oscalTypes.Finding, observation oscalTypes.Obse
 				Target: oscalTypes.FindingTarget{
 					TargetId: targetId,
 					Type:     "statement-id",
-					Status: oscalTypes.ObjectiveStatus{
-						State: "not-satisfied",
-					},
+					Status:   status.objectiveStatus(),
 				},
 			}
 			findings = append(findings, newFinding)
 		} else {
+			if status > findingStatusOf(finding.Target.Status) {
+				finding.Target.Status = status.objectiveStatus()
+			}
 			relObs := oscalTypes.RelatedObservation{
 				ObservationUuid: observation.UUID,
 			}
@@ -323,13 +566,18 @@ func toOscalObservation(observationByCheck policy.ObservationByCheck, ruleSet ex
 				Value: subject.EvaluatedOn.String(),
 				Ns:    extensions.TrestleNameSpace,
 			},
-			{
+		}
+		// Empty property values are not valid OSCAL
+		if subject.Reason != "" {
+			props = append(props, oscalTypes.Property{
 				Name:  "reason",
 				Value: subject.Reason,
 				Ns:    extensions.TrestleNameSpace,
-			},
+			})
 		}
 
+		props = append(props, severityProps(subject.Severity, subject.Remediation)...)
+
 		for _, p := range subject.Props {
 			prop := oscalTypes.Property{
 				Name:  p.Name,
@@ -389,7 +637,71 @@ func toOscalObservation(observationByCheck policy.ObservationByCheck, ruleSet ex
 			Ns:    extensions.TrestleNameSpace,
 		},
 	}
+	props = append(props, severityProps(observationByCheck.Severity, observationByCheck.Remediation)...)
+	for _, p := range observationByCheck.Props {
+		if (p.Name == PluginInstanceProp || p.Name == PluginErrorProp) && p.Value != "" {
+			props = append(props, oscalTypes.Property{
+				Name:  p.Name,
+				Value: p.Value,
+				Ns:    extensions.TrestleNameSpace,
+			})
+		}
+	}
 	oscalObservation.Props = &props
 
 	return oscalObservation, nil
 }
+
+// severityProps returns the SeverityProp and RemediationProp
+// properties for the values that are set.
+func severityProps(severity policy.Severity, remediation string) []oscalTypes.Property {
+	var props []oscalTypes.Property
+	if severity != policy.SeverityUnspecified {
+		props = append(props, oscalTypes.Property{
+			Name:  SeverityProp,
+			Value: severity.String(),
+			Ns:    extensions.TrestleNameSpace,
+		})
+	}
+	if remediation != "" {
+		props = append(props, oscalTypes.Property{
+			Name:  RemediationProp,
+			Value: remediation,
+			Ns:    extensions.TrestleNameSpace,
+		})
+	}
+	return props
+}
+
+// mergeObservation adds the subjects, relevant evidence and properties of
+// the source observation to the destination observation for the same check
+// and plugin instance.
+func mergeObservation(dest *oscalTypes.Observation, src oscalTypes.Observation) {
+	if src.Subjects != nil {
+		subjects := append(utils.ValueOrEmpty(dest.Subjects), *src.Subjects...)
+		dest.Subjects = &subjects
+	}
+	if src.RelevantEvidence != nil {
+		relevantEvidence := utils.ValueOrEmpty(dest.RelevantEvidence)
+		for _, evidence := range *src.RelevantEvidence {
+			if !slices.ContainsFunc(relevantEvidence, func(e oscalTypes.RelevantEvidence) bool {
+				return e.Href == evidence.Href && e.Description == evidence.Description
+			}) {
+				relevantEvidence = append(relevantEvidence, evidence)
+			}
+		}
+		dest.RelevantEvidence = &relevantEvidence
+	}
+	if src.Props != nil {
+		props := utils.ValueOrEmpty(dest.Props)
+		for _, prop := range *src.Props {
+			if !slices.Contains(props, prop) {
+				props = append(props, prop)
+			}
+		}
+		dest.Props = &props
+	}
+	if src.Collected.After(dest.Collected) {
+		dest.Collected = src.Collected
+	}
+}
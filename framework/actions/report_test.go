@@ -8,6 +8,7 @@ package actions
 import (
 	"context"
 	"os"
+	"path/filepath"
 	"testing"
 	"time"
 
@@ -93,6 +94,218 @@ func TestReport(t *testing.T) {
 	require.Len(t, *ar.Results[0].LocalDefinitions.InventoryItems, 1)
 }
 
+func TestReporter(t *testing.T) {
+	inputContext, plan := inputContextHelperPlan(t)
+
+	planHref := "https://test-plan-href"
+	reporter := NewReporter(inputContext, planHref, plan)
+
+	// Add the same observation as separate partial results to
+	// verify observations and subjects are merged across calls.
+	partial := policy.PVPResult{ObservationsByCheck: pvpResults[0].ObservationsByCheck}
+	require.NoError(t, reporter.AddResult(context.TODO(), partial))
+	require.NoError(t, reporter.AddResult(context.TODO(), partial))
+
+	ar, err := reporter.AssessmentResults()
+	require.NoError(t, err)
+	require.Equal(t, ar.ImportAp.Href, planHref)
+	require.Len(t, ar.Results, 1)
+	require.Len(t, *ar.Results[0].Observations, 2)
+	require.Len(t, *ar.Results[0].LocalDefinitions.InventoryItems, 1)
+}
+
+func TestReporter_Instances(t *testing.T) {
+	inputContext, plan := inputContextHelperPlan(t)
+	reporter := NewReporter(inputContext, "https://test-plan-href", plan)
+
+	// Observations for the same check from separate plugin instances
+	// are kept as separate OSCAL Observations.
+	for _, instance := range []string{"kyverno@prod", "kyverno@staging"} {
+		obs := pvpResults[0].ObservationsByCheck[0]
+		obs.Subjects = []policy.Subject{
+			{
+				Title:      instance,
+				Type:       "resource",
+				ResourceID: instance,
+				Result:     policy.ResultPass,
+			},
+		}
+		obs.Props = []policy.Property{{Name: PluginInstanceProp, Value: instance}}
+		require.NoError(t, reporter.AddResult(context.TODO(), policy.PVPResult{ObservationsByCheck: []policy.ObservationByCheck{obs}}))
+	}
+
+	ar, err := reporter.AssessmentResults()
+	require.NoError(t, err)
+
+	var instances []string
+	for _, obs := range *ar.Results[0].Observations {
+		check, ok := extensions.GetTrestleProp(extensions.AssessmentCheckIdProp, *obs.Props)
+		if !ok || check.Value != "etcd_cert_file" {
+			continue
+		}
+		instanceProps := extensions.FindAllProps(*obs.Props, extensions.WithName(PluginInstanceProp))
+		require.Len(t, instanceProps, 1)
+		require.Len(t, *obs.Subjects, 1)
+		require.Equal(t, instanceProps[0].Value, (*obs.Subjects)[0].Title)
+		instances = append(instances, instanceProps[0].Value)
+	}
+	require.Equal(t, []string{"kyverno@prod", "kyverno@staging"}, instances)
+}
+
+func TestReporter_PluginError(t *testing.T) {
+	inputContext, plan := inputContextHelperPlan(t)
+	reporter := NewReporter(inputContext, "https://test-plan-href", plan)
+
+	obs := policy.ObservationByCheck{
+		Title:     "etcd_cert_file",
+		CheckID:   "etcd_cert_file",
+		Methods:   []string{"AUTOMATED"},
+		Collected: time.Now(),
+		Subjects: []policy.Subject{
+			{
+				Title:       "kyverno",
+				Type:        Resource,
+				ResourceID:  "kyverno",
+				Result:      policy.ResultError,
+				EvaluatedOn: time.Now(),
+				Reason:      "failed",
+			},
+		},
+		Props: []policy.Property{{Name: PluginErrorProp, Value: "plugin kyverno failed: failed"}},
+	}
+	require.NoError(t, reporter.AddResult(context.TODO(), policy.PVPResult{ObservationsByCheck: []policy.ObservationByCheck{obs}}))
+
+	ar, err := reporter.AssessmentResults()
+	require.NoError(t, err)
+
+	var errorObsUuid string
+	for _, oscalObs := range *ar.Results[0].Observations {
+		pluginErr, ok := extensions.GetTrestleProp(PluginErrorProp, *oscalObs.Props)
+		if !ok {
+			continue
+		}
+		errorObsUuid = oscalObs.UUID
+		require.Equal(t, "plugin kyverno failed: failed", pluginErr.Value)
+		require.Len(t, *oscalObs.Subjects, 1)
+		result, ok := extensions.GetTrestleProp("result", *(*oscalObs.Subjects)[0].Props)
+		require.True(t, ok)
+		require.Equal(t, policy.ResultError.String(), result.Value)
+	}
+	require.NotEmpty(t, errorObsUuid)
+	// Checks that could not be assessed are reported as findings
+	var found bool
+	for _, finding := range *ar.Results[0].Findings {
+		for _, relObs := range *finding.RelatedObservations {
+			found = found || relObs.ObservationUuid == errorObsUuid
+		}
+	}
+	require.True(t, found)
+}
+
+func TestReporter_Evidence(t *testing.T) {
+	inputContext, plan := inputContextHelperPlan(t)
+	reporter := NewReporter(inputContext, "https://test-plan-href", plan)
+
+	obs := pvpResults[0].ObservationsByCheck[0]
+	obs.Evidence = []policy.Evidence{
+		{
+			Name:        "report.yaml",
+			Description: "Policy report",
+			MediaType:   "application/yaml",
+			Content:     []byte("status: fail\n"),
+		},
+		{Name: "empty.yaml"},
+	}
+	// The same evidence from separate results is embedded once.
+	result := policy.PVPResult{ObservationsByCheck: []policy.ObservationByCheck{obs}}
+	require.NoError(t, reporter.AddResult(context.TODO(), result))
+	require.NoError(t, reporter.AddResult(context.TODO(), result))
+
+	ar, err := reporter.AssessmentResults()
+	require.NoError(t, err)
+	require.NotNil(t, ar.BackMatter)
+	resources := *ar.BackMatter.Resources
+	require.Len(t, resources, 1)
+	resource := resources[0]
+	require.Equal(t, "report.yaml", resource.Title)
+	require.Equal(t, "c3RhdHVzOiBmYWlsCg==", resource.Base64.Value)
+	require.Equal(t, "application/yaml", resource.Base64.MediaType)
+	// Evidence without an original location has no resource link.
+	require.Nil(t, resource.Rlinks)
+	hash, found := extensions.GetTrestleProp(EvidenceHashProp, *resource.Props)
+	require.True(t, found)
+	require.Equal(t, "e9cd61567b1841ace79e55a442753850438e8649bb01ec48beffa2741d9743e7", hash.Value)
+
+	// The merged observation references the evidence once.
+	var references int
+	for _, oscalObs := range *ar.Results[0].Observations {
+		for _, evidence := range utils.ValueOrEmpty(oscalObs.RelevantEvidence) {
+			if evidence.Href == "#"+resource.UUID {
+				references++
+				require.Equal(t, "Policy report", evidence.Description)
+			}
+		}
+	}
+	require.Equal(t, 1, references)
+}
+
+func TestReporter_EvidenceDir(t *testing.T) {
+	inputContext, plan := inputContextHelperPlan(t)
+	evidenceDir := t.TempDir()
+	stored := filepath.Join(evidenceDir, "kyverno", "report.json")
+	require.NoError(t, os.MkdirAll(filepath.Dir(stored), 0750))
+	require.NoError(t, os.WriteFile(stored, []byte(`{"status":"fail"}`), 0600))
+	outside := filepath.Join(t.TempDir(), "secret.json")
+	require.NoError(t, os.WriteFile(outside, []byte(`{}`), 0600))
+	escape := filepath.Join(evidenceDir, "kyverno", "escape.json")
+	require.NoError(t, os.Symlink(outside, escape))
+	broken := filepath.Join(evidenceDir, "kyverno", "broken.json")
+	require.NoError(t, os.Symlink(filepath.Join(evidenceDir, "removed.json"), broken))
+
+	obs := pvpResults[0].ObservationsByCheck[0]
+	obs.RelevantEvidences = []policy.Link{
+		{Description: "Stored report", Href: filepath.ToSlash(stored)},
+		{Description: "Relative report", Href: "kyverno/report.json"},
+		{Description: "Outside report", Href: filepath.ToSlash(outside)},
+		{Description: "Linked outside report", Href: filepath.ToSlash(escape)},
+		{Description: "Missing report", Href: "kyverno/missing.json"},
+		{Description: "Broken report", Href: filepath.ToSlash(broken)},
+		{Description: "Remote report", Href: "https://test-related-evidence-1"},
+	}
+	ar, err := Report(context.TODO(), inputContext, "https://test-plan-href", plan,
+		[]policy.PVPResult{{ObservationsByCheck: []policy.ObservationByCheck{obs}}}, WithEvidenceDir(evidenceDir))
+	require.NoError(t, err)
+
+	// Relative links are resolved against the evidence directory and links
+	// to missing files are kept.
+	resources := *ar.BackMatter.Resources
+	require.Len(t, resources, 1)
+	require.Equal(t, "report.json", resources[0].Title)
+	require.Equal(t, "application/json", resources[0].Base64.MediaType)
+	rlinks := *resources[0].Rlinks
+	require.Len(t, rlinks, 2)
+	require.Equal(t, filepath.ToSlash(stored), rlinks[0].Href)
+	require.Equal(t, "kyverno/report.json", rlinks[1].Href)
+	hash := (*rlinks[0].Hashes)[0]
+	require.Equal(t, "SHA-256", hash.Algorithm)
+
+	var hrefs []string
+	for _, oscalObs := range *ar.Results[0].Observations {
+		for _, evidence := range utils.ValueOrEmpty(oscalObs.RelevantEvidence) {
+			hrefs = append(hrefs, evidence.Href)
+		}
+	}
+	require.Equal(t, []string{
+		"#" + resources[0].UUID,
+		"#" + resources[0].UUID,
+		filepath.ToSlash(outside),
+		filepath.ToSlash(escape),
+		"kyverno/missing.json",
+		filepath.ToSlash(broken),
+		"https://test-related-evidence-1",
+	}, hrefs)
+}
+
 func TestToOscalObservation(t *testing.T) {
 	inputContext := inputContextHelper(t)
 	rulesStore := inputContext.Store()
@@ -134,11 +347,53 @@ func TestToOscalObservation(t *testing.T) {
 	}
 }
 
-func TestShouldGenerateFinding(t *testing.T) {
+func TestToOscalObservation_Severity(t *testing.T) {
+	inputContext := inputContextHelper(t)
+	observationByCheck := pvpResults[0].ObservationsByCheck[0]
+	ruleSet, err := inputContext.Store().GetByCheckID(context.TODO(), observationByCheck.CheckID)
+	require.NoError(t, err)
+
+	observationByCheck.Severity = policy.SeverityHigh
+	observationByCheck.Remediation = "Add the missing label"
+	observationByCheck.Subjects = []policy.Subject{observationByCheck.Subjects[0]}
+	observationByCheck.Subjects[0].Severity = policy.SeverityCritical
+
+	oscalObs, err := toOscalObservation(observationByCheck, ruleSet, &map[string]string{})
+	require.NoError(t, err)
+	severity, found := extensions.GetTrestleProp(SeverityProp, *oscalObs.Props)
+	require.True(t, found)
+	require.Equal(t, "high", severity.Value)
+	remediation, found := extensions.GetTrestleProp(RemediationProp, *oscalObs.Props)
+	require.True(t, found)
+	require.Equal(t, "Add the missing label", remediation.Value)
+
+	subjectProps := *(*oscalObs.Subjects)[0].Props
+	severity, found = extensions.GetTrestleProp(SeverityProp, subjectProps)
+	require.True(t, found)
+	require.Equal(t, "critical", severity.Value)
+	_, found = extensions.GetTrestleProp(RemediationProp, subjectProps)
+	require.False(t, found)
+}
+
+func TestToOscalObservation_EmptyReason(t *testing.T) {
+	inputContext := inputContextHelper(t)
+	observationByCheck := pvpResults[0].ObservationsByCheck[0]
+	ruleSet, err := inputContext.Store().GetByCheckID(context.TODO(), observationByCheck.CheckID)
+	require.NoError(t, err)
+
+	observationByCheck.Subjects = []policy.Subject{observationByCheck.Subjects[0]}
+	observationByCheck.Subjects[0].Reason = ""
+	oscalObs, err := toOscalObservation(observationByCheck, ruleSet, &map[string]string{})
+	require.NoError(t, err)
+	_, found := extensions.GetTrestleProp("reason", *(*oscalObs.Subjects)[0].Props)
+	require.False(t, found)
+}
+
+func TestObservationFindingStatus(t *testing.T) {
 	tests := []struct {
 		name        string
 		observation oscalTypes.Observation
-		result      bool
+		status      findingStatus
 	}{
 		{
 			name: "Success/NewFinding",
@@ -156,7 +411,7 @@ func TestShouldGenerateFinding(t *testing.T) {
 					},
 				},
 			},
-			result: true,
+			status: findingFail,
 		},
 		{
 			name: "Success/Waived",
@@ -179,7 +434,7 @@ func TestShouldGenerateFinding(t *testing.T) {
 					},
 				},
 			},
-			result: false,
+			status: findingNone,
 		},
 		{
 			name: "Success/WithStatus",
@@ -208,7 +463,7 @@ func TestShouldGenerateFinding(t *testing.T) {
 					},
 				},
 			},
-			result: true,
+			status: findingFail,
 		},
 		{
 			name: "Success/WaivedSubject",
@@ -242,7 +497,7 @@ func TestShouldGenerateFinding(t *testing.T) {
 					},
 				},
 			},
-			result: false,
+			status: findingNone,
 		},
 		{
 			name: "Success/MixedWaivedAndFailed",
@@ -285,15 +540,153 @@ func TestShouldGenerateFinding(t *testing.T) {
 					},
 				},
 			},
-			result: true,
+			status: findingFail,
+		},
+		{
+			name: "Success/NotApplicable",
+			observation: oscalTypes.Observation{
+				Props: &[]oscalTypes.Property{
+					{
+						Name:  extensions.AssessmentRuleIdProp,
+						Value: "example",
+						Ns:    extensions.TrestleNameSpace,
+					},
+				},
+				Subjects: &[]oscalTypes.SubjectReference{
+					{
+						Props: &[]oscalTypes.Property{
+							{
+								Name:  "result",
+								Value: policy.ResultNotApplicable.String(),
+								Ns:    extensions.TrestleNameSpace,
+							},
+						},
+					},
+				},
+			},
+			status: findingNone,
+		},
+		{
+			name: "Success/Skipped",
+			observation: oscalTypes.Observation{
+				Props: &[]oscalTypes.Property{
+					{
+						Name:  extensions.AssessmentRuleIdProp,
+						Value: "example",
+						Ns:    extensions.TrestleNameSpace,
+					},
+				},
+				Subjects: &[]oscalTypes.SubjectReference{
+					{
+						Props: &[]oscalTypes.Property{
+							{
+								Name:  "result",
+								Value: policy.ResultSkipped.String(),
+								Ns:    extensions.TrestleNameSpace,
+							},
+						},
+					},
+				},
+			},
+			status: findingReview,
+		},
+		{
+			name: "Success/Manual",
+			observation: oscalTypes.Observation{
+				Props: &[]oscalTypes.Property{
+					{
+						Name:  extensions.AssessmentRuleIdProp,
+						Value: "example",
+						Ns:    extensions.TrestleNameSpace,
+					},
+				},
+				Subjects: &[]oscalTypes.SubjectReference{
+					{
+						Props: &[]oscalTypes.Property{
+							{
+								Name:  "result",
+								Value: policy.ResultManual.String(),
+								Ns:    extensions.TrestleNameSpace,
+							},
+						},
+					},
+				},
+			},
+			status: findingReview,
+		},
+		{
+			name: "Success/SkippedAndFailed",
+			observation: oscalTypes.Observation{
+				Props: &[]oscalTypes.Property{
+					{
+						Name:  extensions.AssessmentRuleIdProp,
+						Value: "example",
+						Ns:    extensions.TrestleNameSpace,
+					},
+				},
+				Subjects: &[]oscalTypes.SubjectReference{
+					{
+						Props: &[]oscalTypes.Property{
+							{
+								Name:  "result",
+								Value: policy.ResultSkipped.String(),
+								Ns:    extensions.TrestleNameSpace,
+							},
+						},
+					},
+					{
+						Props: &[]oscalTypes.Property{
+							{
+								Name:  "result",
+								Value: policy.ResultFail.String(),
+								Ns:    extensions.TrestleNameSpace,
+							},
+						},
+					},
+				},
+			},
+			status: findingFail,
+		},
+		{
+			name: "Success/ErrorAndSkipped",
+			observation: oscalTypes.Observation{
+				Props: &[]oscalTypes.Property{
+					{
+						Name:  extensions.AssessmentRuleIdProp,
+						Value: "example",
+						Ns:    extensions.TrestleNameSpace,
+					},
+				},
+				Subjects: &[]oscalTypes.SubjectReference{
+					{
+						Props: &[]oscalTypes.Property{
+							{
+								Name:  "result",
+								Value: policy.ResultError.String(),
+								Ns:    extensions.TrestleNameSpace,
+							},
+						},
+					},
+					{
+						Props: &[]oscalTypes.Property{
+							{
+								Name:  "result",
+								Value: policy.ResultSkipped.String(),
+								Ns:    extensions.TrestleNameSpace,
+							},
+						},
+					},
+				},
+			},
+			status: findingError,
 		},
 	}
 
 	for _, c := range tests {
 		t.Run(c.name, func(t *testing.T) {
 			obs := c.observation
-			result := shouldGenerateFindings(obs)
-			assert.Equal(t, c.result, result, "shouldGenerateFindings should return %v", c.result)
+			status := observationFindingStatus(obs)
+			assert.Equal(t, c.status, status, "observationFindingStatus should return %v", c.status)
 		})
 	}
 }
@@ -313,16 +706,83 @@ func TestGenerateFindings(t *testing.T) {
 	tests := []struct {
 		name         string
 		initFindings []oscalTypes.Finding
+		status       findingStatus
 		assertFunc   func(*testing.T, []oscalTypes.Finding)
 	}{
 		{
 			name:         "Success/NewFinding",
 			initFindings: []oscalTypes.Finding{},
+			status:       findingFail,
 			assertFunc: func(t *testing.T, findings []oscalTypes.Finding) {
 				require.Len(t, findings, 1)
 				relObs := *findings[0].RelatedObservations
 				require.Len(t, relObs, 1)
 				require.Equal(t, relObs[0].ObservationUuid, oscalObservation.UUID)
+				require.Equal(t, oscalTypes.ObjectiveStatus{State: "not-satisfied", Reason: "fail"}, findings[0].Target.Status)
+			},
+		},
+		{
+			name:         "Success/NewReviewFinding",
+			initFindings: []oscalTypes.Finding{},
+			status:       findingReview,
+			assertFunc: func(t *testing.T, findings []oscalTypes.Finding) {
+				require.Len(t, findings, 1)
+				require.Equal(t, "other", findings[0].Target.Status.Reason)
+				require.Contains(t, findings[0].Target.Status.Remarks, "Needs review")
+			},
+		},
+		{
+			name:         "Success/NewErrorFinding",
+			initFindings: []oscalTypes.Finding{},
+			status:       findingError,
+			assertFunc: func(t *testing.T, findings []oscalTypes.Finding) {
+				require.Len(t, findings, 1)
+				require.Equal(t, "other", findings[0].Target.Status.Reason)
+				require.Contains(t, findings[0].Target.Status.Remarks, "Could not be assessed")
+			},
+		},
+		{
+			name: "Success/ExistingReviewFindingFails",
+			initFindings: []oscalTypes.Finding{
+				{
+					Target: oscalTypes.FindingTarget{
+						TargetId: "CIS-2.1_smt",
+						Type:     "statement-id",
+						Status:   findingReview.objectiveStatus(),
+					},
+					RelatedObservations: &[]oscalTypes.RelatedObservation{
+						{
+							ObservationUuid: "1234",
+						},
+					},
+				},
+			},
+			status: findingFail,
+			assertFunc: func(t *testing.T, findings []oscalTypes.Finding) {
+				require.Len(t, findings, 1)
+				require.Equal(t, findingFail.objectiveStatus(), findings[0].Target.Status)
+			},
+		},
+		{
+			name: "Success/ExistingFailedFindingKeepsStatus",
+			initFindings: []oscalTypes.Finding{
+				{
+					Target: oscalTypes.FindingTarget{
+						TargetId: "CIS-2.1_smt",
+						Type:     "statement-id",
+						Status:   findingFail.objectiveStatus(),
+					},
+					RelatedObservations: &[]oscalTypes.RelatedObservation{
+						{
+							ObservationUuid: "1234",
+						},
+					},
+				},
+			},
+			status: findingError,
+			assertFunc: func(t *testing.T, findings []oscalTypes.Finding) {
+				require.Len(t, findings, 1)
+				require.Equal(t, findingFail.objectiveStatus(), findings[0].Target.Status)
 			},
 		},
 		{
@@ -343,6 +803,7 @@ func TestGenerateFindings(t *testing.T) {
 					},
 				},
 			},
+			status: findingFail,
 			assertFunc: func(t *testing.T, findings []oscalTypes.Finding) {
 				require.Len(t, findings, 1)
 				relObs := *findings[0].RelatedObservations
@@ -367,6 +828,7 @@ func TestGenerateFindings(t *testing.T) {
 					},
 				},
 			},
+			status: findingFail,
 			assertFunc: func(t *testing.T, findings []oscalTypes.Finding) {
 				require.Len(t, findings, 2)
 				for _, f := range findings {
@@ -378,7 +840,7 @@ func TestGenerateFindings(t *testing.T) {
 	}
 
 	for _, c := range tests {
-		findings, err := generateFindings(c.initFindings, oscalObservation, []string{"CIS-2.1_smt"})
+		findings, err := generateFindings(c.initFindings, oscalObservation, []string{"CIS-2.1_smt"}, c.status)
 		require.NoError(t, err)
 		c.assertFunc(t, findings)
 	}
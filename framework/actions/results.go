@@ -0,0 +1,81 @@
+/*
+ Copyright 2025 The OSCAL Compass Authors
+ SPDX-License-Identifier: Apache-2.0
+*/
+
+package actions
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"sigs.k8s.io/yaml"
+
+	"github.com/oscal-compass/compliance-to-policy-go/v2/plugin"
+	"github.com/oscal-compass/compliance-to-policy-go/v2/policy"
+)
+
+// LoadResults loads a policy.PVPResult saved as JSON or YAML, so results
+// collected offline can be reported without launching the plugins that produced them.
+//
+// Unknown fields are rejected to catch files that were not written from a policy.PVPResult.
+func LoadResults(path string) (policy.PVPResult, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return policy.PVPResult{}, err
+	}
+	var result policy.PVPResult
+	if err := yaml.UnmarshalStrict(data, &result); err != nil {
+		return policy.PVPResult{}, fmt.Errorf("error loading results from %s: %w", path, err)
+	}
+	return result, nil
+}
+
+// ResultsFile is a saved policy.PVPResult and the plugin that produced it.
+type ResultsFile struct {
+	// Path is the path of the saved results.
+	Path string
+	// PluginID identifies the plugin or plugin instance that produced the
+	// results. If empty, the results are reported without a plugin instance.
+	PluginID plugin.ID
+}
+
+// ParseResultsFile parses a results file in the form [<plugin-id>=]<path>, e.g.
+// kyverno@prod=results.json.
+func ParseResultsFile(value string) (ResultsFile, error) {
+	id, path, found := strings.Cut(value, "=")
+	if !found || !plugin.ID(id).Validate() {
+		return ResultsFile{Path: value}, nil
+	}
+	if path == "" {
+		return ResultsFile{}, fmt.Errorf("missing path for results file of plugin %s", id)
+	}
+	return ResultsFile{Path: path, PluginID: plugin.ID(id)}, nil
+}
+
+// Load loads the saved results. If the PluginID has an instance, it is set as the
+// PluginInstanceProp of each observation, as for results from StreamResults.
+func (f ResultsFile) Load() (policy.PVPResult, error) {
+	result, err := LoadResults(f.Path)
+	if err != nil {
+		return policy.PVPResult{}, err
+	}
+	if f.PluginID.Instance() == "" {
+		return result, nil
+	}
+	for i, observation := range result.ObservationsByCheck {
+		instance := newObservationKey(observation).instance
+		if instance != "" && instance != f.PluginID.String() {
+			return policy.PVPResult{}, fmt.Errorf("results file %s: observation for check %s is from plugin instance %s, not %s",
+				f.Path, observation.CheckID, instance, f.PluginID)
+		}
+		if instance == "" {
+			result.ObservationsByCheck[i].Props = append(result.ObservationsByCheck[i].Props, policy.Property{
+				Name:  PluginInstanceProp,
+				Value: f.PluginID.String(),
+			})
+		}
+	}
+	return result, nil
+}
@@ -0,0 +1,132 @@
+/*
+ Copyright 2025 The OSCAL Compass Authors
+ SPDX-License-Identifier: Apache-2.0
+*/
+
+package actions
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/oscal-compass/compliance-to-policy-go/v2/policy"
+)
+
+func TestLoadResults(t *testing.T) {
+	collected := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+	want := policy.PVPResult{
+		ObservationsByCheck: []policy.ObservationByCheck{
+			{
+				Title:    "etcd_cert_file",
+				CheckID:  "etcd_cert_file",
+				Methods:  []string{"AUTOMATED"},
+				Severity: policy.SeverityHigh,
+				Subjects: []policy.Subject{
+					{
+						Title:       "etcd",
+						Type:        "resource",
+						ResourceID:  "etcd-pod",
+						Result:      policy.ResultNotApplicable,
+						EvaluatedOn: collected,
+						Reason:      "etcd is managed",
+					},
+				},
+				Collected: collected,
+				Props:     []policy.Property{{Name: PluginInstanceProp, Value: "kyverno@prod"}},
+			},
+		},
+		Links: []policy.Link{{Href: "https://example.com/report"}},
+	}
+	dir := t.TempDir()
+
+	// Results saved with encoding/json can be loaded
+	data, err := json.Marshal(want)
+	require.NoError(t, err)
+	jsonPath := filepath.Join(dir, "results.json")
+	require.NoError(t, os.WriteFile(jsonPath, data, 0600))
+	result, err := LoadResults(jsonPath)
+	require.NoError(t, err)
+	require.Equal(t, want, result)
+
+	yamlPath := filepath.Join(dir, "results.yaml")
+	require.NoError(t, os.WriteFile(yamlPath, []byte(`observations:
+- title: etcd_cert_file
+  checkId: etcd_cert_file
+  methods: [AUTOMATED]
+  severity: high
+  collected: 2025-03-01T12:00:00Z
+  props:
+  - name: plugin-instance
+    value: kyverno@prod
+  subjects:
+  - title: etcd
+    type: resource
+    resourceId: etcd-pod
+    result: not-applicable
+    evaluatedOn: 2025-03-01T12:00:00Z
+    reason: etcd is managed
+links:
+- href: https://example.com/report
+`), 0600))
+	result, err = LoadResults(yamlPath)
+	require.NoError(t, err)
+	require.Equal(t, want, result)
+
+	invalidPath := filepath.Join(dir, "invalid.yaml")
+	require.NoError(t, os.WriteFile(invalidPath, []byte("observations:\n- checkId: etcd_cert_file\n  subjects:\n  - result: passed\n"), 0600))
+	_, err = LoadResults(invalidPath)
+	require.ErrorContains(t, err, `unknown result "passed"`)
+
+	require.NoError(t, os.WriteFile(invalidPath, []byte("observations: []\nresults: []\n"), 0600))
+	_, err = LoadResults(invalidPath)
+	require.ErrorContains(t, err, `unknown field "results"`)
+}
+
+func TestParseResultsFile(t *testing.T) {
+	resultsFile, err := ParseResultsFile("kyverno@prod=results.json")
+	require.NoError(t, err)
+	require.Equal(t, ResultsFile{Path: "results.json", PluginID: "kyverno@prod"}, resultsFile)
+
+	// Values without a valid plugin id are paths
+	for _, value := range []string{"results.json", "./out/a=b.json"} {
+		resultsFile, err = ParseResultsFile(value)
+		require.NoError(t, err)
+		require.Equal(t, ResultsFile{Path: value}, resultsFile)
+	}
+
+	_, err = ParseResultsFile("kyverno=")
+	require.ErrorContains(t, err, "missing path")
+}
+
+func TestResultsFile_Load(t *testing.T) {
+	result := policy.PVPResult{
+		ObservationsByCheck: []policy.ObservationByCheck{
+			{CheckID: "etcd_cert_file"},
+			{CheckID: "etcd_key_file", Props: []policy.Property{{Name: PluginInstanceProp, Value: "kyverno@prod"}}},
+		},
+	}
+	data, err := json.Marshal(result)
+	require.NoError(t, err)
+	path := filepath.Join(t.TempDir(), "results.json")
+	require.NoError(t, os.WriteFile(path, data, 0600))
+
+	// The instance is set on the observations without one
+	loaded, err := ResultsFile{Path: path, PluginID: "kyverno@prod"}.Load()
+	require.NoError(t, err)
+	for _, observation := range loaded.ObservationsByCheck {
+		require.Equal(t, []policy.Property{{Name: PluginInstanceProp, Value: "kyverno@prod"}}, observation.Props)
+	}
+
+	// Plugins without an instance are not added, as for StreamResults
+	loaded, err = ResultsFile{Path: path, PluginID: "kyverno"}.Load()
+	require.NoError(t, err)
+	require.Equal(t, result, loaded)
+
+	_, err = ResultsFile{Path: path, PluginID: "kyverno@dev"}.Load()
+	require.ErrorContains(t, err, "is from plugin instance kyverno@prod, not kyverno@dev")
+}
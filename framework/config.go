@@ -10,6 +10,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"regexp"
 	"strings"
 
 	"github.com/hashicorp/go-hclog"
@@ -31,9 +32,48 @@ type C2PConfig struct {
 	// PluginManifestDir is the directory where the PluginManager searches
 	// for installed plugin manifests.
 	PluginManifestDir string
+	// PluginPath are additional directories where the PluginManager
+	// searches for installed plugins. See SearchPath for the precedence
+	// of the plugin directories.
+	PluginPath []plugin.SearchDir
 	// Logger is the logging implementation used in the PluginManager and
 	// plugin clients.
 	Logger hclog.Logger
+	// PluginAliases maps validation component titles to plugin IDs
+	// for components that are not titled with the plugin ID.
+	PluginAliases map[string]plugin.ID
+	// CallPolicies override the call policy in the manifest for
+	// plugins by ID. Options for a plugin instance (e.g. kyverno@prod)
+	// are applied over the options for the plugin.
+	CallPolicies map[plugin.ID]plugin.CallPolicy
+	// RelaunchPlugins relaunches and reconfigures a crashed plugin
+	// once before failing the call to the plugin.
+	RelaunchPlugins bool
+	// TrustedKeys are paths to PEM encoded Ed25519 or ECDSA public keys.
+	// If set, plugin manifests with a detached signature are verified
+	// with the keys before the plugins are launched.
+	TrustedKeys []string
+	// RequireSignedPlugins refuses to launch plugins without a
+	// manifest signature from one of the TrustedKeys.
+	RequireSignedPlugins bool
+	// Reattach attaches to running plugins by ID instead of launching the
+	// plugin executable, overriding the reattach configuration in the manifest.
+	// Options for a plugin instance (e.g. kyverno@prod) take precedence over
+	// the options for the plugin.
+	Reattach map[plugin.ID]plugin.ReattachConfig
+	// EvidenceDir is the directory where evidence stored by plugins with
+	// the host services is written, in a directory for each plugin ID.
+	// If not set, plugins cannot store evidence.
+	EvidenceDir string
+	// WorkDir is the directory that relative paths from plugins are
+	// resolved against. Defaults to the current working directory.
+	WorkDir string
+	// ProgressHandler receives the progress events reported by plugins.
+	// If not set, progress events are logged.
+	ProgressHandler ProgressHandler
+	// Registry contains plugins that run in-process. Registered plugins
+	// take precedence over discovered plugins with the same ID.
+	Registry *plugin.Registry
 }
 
 var defaultLogger = hclog.New(&hclog.LoggerOptions{
@@ -51,6 +91,17 @@ func DefaultConfig() *C2PConfig {
 	}
 }
 
+// SearchPath returns the ordered plugin search path. Plugins in earlier directories
+// take precedence over plugins with the same ID in later directories. The order is:
+//  1. PluginDir and PluginManifestDir
+//  2. The directories in PluginPath
+//  3. The directories in the plugin.PluginPathEnv environment variable
+func (c *C2PConfig) SearchPath() []plugin.SearchDir {
+	searchPath := []plugin.SearchDir{{PluginDir: c.PluginDir, ManifestDir: c.PluginManifestDir}}
+	searchPath = append(searchPath, c.PluginPath...)
+	return append(searchPath, plugin.SearchPathFromEnv()...)
+}
+
 // Validate returns an error if C2PConfig has invalid fields.
 func (c *C2PConfig) Validate() error {
 	// Sanitize the plugin directory input
@@ -59,18 +110,135 @@ func (c *C2PConfig) Validate() error {
 	if strings.TrimSpace(c.PluginDir) == "" {
 		return fmt.Errorf("plugin directory cannot be empty")
 	}
+	// The plugin directory may only be missing when
+	// plugins can be found in other directories.
 	if _, err := os.Stat(c.PluginDir); err != nil {
-		if errors.Is(err, os.ErrNotExist) {
+		if !errors.Is(err, os.ErrNotExist) {
+			return err
+		}
+		if len(c.SearchPath()) == 1 {
 			return fmt.Errorf("plugin directory %s does not exist: %w", c.PluginDir, err)
 		}
-		return err
+	}
+	for _, dir := range c.PluginPath {
+		if strings.TrimSpace(dir.PluginDir) == "" {
+			return fmt.Errorf("plugin directory in plugin path cannot be empty")
+		}
 	}
 	if c.Logger == nil {
 		c.Logger = defaultLogger
 	}
+	if c.RequireSignedPlugins && len(c.TrustedKeys) == 0 {
+		return fmt.Errorf("trusted keys are required to verify signed plugins")
+	}
+	for title, id := range c.PluginAliases {
+		if !id.Validate() {
+			return fmt.Errorf("invalid plugin id %q for alias %q", id, title)
+		}
+	}
+	for id, callPolicy := range c.CallPolicies {
+		if !id.Validate() {
+			return fmt.Errorf("invalid plugin id %q for call policy", id)
+		}
+		if err := callPolicy.Validate(); err != nil {
+			return fmt.Errorf("invalid call policy for plugin %s: %w", id, err)
+		}
+	}
+	for id, reattach := range c.Reattach {
+		if !id.Validate() {
+			return fmt.Errorf("invalid plugin id %q for reattach", id)
+		}
+		if err := reattach.Validate(); err != nil {
+			return fmt.Errorf("invalid reattach configuration for plugin %s: %w", id, err)
+		}
+	}
 	return nil
 }
 
+// Verifier returns a plugin.Verifier for the TrustedKeys
+// or nil if no keys are set.
+func (c *C2PConfig) Verifier() (*plugin.Verifier, error) {
+	if len(c.TrustedKeys) == 0 {
+		return nil, nil
+	}
+	keys, err := plugin.LoadPublicKeys(c.TrustedKeys...)
+	if err != nil {
+		return nil, err
+	}
+	var opts []plugin.VerifierOption
+	if c.RequireSignedPlugins {
+		opts = append(opts, plugin.WithRequiredSignatures())
+	}
+	return plugin.NewVerifier(keys, opts...)
+}
+
 // PluginConfig is a function signature that returns configuration
 // option key, value pairs for a given plugin id.
 type PluginConfig func(id plugin.ID) map[string]string
+
+const (
+	// envRefPrefix is the prefix for configuration values read
+	// from an environment variable.
+	envRefPrefix = "env:"
+	// fileRefPrefix is the prefix for configuration values read
+	// from a file.
+	fileRefPrefix = "file:"
+)
+
+var envVarPattern = regexp.MustCompile(`\$\{([A-Za-z_][A-Za-z0-9_]*)\}`)
+
+// resolveReferences resolves secret references in the given configuration selections.
+//
+// Supported references are:
+//   - env:VAR - the value of the environment variable VAR
+//   - file:/path - the contents of the file with trailing newlines removed
+//   - ${VAR} - expanded to the value of the environment variable VAR within the value
+//
+// The names of options with resolved references are returned so values can be treated as sensitive.
+// Errors never include resolved values.
+func resolveReferences(selections map[string]string) (map[string]string, map[string]struct{}, error) {
+	resolved := make(map[string]string, len(selections))
+	referenced := make(map[string]struct{})
+	var errs []error
+	for name, value := range selections {
+		switch {
+		case strings.HasPrefix(value, envRefPrefix):
+			envVar := strings.TrimPrefix(value, envRefPrefix)
+			envValue, ok := os.LookupEnv(envVar)
+			if !ok {
+				errs = append(errs, fmt.Errorf("option %q: environment variable %q is not set", name, envVar))
+				continue
+			}
+			resolved[name] = envValue
+			referenced[name] = struct{}{}
+		case strings.HasPrefix(value, fileRefPrefix):
+			path := filepath.Clean(strings.TrimPrefix(value, fileRefPrefix))
+			content, err := os.ReadFile(path)
+			if err != nil {
+				errs = append(errs, fmt.Errorf("option %q: failed to read file reference: %w", name, err))
+				continue
+			}
+			resolved[name] = strings.TrimRight(string(content), "\r\n")
+			referenced[name] = struct{}{}
+		case envVarPattern.MatchString(value):
+			var missing []string
+			expanded := envVarPattern.ReplaceAllStringFunc(value, func(match string) string {
+				envVar := envVarPattern.FindStringSubmatch(match)[1]
+				envValue, ok := os.LookupEnv(envVar)
+				if !ok {
+					missing = append(missing, envVar)
+				}
+				return envValue
+			})
+			if len(missing) > 0 {
+				errs = append(errs, fmt.Errorf("option %q: environment variables not set: %s", name, strings.Join(missing, ", ")))
+				continue
+			}
+			resolved[name] = expanded
+			referenced[name] = struct{}{}
+		default:
+			resolved[name] = value
+		}
+	}
+	return resolved, referenced, errors.Join(errs...)
+}
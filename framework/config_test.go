@@ -6,9 +6,13 @@
 package framework
 
 import (
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/stretchr/testify/require"
+
+	"github.com/oscal-compass/compliance-to-policy-go/v2/plugin"
 )
 
 func TestC2PConfig_Validate(t *testing.T) {
@@ -18,6 +22,33 @@ func TestC2PConfig_Validate(t *testing.T) {
 	config.Logger = nil
 	require.NoError(t, config.Validate())
 	require.NotNil(t, config.Logger)
+
+	config.RequireSignedPlugins = true
+	require.EqualError(t, config.Validate(), "trusted keys are required to verify signed plugins")
+	config.RequireSignedPlugins = false
+
+	config.PluginAliases = map[string]plugin.ID{"Kyverno Prod Cluster": "Kyverno Prod"}
+	require.EqualError(t, config.Validate(), "invalid plugin id \"Kyverno Prod\" for alias \"Kyverno Prod Cluster\"")
+}
+
+func TestC2PConfig_SearchPath(t *testing.T) {
+	t.Setenv(plugin.PluginPathEnv, "")
+	config := DefaultConfig()
+	config.PluginDir = "c2p-plugins"
+	require.EqualError(t, config.Validate(), "plugin directory c2p-plugins does not exist: stat c2p-plugins: no such file or directory")
+
+	// The plugin directory is not required with other directories in the search path
+	config.PluginPath = []plugin.SearchDir{{PluginDir: "shared-plugins"}}
+	t.Setenv(plugin.PluginPathEnv, "env-plugins")
+	require.NoError(t, config.Validate())
+	require.Equal(t, []plugin.SearchDir{
+		{PluginDir: "c2p-plugins", ManifestDir: "c2p-plugins"},
+		{PluginDir: "shared-plugins"},
+		{PluginDir: "env-plugins"},
+	}, config.SearchPath())
+
+	config.PluginPath = []plugin.SearchDir{{PluginDir: " "}}
+	require.EqualError(t, config.Validate(), "plugin directory in plugin path cannot be empty")
 }
 
 func TestDefaultConfig(t *testing.T) {
@@ -26,3 +57,77 @@ func TestDefaultConfig(t *testing.T) {
 	require.Equal(t, defaultConfig.PluginManifestDir, DefaultPluginManifestPath)
 	require.NotNil(t, defaultConfig.Logger)
 }
+
+func TestResolveReferences(t *testing.T) {
+	t.Setenv("C2P_TEST_TOKEN", "secret-token")
+	t.Setenv("C2P_TEST_HOST", "example.com")
+	secretFile := filepath.Join(t.TempDir(), "secret")
+	require.NoError(t, os.WriteFile(secretFile, []byte("file-secret\n"), 0600))
+
+	tests := []struct {
+		name           string
+		selections     map[string]string
+		wantResolved   map[string]string
+		wantReferenced map[string]struct{}
+		wantError      string
+	}{
+		{
+			name: "Valid/References",
+			selections: map[string]string{
+				"plain":  "value",
+				"env":    "env:C2P_TEST_TOKEN",
+				"file":   "file:" + secretFile,
+				"expand": "https://${C2P_TEST_HOST}/api",
+			},
+			wantResolved: map[string]string{
+				"plain":  "value",
+				"env":    "secret-token",
+				"file":   "file-secret",
+				"expand": "https://example.com/api",
+			},
+			wantReferenced: map[string]struct{}{"env": {}, "file": {}, "expand": {}},
+		},
+		{
+			name: "Valid/NoReferences",
+			selections: map[string]string{
+				"plain": "$HOME",
+			},
+			wantResolved:   map[string]string{"plain": "$HOME"},
+			wantReferenced: map[string]struct{}{},
+		},
+		{
+			name: "Invalid/MissingEnv",
+			selections: map[string]string{
+				"env": "env:C2P_TEST_NOT_SET",
+			},
+			wantError: "option \"env\": environment variable \"C2P_TEST_NOT_SET\" is not set",
+		},
+		{
+			name: "Invalid/MissingExpansion",
+			selections: map[string]string{
+				"expand": "${C2P_TEST_HOST}:${C2P_TEST_NOT_SET}",
+			},
+			wantError: "option \"expand\": environment variables not set: C2P_TEST_NOT_SET",
+		},
+		{
+			name: "Invalid/MissingFile",
+			selections: map[string]string{
+				"file": "file:not-exist",
+			},
+			wantError: "option \"file\": failed to read file reference: open not-exist: no such file or directory",
+		},
+	}
+
+	for _, c := range tests {
+		t.Run(c.name, func(t *testing.T) {
+			resolved, referenced, err := resolveReferences(c.selections)
+			if c.wantError != "" {
+				require.EqualError(t, err, c.wantError)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, c.wantResolved, resolved)
+			require.Equal(t, c.wantReferenced, referenced)
+		})
+	}
+}
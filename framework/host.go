@@ -0,0 +1,85 @@
+/*
+ Copyright 2025 The OSCAL Compass Authors
+ SPDX-License-Identifier: Apache-2.0
+*/
+
+package framework
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/hashicorp/go-hclog"
+
+	"github.com/oscal-compass/compliance-to-policy-go/v2/plugin"
+	"github.com/oscal-compass/compliance-to-policy-go/v2/policy"
+)
+
+// ProgressHandler receives the progress events reported by a plugin.
+type ProgressHandler func(id plugin.ID, event policy.ProgressEvent)
+
+var _ policy.Host = (*pluginHost)(nil)
+
+// pluginHost implements the host services for a single plugin
+// launched by the PluginManager.
+type pluginHost struct {
+	id          plugin.ID
+	evidenceDir string
+	workDir     string
+	onProgress  ProgressHandler
+	log         hclog.Logger
+}
+
+// StoreEvidence writes the evidence to a directory for the plugin
+// under the evidence directory and links to the absolute path of
+// the written file.
+func (h *pluginHost) StoreEvidence(_ context.Context, evidence policy.Evidence) (policy.Link, error) {
+	if h.evidenceDir == "" {
+		return policy.Link{}, errors.New("no evidence directory is configured on the host")
+	}
+	name := evidence.Name
+	if name == "" || name == "." || name == ".." || strings.ContainsAny(name, `/\`) {
+		return policy.Link{}, fmt.Errorf("invalid evidence name %q", name)
+	}
+	dir := filepath.Join(h.evidenceDir, h.id.String())
+	if err := os.MkdirAll(dir, 0750); err != nil {
+		return policy.Link{}, fmt.Errorf("failed to create evidence directory: %w", err)
+	}
+	path, err := filepath.Abs(filepath.Join(dir, name))
+	if err != nil {
+		return policy.Link{}, fmt.Errorf("failed to resolve evidence path: %w", err)
+	}
+	if err := os.WriteFile(path, evidence.Content, 0600); err != nil {
+		return policy.Link{}, fmt.Errorf("failed to write evidence %s: %w", name, err)
+	}
+	h.log.Debug(fmt.Sprintf("Stored evidence %s from plugin %s", path, h.id))
+	return policy.Link{Description: evidence.Description, Href: filepath.ToSlash(path)}, nil
+}
+
+func (h *pluginHost) ReportProgress(_ context.Context, event policy.ProgressEvent) error {
+	if h.onProgress != nil {
+		h.onProgress(h.id, event)
+		return nil
+	}
+	if event.Total > 0 {
+		h.log.Info(fmt.Sprintf("Plugin %s: %s (%d/%d)", h.id, event.Message, event.Current, event.Total))
+	} else {
+		h.log.Info(fmt.Sprintf("Plugin %s: %s", h.id, event.Message))
+	}
+	return nil
+}
+
+// ResolvePath resolves relative paths against the working directory.
+func (h *pluginHost) ResolvePath(_ context.Context, path string) (string, error) {
+	if path == "" {
+		return "", errors.New("path cannot be empty")
+	}
+	if filepath.IsAbs(path) {
+		return filepath.Clean(path), nil
+	}
+	return filepath.Abs(filepath.Join(h.workDir, path))
+}
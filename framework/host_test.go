@@ -0,0 +1,108 @@
+/*
+ Copyright 2025 The OSCAL Compass Authors
+ SPDX-License-Identifier: Apache-2.0
+*/
+
+package framework
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/stretchr/testify/require"
+
+	"github.com/oscal-compass/compliance-to-policy-go/v2/plugin"
+	"github.com/oscal-compass/compliance-to-policy-go/v2/policy"
+)
+
+func TestPluginHost_StoreEvidence(t *testing.T) {
+	evidenceDir := t.TempDir()
+	host := &pluginHost{id: "kyverno@prod", evidenceDir: evidenceDir, log: hclog.NewNullLogger()}
+
+	link, err := host.StoreEvidence(context.TODO(), policy.Evidence{Name: "report.json", Description: "Policy report", Content: []byte("{}")})
+	require.NoError(t, err)
+	wantPath := filepath.Join(evidenceDir, "kyverno@prod", "report.json")
+	require.Equal(t, policy.Link{Description: "Policy report", Href: filepath.ToSlash(wantPath)}, link)
+	content, err := os.ReadFile(wantPath)
+	require.NoError(t, err)
+	require.Equal(t, "{}", string(content))
+
+	for _, name := range []string{"", "..", "../report.json", "dir/report.json"} {
+		_, err = host.StoreEvidence(context.TODO(), policy.Evidence{Name: name})
+		require.EqualError(t, err, "invalid evidence name \""+name+"\"")
+	}
+
+	// Links are absolute for relative evidence directories.
+	t.Chdir(evidenceDir)
+	host.evidenceDir = "evidence"
+	link, err = host.StoreEvidence(context.TODO(), policy.Evidence{Name: "report.json", Content: []byte("{}")})
+	require.NoError(t, err)
+	require.Equal(t, filepath.ToSlash(filepath.Join(evidenceDir, "evidence", "kyverno@prod", "report.json")), link.Href)
+
+	host.evidenceDir = ""
+	_, err = host.StoreEvidence(context.TODO(), policy.Evidence{Name: "report.json"})
+	require.EqualError(t, err, "no evidence directory is configured on the host")
+}
+
+func TestPluginHost_ResolvePath(t *testing.T) {
+	host := &pluginHost{workDir: "/work"}
+	resolved, err := host.ResolvePath(context.TODO(), "policies/../results")
+	require.NoError(t, err)
+	require.Equal(t, filepath.Join("/work", "results"), resolved)
+
+	resolved, err = host.ResolvePath(context.TODO(), "/etc/policies/")
+	require.NoError(t, err)
+	require.Equal(t, "/etc/policies", resolved)
+
+	_, err = host.ResolvePath(context.TODO(), "")
+	require.EqualError(t, err, "path cannot be empty")
+}
+
+func TestPluginHost_ReportProgress(t *testing.T) {
+	var events []policy.ProgressEvent
+	host := &pluginHost{id: "kyverno", onProgress: func(id plugin.ID, event policy.ProgressEvent) {
+		require.Equal(t, plugin.ID("kyverno"), id)
+		events = append(events, event)
+	}}
+	require.NoError(t, host.ReportProgress(context.TODO(), policy.ProgressEvent{Message: "Collected results", Current: 1, Total: 2}))
+	require.Equal(t, []policy.ProgressEvent{{Message: "Collected results", Current: 1, Total: 2}}, events)
+}
+
+func TestPluginManager_Host(t *testing.T) {
+	consumer := &hostConsumer{}
+	registry := plugin.NewRegistry()
+	require.NoError(t, registry.Register(plugin.Manifest{Metadata: plugin.Metadata{ID: "inproc"}}, func() (policy.Provider, error) {
+		return consumer, nil
+	}))
+
+	cfg := DefaultConfig()
+	cfg.PluginDir = "."
+	cfg.Registry = registry
+	cfg.EvidenceDir = t.TempDir()
+	cfg.WorkDir = "/work"
+	pluginManager, err := NewPluginManager(cfg)
+	require.NoError(t, err)
+
+	manifests, err := pluginManager.FindRequestedPlugins([]plugin.ID{"inproc"})
+	require.NoError(t, err)
+	_, err = pluginManager.LaunchPolicyPlugins(context.Background(), manifests, func(plugin.ID) map[string]string { return nil })
+	require.NoError(t, err)
+	host, ok := consumer.host.(*pluginHost)
+	require.True(t, ok)
+	require.Equal(t, plugin.ID("inproc"), host.id)
+	require.Equal(t, cfg.EvidenceDir, host.evidenceDir)
+	require.Equal(t, "/work", host.workDir)
+}
+
+// hostConsumer is a policy.Provider that records the host.
+type hostConsumer struct {
+	policyProvider
+	host policy.Host
+}
+
+func (c *hostConsumer) SetHost(host policy.Host) {
+	c.host = host
+}
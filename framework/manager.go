@@ -7,7 +7,12 @@ package framework
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"io"
+	"slices"
+	"strings"
+	"sync"
 
 	"github.com/hashicorp/go-hclog"
 
@@ -23,11 +28,36 @@ type PluginManager struct {
 	pluginDir string
 	// pluginManifestDir is the location to search for plugin manifests.
 	pluginManifestDir string
+	// searchPath is the ordered list of locations to search for plugins.
+	searchPath []plugin.SearchDir
 	// clientFactory is the function used to
 	// create new plugin clients.
 	clientFactory plugin.ClientFactoryFunc
+	// callPolicies override the call policy in plugin manifests.
+	callPolicies map[plugin.ID]plugin.CallPolicy
+	// reattach overrides the reattach configuration in plugin manifests.
+	reattach map[plugin.ID]plugin.ReattachConfig
+	// relaunchPlugins relaunches crashed plugins once.
+	relaunchPlugins bool
+	// verifier verifies plugin signatures if trusted keys are set.
+	verifier *plugin.Verifier
+	// registry contains the in-process plugins.
+	registry *plugin.Registry
+	// evidenceDir, workDir and onProgress configure
+	// the host services for plugins.
+	evidenceDir string
+	workDir     string
+	onProgress  ProgressHandler
 	// logger for the PluginManager
 	log hclog.Logger
+
+	// mu protects clients and closers
+	mu sync.Mutex
+	// clients are the plugin clients launched by this PluginManager
+	// by plugin ID. Relaunched plugins have more than one client.
+	clients map[plugin.ID][]*plugin.Client
+	// closers are the in-process providers that implement io.Closer.
+	closers map[plugin.ID]io.Closer
 }
 
 // NewPluginManager creates a new instance of a PluginManager from a C2PConfig that can be used to
@@ -36,7 +66,10 @@ type PluginManager struct {
 // It supports the plugin lifecycle with the following methods:
 //   - Discover plugins: FindRequestedPlugins()
 //   - Launching and initializing plugins: LaunchPolicyPlugins()
-//   - Clean/Stop - Clean()
+//   - Clean/Stop - Close() or Clean()
+//
+// Each PluginManager only stops the plugins it launched, so multiple
+// PluginManagers can be used concurrently.
 func NewPluginManager(cfg *C2PConfig) (*PluginManager, error) {
 	if err := cfg.Validate(); err != nil {
 		return nil, err
@@ -44,27 +77,83 @@ func NewPluginManager(cfg *C2PConfig) (*PluginManager, error) {
 
 	logging.SetLogger(cfg.Logger)
 
+	verifier, err := cfg.Verifier()
+	if err != nil {
+		return nil, err
+	}
+
 	return &PluginManager{
 		pluginDir:         cfg.PluginDir,
 		pluginManifestDir: cfg.PluginManifestDir,
+		searchPath:        cfg.SearchPath(),
 		clientFactory:     plugin.ClientFactory(cfg.Logger),
+		callPolicies:      cfg.CallPolicies,
+		reattach:          cfg.Reattach,
+		relaunchPlugins:   cfg.RelaunchPlugins,
+		verifier:          verifier,
+		registry:          cfg.Registry,
+		evidenceDir:       cfg.EvidenceDir,
+		workDir:           cfg.WorkDir,
+		onProgress:        cfg.ProgressHandler,
 		log:               cfg.Logger,
+		clients:           make(map[plugin.ID][]*plugin.Client),
+		closers:           make(map[plugin.ID]io.Closer),
 	}, nil
 }
 
 // FindRequestedPlugins retrieves information for the plugins that have been requested
 // returns the plugin manifests for use with LaunchPolicyPlugins().
+//
+// In-process plugins in the C2PConfig.Registry are returned first. The remaining plugins
+// are searched for in the C2PConfig.SearchPath. A registered plugin takes precedence over
+// a discovered plugin with the same ID, which is not returned. If C2PConfig.TrustedKeys are set, plugin
+// signatures are verified and plugins that fail verification are returned as a
+// plugin.VerificationError.
 func (m *PluginManager) FindRequestedPlugins(requestedPlugins []plugin.ID) (plugin.Manifests, error) {
-	m.log.Info(fmt.Sprintf("Searching for plugins in %s", m.pluginDir))
+	pluginManifests := make(plugin.Manifests)
+	remaining := requestedPlugins
+	if m.registry != nil {
+		pluginManifests = m.registry.Find(
+			plugin.WithProviderIds(requestedPlugins),
+			plugin.WithPluginType(plugin.PVPPluginName),
+		)
+		remaining = nil
+		for _, id := range requestedPlugins {
+			if _, ok := pluginManifests[id]; !ok {
+				remaining = append(remaining, id)
+			}
+		}
+		m.log.Debug(fmt.Sprintf("Found %d matching in-process plugins", len(pluginManifests)))
+		if len(pluginManifests) > 0 && len(remaining) == 0 {
+			return pluginManifests, nil
+		}
+	}
 
-	pluginManifests, err := plugin.FindPlugins(
-		m.pluginDir,
-		m.pluginManifestDir,
-		plugin.WithProviderIds(requestedPlugins),
+	searchedDirs := make([]string, 0, len(m.searchPath))
+	for _, dir := range m.searchPath {
+		searchedDirs = append(searchedDirs, dir.PluginDir)
+	}
+	m.log.Info(fmt.Sprintf("Searching for plugins in %s", strings.Join(searchedDirs, ", ")))
+
+	discoveredManifests, err := plugin.FindPluginsInPath(
+		m.searchPath,
+		plugin.WithProviderIds(remaining),
 		plugin.WithPluginType(plugin.PVPPluginName),
+		plugin.WithVerifier(m.verifier),
 	)
 	if err != nil {
-		return pluginManifests, err
+		// All plugins may be in-process when no plugins are requested
+		if len(requestedPlugins) == 0 && len(pluginManifests) > 0 && errors.Is(err, plugin.ErrPluginsNotFound) {
+			return pluginManifests, nil
+		}
+		return nil, err
+	}
+	for id, manifest := range discoveredManifests {
+		if _, ok := pluginManifests[id]; ok {
+			m.log.Warn(fmt.Sprintf("Discovered plugin %s is shadowed by the in-process plugin", id))
+			continue
+		}
+		pluginManifests[id] = manifest
 	}
 	m.log.Debug(fmt.Sprintf("Found %d matching plugins", len(pluginManifests)))
 	return pluginManifests, nil
@@ -73,46 +162,270 @@ func (m *PluginManager) FindRequestedPlugins(requestedPlugins []plugin.ID) (plug
 // LaunchPolicyPlugins launches requested policy plugins and configures each plugin to make it ready for use with defined plugin workflows.
 // The plugin is configured based on default options and given options.
 // Given options are represented by config.PluginConfig.
+//
+// Options are validated against the manifest before the plugin is launched.
+// The call policy in the manifest is overridden by the call policy set for
+// the plugin in the C2PConfig.
+//
+// Calls to a plugin that crashed return a plugin.PluginCrashedError. If
+// C2PConfig.RelaunchPlugins is set, the plugin is relaunched once first.
+// Named plugin instances (e.g. kyverno@prod) are launched as separate plugins
+// and configured with the options for the instance ID.
+//
+// Plugins that implement policy.HostConsumer can use the host services to store
+// evidence in C2PConfig.EvidenceDir, report progress and resolve paths relative to
+// C2PConfig.WorkDir.
+//
+// Plugins with a reattach configuration in the manifest or C2PConfig.Reattach are
+// attached to instead of launched and are left running by Close.
+//
+// Plugins in the C2PConfig.Registry are created in-process and configured the same
+// way. Call policies and relaunching only apply to plugin processes.
 func (m *PluginManager) LaunchPolicyPlugins(ctx context.Context, manifests plugin.Manifests, pluginConfig PluginConfig) (map[plugin.ID]policy.Provider, error) {
 	pluginsByIds := make(map[plugin.ID]policy.Provider)
-	for _, manifest := range manifests {
-		policyPlugin, err := plugin.NewPolicyPlugin(manifest, m.clientFactory)
+	for id, manifest := range manifests {
+		m.log.Debug(fmt.Sprintf("Gathering configuration options for %s", id))
+		configMap, err := m.resolveOptions(id, manifest, pluginConfig)
+		if err != nil {
+			return pluginsByIds, fmt.Errorf("failed to configure plugin %s: %w", id, err)
+		}
+
+		manifest.CallPolicy = m.callPolicy(id, manifest.CallPolicy)
+		if err := manifest.CallPolicy.Validate(); err != nil {
+			return pluginsByIds, fmt.Errorf("invalid call policy for plugin %s: %w", id, err)
+		}
+		manifest.Reattach = m.reattachConfig(id, manifest.Reattach)
+
+		policyPlugin, err := m.newPolicyPlugin(id, manifest)
 		if err != nil {
 			return pluginsByIds, err
 		}
-		pluginsByIds[manifest.ID] = policyPlugin
-		m.log.Debug(fmt.Sprintf("Launched plugin %s", manifest.ID))
-		m.log.Debug(fmt.Sprintf("Gathering configuration options for %s", manifest.ID))
+		pluginsByIds[id] = policyPlugin
+		m.log.Debug(fmt.Sprintf("Launched plugin %s", id))
+
+		if err := m.checkPlugin(ctx, policyPlugin, manifest); err != nil {
+			return pluginsByIds, err
+		}
 
 		// Get all the base configuration
 		if len(manifest.Configuration) > 0 {
-			if err := m.configurePlugin(ctx, policyPlugin, manifest, pluginConfig); err != nil {
-				return pluginsByIds, fmt.Errorf("failed to configure plugin %s: %w", manifest.ID, err)
+			if err := m.configurePlugin(ctx, policyPlugin, configMap); err != nil {
+				return pluginsByIds, fmt.Errorf("failed to configure plugin %s: %w", id, err)
 			}
 		}
 	}
 	return pluginsByIds, nil
 }
 
-func (m *PluginManager) configurePlugin(ctx context.Context, policyPlugin policy.Provider, manifest plugin.Manifest, pluginConfig PluginConfig) error {
-	selections := pluginConfig(manifest.ID)
+// newPolicyPlugin creates the provider for an in-process plugin or
+// launches the plugin process. Providers that implement policy.HostConsumer
+// receive the host services for the plugin.
+func (m *PluginManager) newPolicyPlugin(id plugin.ID, manifest plugin.Manifest) (policy.Provider, error) {
+	host := &pluginHost{
+		id:          id,
+		evidenceDir: m.evidenceDir,
+		workDir:     m.workDir,
+		onProgress:  m.onProgress,
+		log:         m.log,
+	}
+	if m.registry != nil && m.registry.Has(id) {
+		provider, err := m.registry.NewProvider(id)
+		if err != nil {
+			return nil, err
+		}
+		if consumer, ok := provider.(policy.HostConsumer); ok {
+			consumer.SetHost(host)
+		}
+		if closer, ok := provider.(io.Closer); ok {
+			m.mu.Lock()
+			m.closers[id] = closer
+			m.mu.Unlock()
+		}
+		return provider, nil
+	}
+	opts := []plugin.PolicyPluginOption{plugin.WithHost(host)}
+	if m.relaunchPlugins {
+		opts = append(opts, plugin.WithRelaunch())
+	}
+	return plugin.NewPolicyPlugin(manifest, m.trackClients(id), opts...)
+}
+
+// checkPlugin cross-checks the capabilities reported by a running plugin against
+// its manifest. Plugins that do not implement policy.Describer are not checked.
+func (m *PluginManager) checkPlugin(ctx context.Context, policyPlugin policy.Provider, manifest plugin.Manifest) error {
+	describer, ok := policyPlugin.(policy.Describer)
+	if !ok {
+		return nil
+	}
+	info, err := describer.Describe(ctx)
+	if err != nil {
+		if errors.Is(err, plugin.ErrNotImplemented) {
+			m.log.Debug(fmt.Sprintf("Plugin %s does not support Describe, skipping compatibility check", manifest.ID))
+			return nil
+		}
+		return fmt.Errorf("failed to describe plugin %s: %w", manifest.ID, err)
+	}
+	return manifest.CheckCompatibility(info)
+}
+
+// resolveOptions resolves secret references in the configuration selections for the plugin,
+// validates them against the manifest and returns the resolved options.
+//
+// Options with resolved references are treated as sensitive.
+func (m *PluginManager) resolveOptions(id plugin.ID, manifest plugin.Manifest, pluginConfig PluginConfig) (map[string]string, error) {
+	selections := pluginConfig(id)
 	if selections == nil {
 		selections = make(map[string]string)
-		m.log.Debug("No overrides set for plugin %s, using defaults...", manifest.ID)
+		m.log.Debug(fmt.Sprintf("No overrides set for plugin %s, using defaults...", id))
+	}
+	selections, referenced, err := resolveReferences(selections)
+	if err != nil {
+		return nil, err
 	}
+
+	// Copy the configuration to avoid modifying the given manifest
+	configuration := make([]plugin.ConfigurationOption, len(manifest.Configuration))
+	copy(configuration, manifest.Configuration)
+	for i, option := range configuration {
+		if _, ok := referenced[option.Name]; ok {
+			configuration[i].Sensitive = true
+		}
+	}
+	manifest.Configuration = configuration
+
 	configMap, err := manifest.ResolveOptions(selections, m.log)
 	if err != nil {
-		return err
+		return nil, err
+	}
+	m.log.Debug(fmt.Sprintf("Resolved configuration options for %s: %v", id, manifest.RedactOptions(configMap)))
+	return configMap, nil
+}
+
+// callPolicy applies the configured overrides for the plugin and then
+// the plugin instance to the manifest call policy.
+func (m *PluginManager) callPolicy(id plugin.ID, manifestPolicy plugin.CallPolicy) plugin.CallPolicy {
+	callPolicy := manifestPolicy
+	if override, ok := m.callPolicies[id.Base()]; ok {
+		callPolicy = callPolicy.Merge(override)
+	}
+	if id.Instance() != "" {
+		if override, ok := m.callPolicies[id]; ok {
+			callPolicy = callPolicy.Merge(override)
+		}
 	}
+	return callPolicy
+}
+
+// reattachConfig returns the configured reattach configuration for the plugin
+// instance or the plugin, or the manifest reattach configuration.
+func (m *PluginManager) reattachConfig(id plugin.ID, manifestReattach *plugin.ReattachConfig) *plugin.ReattachConfig {
+	if reattach, ok := m.reattach[id]; ok {
+		return &reattach
+	}
+	if reattach, ok := m.reattach[id.Base()]; ok {
+		return &reattach
+	}
+	return manifestReattach
+}
+
+func (m *PluginManager) configurePlugin(ctx context.Context, policyPlugin policy.Provider, configMap map[string]string) error {
 	if err := policyPlugin.Configure(ctx, configMap); err != nil {
 		return err
 	}
 	return nil
 }
 
-// Clean deletes managed instances of plugin clients that have been created using LaunchPolicyPlugins.
-// This will remove all clients launched with the plugin.ClientFactoryFunc.
+// trackClients returns a plugin.ClientFactoryFunc that records the clients
+// created for the plugin with the given ID.
+func (m *PluginManager) trackClients(id plugin.ID) plugin.ClientFactoryFunc {
+	return func(manifest plugin.Manifest) (*plugin.Client, error) {
+		client, err := m.clientFactory(manifest)
+		if err != nil {
+			return nil, err
+		}
+		m.mu.Lock()
+		defer m.mu.Unlock()
+		m.clients[id] = append(m.clients[id], client)
+		return client, nil
+	}
+}
+
+// RunningPlugins returns the sorted IDs of the plugins launched by
+// this PluginManager with a running plugin process.
+func (m *PluginManager) RunningPlugins() []plugin.ID {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var running []plugin.ID
+	for id, clients := range m.clients {
+		for _, client := range clients {
+			// Clients without a reattach configuration were never started.
+			if !client.Exited() && client.ReattachConfig() != nil {
+				running = append(running, id)
+				break
+			}
+		}
+	}
+	slices.Sort(running)
+	return running
+}
+
+// Close stops all plugins launched by this PluginManager. Each plugin is
+// asked to exit gracefully before it is forcefully killed. Attached plugins
+// are disconnected and left running. In-process providers that implement
+// io.Closer are closed.
+//
+// If the context is done before all plugins have stopped, the context
+// error is returned and the remaining plugins are stopped in the background.
+func (m *PluginManager) Close(ctx context.Context) error {
+	m.mu.Lock()
+	var clients []*plugin.Client
+	for _, launched := range m.clients {
+		clients = append(clients, launched...)
+	}
+	m.clients = make(map[plugin.ID][]*plugin.Client)
+	closers := m.closers
+	m.closers = make(map[plugin.ID]io.Closer)
+	m.mu.Unlock()
+
+	m.log.Debug(fmt.Sprintf("Stopping %d launched plugin(s)", len(clients)+len(closers)))
+	var wg sync.WaitGroup
+	for _, client := range clients {
+		wg.Add(1)
+		go func(client *plugin.Client) {
+			defer wg.Done()
+			client.Kill()
+		}(client)
+	}
+	var errsMu sync.Mutex
+	var errs []error
+	for id, closer := range closers {
+		wg.Add(1)
+		go func(id plugin.ID, closer io.Closer) {
+			defer wg.Done()
+			if err := closer.Close(); err != nil {
+				errsMu.Lock()
+				errs = append(errs, fmt.Errorf("failed to close plugin %s: %w", id, err))
+				errsMu.Unlock()
+			}
+		}(id, closer)
+	}
+	done := make(chan struct{})
+	go func() {
+		wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return errors.Join(errs...)
+	case <-ctx.Done():
+		return fmt.Errorf("failed to stop all plugins: %w", ctx.Err())
+	}
+}
+
+// Clean stops all plugins launched by this PluginManager and waits
+// for the plugins to exit.
 func (m *PluginManager) Clean() {
 	m.log.Debug("Cleaning launched plugins")
-	plugin.Cleanup()
+	_ = m.Close(context.Background())
 }
@@ -8,10 +8,14 @@ package framework
 import (
 	"bytes"
 	"context"
+	"errors"
+	"os"
+	"os/exec"
 	"sort"
 	"testing"
 
 	"github.com/hashicorp/go-hclog"
+	hplugin "github.com/hashicorp/go-plugin"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 
@@ -70,9 +74,245 @@ func TestPluginManager_Configure(t *testing.T) {
 	providerTestObj.
 		On("Configure", map[string]string{"option 2": "value", "option1": "override"}).
 		Return(nil)
-	err = pluginManager.configurePlugin(context.Background(), providerTestObj, manifest, pluginMap)
+	configMap, err := pluginManager.resolveOptions(manifest.ID, manifest, pluginMap)
+	require.NoError(t, err)
+	err = pluginManager.configurePlugin(context.Background(), providerTestObj, configMap)
 	require.NoError(t, err)
 	providerTestObj.AssertExpectations(t)
+
+	// Invalid options fail before the plugin is configured
+	manifest.Configuration[0].Type = plugin.OptionTypeInt
+	_, err = pluginManager.resolveOptions(manifest.ID, manifest, pluginMap)
+	require.EqualError(t, err, "invalid value \"override\" for option \"option1\": must be an integer")
+}
+
+func TestPluginManager_ResolveOptionsRedacted(t *testing.T) {
+	var buf bytes.Buffer
+	cfg := &C2PConfig{
+		PluginDir:         ".",
+		PluginManifestDir: ".",
+		Logger: hclog.New(&hclog.LoggerOptions{
+			Output: &buf,
+			Level:  hclog.Debug,
+		}),
+	}
+	pluginManager, err := NewPluginManager(cfg)
+	require.NoError(t, err)
+
+	t.Setenv("C2P_TEST_TOKEN", "secret-token")
+	manifest := plugin.Manifest{
+		Metadata: plugin.Metadata{
+			ID: "myplugin",
+		},
+		Configuration: []plugin.ConfigurationOption{
+			{Name: "token", Pattern: "^[0-9]+$"},
+			{Name: "password", Sensitive: true},
+			{Name: "region"},
+		},
+	}
+	pluginMap := func(id plugin.ID) map[string]string {
+		return map[string]string{
+			"token":    "env:C2P_TEST_TOKEN",
+			"password": "hunter2",
+			"region":   "us-east",
+			"unknown":  "unknown-secret",
+		}
+	}
+
+	// Values resolved from references are redacted from errors
+	_, err = pluginManager.resolveOptions(manifest.ID, manifest, pluginMap)
+	require.EqualError(t, err, "invalid value for option \"token\": must match pattern \"^[0-9]+$\"")
+	require.False(t, manifest.Configuration[0].Sensitive)
+
+	manifest.Configuration[0].Pattern = ""
+	configMap, err := pluginManager.resolveOptions(manifest.ID, manifest, pluginMap)
+	require.NoError(t, err)
+	require.Equal(t, map[string]string{"token": "secret-token", "password": "hunter2", "region": "us-east"}, configMap)
+
+	logs := buf.String()
+	require.Contains(t, logs, "us-east")
+	require.Contains(t, logs, "Unknown configuration options found: unknown")
+	for _, secret := range []string{"secret-token", "hunter2", "unknown-secret"} {
+		require.NotContains(t, logs, secret)
+	}
+}
+
+func TestPluginManager_CallPolicy(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.PluginDir = "."
+	cfg.PluginManifestDir = "."
+	cfg.CallPolicies = map[plugin.ID]plugin.CallPolicy{
+		"kyverno":      {Timeout: "10m", MaxAttempts: 5},
+		"kyverno@prod": {MaxAttempts: 2},
+	}
+
+	pluginManager, err := NewPluginManager(cfg)
+	require.NoError(t, err)
+
+	manifestPolicy := plugin.CallPolicy{Timeout: "1m", InitialBackoff: "5s"}
+	require.Equal(t, plugin.CallPolicy{Timeout: "10m", MaxAttempts: 5, InitialBackoff: "5s"}, pluginManager.callPolicy("kyverno", manifestPolicy))
+	require.Equal(t, plugin.CallPolicy{Timeout: "10m", MaxAttempts: 2, InitialBackoff: "5s"}, pluginManager.callPolicy("kyverno@prod", manifestPolicy))
+	require.Equal(t, manifestPolicy, pluginManager.callPolicy("ocm", manifestPolicy))
+
+	cfg.CallPolicies["ocm"] = plugin.CallPolicy{Timeout: "soon"}
+	_, err = NewPluginManager(cfg)
+	require.EqualError(t, err, "invalid call policy for plugin ocm: invalid timeout \"soon\": must be a positive duration")
+}
+
+func TestPluginManager_Reattach(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.PluginDir = "."
+	cfg.PluginManifestDir = "."
+	cfg.Reattach = map[plugin.ID]plugin.ReattachConfig{
+		"kyverno":      {File: "/run/c2p/kyverno.json"},
+		"kyverno@prod": {Address: "/run/c2p/kyverno-prod.sock"},
+	}
+
+	pluginManager, err := NewPluginManager(cfg)
+	require.NoError(t, err)
+
+	manifestReattach := &plugin.ReattachConfig{Address: "/run/c2p/manifest.sock"}
+	require.Equal(t, &plugin.ReattachConfig{File: "/run/c2p/kyverno.json"}, pluginManager.reattachConfig("kyverno", manifestReattach))
+	require.Equal(t, &plugin.ReattachConfig{File: "/run/c2p/kyverno.json"}, pluginManager.reattachConfig("kyverno@dev", nil))
+	require.Equal(t, &plugin.ReattachConfig{Address: "/run/c2p/kyverno-prod.sock"}, pluginManager.reattachConfig("kyverno@prod", nil))
+	require.Equal(t, manifestReattach, pluginManager.reattachConfig("ocm", manifestReattach))
+
+	cfg.Reattach["ocm"] = plugin.ReattachConfig{Network: "tcp", Address: "localhost:7777"}
+	_, err = NewPluginManager(cfg)
+	require.EqualError(t, err, "invalid reattach configuration for plugin ocm: tls is required for tcp address \"localhost:7777\"")
+}
+
+func TestPluginManager_CheckPlugin(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.PluginDir = "."
+	cfg.PluginManifestDir = "."
+
+	pluginManager, err := NewPluginManager(cfg)
+	require.NoError(t, err)
+
+	manifest := plugin.Manifest{
+		Metadata: plugin.Metadata{
+			ID:      "myplugin",
+			Version: "0.0.1",
+		},
+	}
+
+	t.Run("Success/Compatible", func(t *testing.T) {
+		providerTestObj := new(describingProvider)
+		providerTestObj.On("Describe").Return(policy.ProviderInfo{Version: "0.0.1"}, nil)
+		require.NoError(t, pluginManager.checkPlugin(context.Background(), providerTestObj, manifest))
+		providerTestObj.AssertExpectations(t)
+	})
+
+	t.Run("Success/NotImplemented", func(t *testing.T) {
+		providerTestObj := new(describingProvider)
+		providerTestObj.On("Describe").Return(policy.ProviderInfo{}, plugin.ErrNotImplemented)
+		require.NoError(t, pluginManager.checkPlugin(context.Background(), providerTestObj, manifest))
+		providerTestObj.AssertExpectations(t)
+	})
+
+	t.Run("Success/NotADescriber", func(t *testing.T) {
+		require.NoError(t, pluginManager.checkPlugin(context.Background(), new(policyProvider), manifest))
+	})
+
+	t.Run("Failure/VersionMismatch", func(t *testing.T) {
+		providerTestObj := new(describingProvider)
+		providerTestObj.On("Describe").Return(policy.ProviderInfo{Version: "1.0.0"}, nil)
+		err := pluginManager.checkPlugin(context.Background(), providerTestObj, manifest)
+		var incompatibleErr *plugin.IncompatibleError
+		require.ErrorAs(t, err, &incompatibleErr)
+		providerTestObj.AssertExpectations(t)
+	})
+}
+
+func TestPluginManager_Close(t *testing.T) {
+	newManager := func() *PluginManager {
+		cfg := DefaultConfig()
+		cfg.PluginDir = "."
+		cfg.PluginManifestDir = "."
+		pluginManager, err := NewPluginManager(cfg)
+		require.NoError(t, err)
+		pluginManager.clientFactory = testClientFactory
+		return pluginManager
+	}
+	manifests := plugin.Manifests{
+		"myplugin": plugin.Manifest{Metadata: plugin.Metadata{ID: "myplugin"}},
+	}
+	noConfig := func(plugin.ID) map[string]string { return nil }
+
+	manager1 := newManager()
+	manager2 := newManager()
+	defer manager2.Clean()
+	_, err := manager1.LaunchPolicyPlugins(context.Background(), manifests, noConfig)
+	require.NoError(t, err)
+	providers, err := manager2.LaunchPolicyPlugins(context.Background(), manifests, noConfig)
+	require.NoError(t, err)
+	require.Equal(t, []plugin.ID{"myplugin"}, manager1.RunningPlugins())
+	require.Equal(t, []plugin.ID{"myplugin"}, manager2.RunningPlugins())
+
+	// Closing one manager does not stop the plugins of other managers
+	require.NoError(t, manager1.Close(context.Background()))
+	require.Empty(t, manager1.RunningPlugins())
+	require.Equal(t, []plugin.ID{"myplugin"}, manager2.RunningPlugins())
+	_, err = providers["myplugin"].GetResults(context.Background(), policy.Policy{})
+	require.NoError(t, err)
+}
+
+// TestHelperPlugin is not a real test. It serves a staticProvider
+// when launched as a plugin process by testClientFactory.
+func TestHelperPlugin(t *testing.T) {
+	if os.Getenv(testPluginEnv) != "1" {
+		return
+	}
+	plugin.Register(plugin.ServeConfig{
+		PluginSet: map[string]hplugin.Plugin{
+			plugin.PVPPluginName: &plugin.PVPPlugin{Impl: staticProvider{}},
+		},
+		Logger: hclog.NewNullLogger(),
+	})
+	os.Exit(0)
+}
+
+// testPluginEnv runs TestHelperPlugin as a plugin process.
+const testPluginEnv = "C2P_TEST_PLUGIN"
+
+// testClientFactory launches the test binary as a plugin process.
+func testClientFactory(_ plugin.Manifest) (*plugin.Client, error) {
+	cmd := exec.Command(os.Args[0], "-test.run=^TestHelperPlugin$") /* #nosec G204 */
+	cmd.Env = append(os.Environ(), testPluginEnv+"=1")
+	return plugin.NewClient(&hplugin.ClientConfig{
+		HandshakeConfig:  plugin.Handshake,
+		Plugins:          plugin.SupportedPlugins,
+		Cmd:              cmd,
+		AllowedProtocols: []hplugin.Protocol{hplugin.ProtocolGRPC},
+		Logger:           hclog.NewNullLogger(),
+	}), nil
+}
+
+// staticProvider is a policy.Provider with empty results.
+type staticProvider struct{}
+
+func (staticProvider) Configure(context.Context, map[string]string) error {
+	return nil
+}
+
+func (staticProvider) Generate(context.Context, policy.Policy) ([]policy.Artifact, error) {
+	return nil, nil
+}
+
+func (staticProvider) GetResults(context.Context, policy.Policy) (policy.PVPResult, error) {
+	return policy.PVPResult{}, nil
+}
+
+// describingProvider is a mocked implementation of policy.Provider
+// and policy.Describer.
+type describingProvider struct {
+	policyProvider
+}
+
+func (p *describingProvider) Describe(_ context.Context) (policy.ProviderInfo, error) {
+	args := p.Called()
+	return args.Get(0).(policy.ProviderInfo), args.Error(1)
 }
 
 // policyProvider is a mocked implementation of policy.Provider.
@@ -85,12 +325,12 @@ func (p *policyProvider) Configure(_ context.Context, option map[string]string)
 	return args.Error(0)
 }
 
-func (p *policyProvider) Generate(_ context.Context, policyRules policy.Policy) error {
+func (p *policyProvider) Generate(_ context.Context, policyRules policy.Policy) ([]policy.Artifact, error) {
 	sort.SliceStable(policyRules, func(i, j int) bool {
 		return policyRules[i].Rule.ID > policyRules[j].Rule.ID
 	})
 	args := p.Called(policyRules)
-	return args.Error(0)
+	return args.Get(0).([]policy.Artifact), args.Error(1)
 }
 
 func (p *policyProvider) GetResults(_ context.Context, policyRules policy.Policy) (policy.PVPResult, error) {
@@ -124,3 +364,89 @@ func TestNewPluginManager_ConfiguresGlobalLogger(t *testing.T) {
 	require.Contains(t, buf.String(), "test message")
 	require.Contains(t, buf.String(), "test-logger.test-component")
 }
+
+func TestPluginManager_InProcess(t *testing.T) {
+	registry := plugin.NewRegistry()
+	providers := make(map[plugin.ID]*policyProvider)
+	registerProvider := func(id plugin.ID, configuration ...plugin.ConfigurationOption) {
+		manifest := plugin.Manifest{Metadata: plugin.Metadata{ID: id}, Configuration: configuration}
+		require.NoError(t, registry.Register(manifest, func() (policy.Provider, error) {
+			return providers[id], nil
+		}))
+	}
+	providers["inproc"] = new(policyProvider)
+	registerProvider("inproc", plugin.ConfigurationOption{Name: "option1", Required: true})
+	// Registered plugins shadow discovered plugins
+	providers["testplugin"] = new(policyProvider)
+	registerProvider("testplugin")
+
+	cfg := DefaultConfig()
+	cfg.PluginDir = "../plugin/testdata/plugins"
+	cfg.PluginManifestDir = "../plugin/testdata/plugins"
+	cfg.Registry = registry
+	pluginManager, err := NewPluginManager(cfg)
+	require.NoError(t, err)
+
+	manifests, err := pluginManager.FindRequestedPlugins([]plugin.ID{"inproc", "testplugin", "another-testplugin"})
+	require.NoError(t, err)
+	require.Len(t, manifests, 3)
+	require.Empty(t, manifests["testplugin"].ExecutablePath)
+	require.NotEmpty(t, manifests["another-testplugin"].ExecutablePath)
+
+	// Only in-process plugins are launched
+	delete(manifests, "another-testplugin")
+	providers["inproc"].On("Configure", map[string]string{"option1": "value"}).Return(nil)
+	launched, err := pluginManager.LaunchPolicyPlugins(context.Background(), manifests, func(id plugin.ID) map[string]string {
+		return map[string]string{"option1": "value"}
+	})
+	require.NoError(t, err)
+	require.Len(t, launched, 2)
+	require.Same(t, providers["inproc"], launched["inproc"])
+	providers["inproc"].AssertExpectations(t)
+	require.Empty(t, pluginManager.RunningPlugins())
+
+	// Options are validated for in-process plugins
+	_, err = pluginManager.LaunchPolicyPlugins(context.Background(), manifests, func(id plugin.ID) map[string]string {
+		return nil
+	})
+	require.EqualError(t, err, "failed to configure plugin inproc: required value not supplied for option \"option1\"")
+}
+
+func TestPluginManager_CloseInProcess(t *testing.T) {
+	registry := plugin.NewRegistry()
+	closing := &closingProvider{err: errors.New("close failed")}
+	require.NoError(t, registry.Register(plugin.Manifest{Metadata: plugin.Metadata{ID: "inproc"}}, func() (policy.Provider, error) {
+		return closing, nil
+	}))
+	cfg := DefaultConfig()
+	cfg.PluginDir = "../plugin/testdata/plugins"
+	cfg.PluginManifestDir = "../plugin/testdata/plugins"
+	cfg.Registry = registry
+	pluginManager, err := NewPluginManager(cfg)
+	require.NoError(t, err)
+
+	manifests, err := pluginManager.FindRequestedPlugins([]plugin.ID{"inproc"})
+	require.NoError(t, err)
+	_, err = pluginManager.LaunchPolicyPlugins(context.Background(), manifests, func(id plugin.ID) map[string]string {
+		return nil
+	})
+	require.NoError(t, err)
+
+	require.EqualError(t, pluginManager.Close(context.Background()), "failed to close plugin inproc: close failed")
+	require.Equal(t, 1, closing.closed)
+	// Providers are only closed once
+	require.NoError(t, pluginManager.Close(context.Background()))
+	require.Equal(t, 1, closing.closed)
+}
+
+// closingProvider is an in-process provider that records Close calls.
+type closingProvider struct {
+	policyProvider
+	err    error
+	closed int
+}
+
+func (p *closingProvider) Close() error {
+	p.closed++
+	return p.err
+}
@@ -83,6 +83,23 @@ func TestGenerate(t *testing.T) {
 	assessmentResultsMd, err = posturemdTable.Generate("assessment-results-table.md")
 	require.NoError(t, err)
 	require.Equal(t, string(expectedmd), string(assessmentResultsMd))
+
+	// Test not-applicable, skipped and manual results
+	posturemd.assessmentPlan = &assessmentPlanResultTypes
+	posturemd.assessmentResults = &assessmentResultsResultTypes
+	expectedmd, err = os.ReadFile("./testdata/assessment-results-result-types.md")
+	require.NoError(t, err)
+	assessmentResultsMd, err = posturemd.Generate("assessment-results-result-types.md")
+	require.NoError(t, err)
+	require.Equal(t, string(expectedmd), string(assessmentResultsMd))
+
+	posturemdTable.assessmentPlan = &assessmentPlanResultTypes
+	posturemdTable.assessmentResults = &assessmentResultsResultTypes
+	expectedmd, err = os.ReadFile("./testdata/assessment-results-result-types-table.md")
+	require.NoError(t, err)
+	assessmentResultsMd, err = posturemdTable.Generate("assessment-results-result-types-table.md")
+	require.NoError(t, err)
+	require.Equal(t, string(expectedmd), string(assessmentResultsMd))
 }
 
 // Mock data for testing
@@ -319,4 +336,186 @@ var (
 			},
 		},
 	}
+	// Not-applicable, skipped and manual result data
+	assessmentPlanResultTypes = oscalTypes.AssessmentPlan{
+		LocalDefinitions: &oscalTypes.LocalDefinitions{
+			Components: &[]oscalTypes.SystemComponent{
+				{
+					Title: "Component Title",
+					Props: &[]oscalTypes.Property{
+						{
+							Name:  extensions.RuleIdProp,
+							Value: "rule-passed",
+							Ns:    extensions.TrestleNameSpace,
+						},
+						{
+							Name:  extensions.RuleIdProp,
+							Value: "rule-not-applicable",
+							Ns:    extensions.TrestleNameSpace,
+						},
+						{
+							Name:  extensions.RuleIdProp,
+							Value: "rule-manual",
+							Ns:    extensions.TrestleNameSpace,
+						},
+						{
+							Name:  extensions.RuleIdProp,
+							Value: "rule-skipped",
+							Ns:    extensions.TrestleNameSpace,
+						},
+					},
+				},
+			},
+		},
+	}
+	assessmentResultsResultTypes = oscalTypes.AssessmentResults{
+		Results: []oscalTypes.Result{
+			{
+				Findings: &[]oscalTypes.Finding{
+					{
+						Target: oscalTypes.FindingTarget{
+							TargetId: "control-1_smt",
+						},
+						RelatedObservations: &[]oscalTypes.RelatedObservation{
+							{
+								ObservationUuid: "observationuuid-pass",
+							},
+							{
+								ObservationUuid: "observationuuid-not-applicable",
+							},
+						},
+					},
+					{
+						Target: oscalTypes.FindingTarget{
+							TargetId: "control-2_smt",
+						},
+						RelatedObservations: &[]oscalTypes.RelatedObservation{
+							{
+								ObservationUuid: "observationuuid-not-applicable",
+							},
+						},
+					},
+					{
+						Target: oscalTypes.FindingTarget{
+							TargetId: "control-3_smt",
+						},
+						RelatedObservations: &[]oscalTypes.RelatedObservation{
+							{
+								ObservationUuid: "observationuuid-manual",
+							},
+							{
+								ObservationUuid: "observationuuid-skipped",
+							},
+						},
+					},
+				},
+				Observations: &[]oscalTypes.Observation{
+					{
+						UUID: "observationuuid-pass",
+						Props: &[]oscalTypes.Property{
+							{
+								Name:  "assessment-rule-id",
+								Value: "rule-passed",
+								Ns:    extensions.TrestleNameSpace,
+							},
+						},
+						Subjects: &[]oscalTypes.SubjectReference{
+							{
+								SubjectUuid: "subject-1234",
+								Title:       "my component",
+								Props: &[]oscalTypes.Property{
+									{
+										Name:  "result",
+										Value: "pass",
+									},
+									{
+										Name:  "reason",
+										Value: "my reason",
+									},
+								},
+							},
+						},
+					},
+					{
+						UUID: "observationuuid-not-applicable",
+						Props: &[]oscalTypes.Property{
+							{
+								Name:  "assessment-rule-id",
+								Value: "rule-not-applicable",
+								Ns:    extensions.TrestleNameSpace,
+							},
+						},
+						Subjects: &[]oscalTypes.SubjectReference{
+							{
+								SubjectUuid: "subject-5678",
+								Title:       "windows component",
+								Props: &[]oscalTypes.Property{
+									{
+										Name:  "result",
+										Value: "not-applicable",
+									},
+									{
+										Name:  "reason",
+										Value: "Check only applies to Linux hosts",
+									},
+								},
+							},
+						},
+					},
+					{
+						UUID: "observationuuid-manual",
+						Props: &[]oscalTypes.Property{
+							{
+								Name:  "assessment-rule-id",
+								Value: "rule-manual",
+								Ns:    extensions.TrestleNameSpace,
+							},
+						},
+						Subjects: &[]oscalTypes.SubjectReference{
+							{
+								SubjectUuid: "subject-9999",
+								Title:       "physical access",
+								Props: &[]oscalTypes.Property{
+									{
+										Name:  "result",
+										Value: "manual",
+									},
+									{
+										Name:  "reason",
+										Value: "Requires an on-site inspection",
+									},
+								},
+							},
+						},
+					},
+					{
+						UUID: "observationuuid-skipped",
+						Props: &[]oscalTypes.Property{
+							{
+								Name:  "assessment-rule-id",
+								Value: "rule-skipped",
+								Ns:    extensions.TrestleNameSpace,
+							},
+						},
+						Subjects: &[]oscalTypes.SubjectReference{
+							{
+								SubjectUuid: "subject-0000",
+								Title:       "my component",
+								Props: &[]oscalTypes.Property{
+									{
+										Name:  "result",
+										Value: "skipped",
+									},
+									{
+										Name:  "reason",
+										Value: "Policy was not selected",
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
 )
@@ -23,6 +23,10 @@ type RuleResult struct {
 	RuleId string `json:"ruleId,omitempty" yaml:"ruleId,omitempty"`
 	// Subjects
 	Subjects []oscalTypes.SubjectReference `json:"subjects,omitempty" yaml:"subjects,omitempty"`
+	// Severity of the rule result
+	Severity string `json:"severity,omitempty" yaml:"severity,omitempty"`
+	// Remediation guidance for the rule result
+	Remediation string `json:"remediation,omitempty" yaml:"remediation,omitempty"`
 }
 
 type Findings struct {
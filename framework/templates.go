@@ -8,13 +8,16 @@ package framework
 import (
 	"fmt"
 	"slices"
+	"sort"
 	"strings"
 
 	oscalTypes "github.com/defenseunicorns/go-oscal/src/types/oscal-1-1-3"
 	"github.com/hashicorp/go-hclog"
 	"github.com/oscal-compass/oscal-sdk-go/extensions"
 
+	"github.com/oscal-compass/compliance-to-policy-go/v2/framework/actions"
 	tp "github.com/oscal-compass/compliance-to-policy-go/v2/framework/template"
+	"github.com/oscal-compass/compliance-to-policy-go/v2/policy"
 )
 
 // ResultsTemplateValues defines values for a plan-based posture report.
@@ -75,6 +78,10 @@ func CreateResultsValues(
 				tpComp.Findings = append(tpComp.Findings, tpFinding)
 			}
 		}
+		// Show the controls with the most severe results first
+		sort.SliceStable(tpComp.Findings, func(i, j int) bool {
+			return findingSeverity(tpComp.Findings[i]) > findingSeverity(tpComp.Findings[j])
+		})
 		templateValues.Components = append(templateValues.Components, tpComp)
 	}
 
@@ -147,11 +154,54 @@ func allFindings(assessmentResults oscalTypes.AssessmentResults, logger hclog.Lo
 						RuleId:   ruleId.Value,
 						Subjects: subjects,
 					}
+					if severity := ruleSeverity(*ob.Props, subjects); severity != policy.SeverityUnspecified {
+						ruleResult.Severity = severity.String()
+					}
+					if remediation, found := extensions.GetTrestleProp(actions.RemediationProp, *ob.Props); found {
+						ruleResult.Remediation = remediation.Value
+					}
 					item.Results = append(item.Results, ruleResult)
 				}
+				// Show the most severe rule results first
+				sort.SliceStable(item.Results, func(i, j int) bool {
+					return policy.ParseSeverity(item.Results[i].Severity) > policy.ParseSeverity(item.Results[j].Severity)
+				})
 				findings = append(findings, item)
 			}
 		}
 	}
 	return findings
 }
+
+// ruleSeverity returns the highest severity of the subjects. The severity of a subject
+// overrides the severity of the observation, which is used for subjects without a severity
+// and for observations without subjects.
+func ruleSeverity(props []oscalTypes.Property, subjects []oscalTypes.SubjectReference) policy.Severity {
+	observationSeverity := policy.SeverityUnspecified
+	if prop, found := extensions.GetTrestleProp(actions.SeverityProp, props); found {
+		observationSeverity = policy.ParseSeverity(prop.Value)
+	}
+	if len(subjects) == 0 {
+		return observationSeverity
+	}
+	severity := policy.SeverityUnspecified
+	for _, subject := range subjects {
+		subjectSeverity := observationSeverity
+		if subject.Props != nil {
+			if prop, found := extensions.GetTrestleProp(actions.SeverityProp, *subject.Props); found {
+				subjectSeverity = policy.ParseSeverity(prop.Value)
+			}
+		}
+		severity = max(severity, subjectSeverity)
+	}
+	return severity
+}
+
+// findingSeverity returns the highest severity of the rule results for a control.
+func findingSeverity(finding tp.Findings) policy.Severity {
+	severity := policy.SeverityUnspecified
+	for _, result := range finding.Results {
+		severity = max(severity, policy.ParseSeverity(result.Severity))
+	}
+	return severity
+}
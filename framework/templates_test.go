@@ -10,8 +10,10 @@ import (
 
 	oscalTypes "github.com/defenseunicorns/go-oscal/src/types/oscal-1-1-3"
 	"github.com/hashicorp/go-hclog"
+	"github.com/oscal-compass/oscal-sdk-go/extensions"
 	"github.com/stretchr/testify/require"
 
+	"github.com/oscal-compass/compliance-to-policy-go/v2/framework/actions"
 	tp "github.com/oscal-compass/compliance-to-policy-go/v2/framework/template"
 )
 
@@ -145,3 +147,77 @@ func TestCreateTemplateValues(t *testing.T) {
 	}
 	require.Equal(t, test.expected, result)
 }
+
+func TestCreateTemplateValues_Severity(t *testing.T) {
+	ruleProp := func(ruleID string) oscalTypes.Property {
+		return oscalTypes.Property{Name: extensions.RuleIdProp, Value: ruleID, Ns: extensions.TrestleNameSpace}
+	}
+	observation := func(uuid, ruleID string, props []oscalTypes.Property, subjectProps []oscalTypes.Property) oscalTypes.Observation {
+		props = append([]oscalTypes.Property{{Name: "assessment-rule-id", Value: ruleID, Ns: extensions.TrestleNameSpace}}, props...)
+		subjectProps = append([]oscalTypes.Property{{Name: "result", Value: "fail"}}, subjectProps...)
+		return oscalTypes.Observation{
+			UUID:     uuid,
+			Props:    &props,
+			Subjects: &[]oscalTypes.SubjectReference{{SubjectUuid: uuid + "-subject", Props: &subjectProps}},
+		}
+	}
+	severityProp := func(severity string) []oscalTypes.Property {
+		return []oscalTypes.Property{{Name: actions.SeverityProp, Value: severity, Ns: extensions.TrestleNameSpace}}
+	}
+
+	plan := oscalTypes.AssessmentPlan{
+		LocalDefinitions: &oscalTypes.LocalDefinitions{
+			Components: &[]oscalTypes.SystemComponent{
+				{
+					Title: "Component Title",
+					Props: &[]oscalTypes.Property{ruleProp("rule-none"), ruleProp("rule-low"), ruleProp("rule-override"), ruleProp("rule-high"), ruleProp("rule-critical")},
+				},
+			},
+		},
+	}
+	results := oscalTypes.AssessmentResults{
+		Results: []oscalTypes.Result{
+			{
+				Findings: &[]oscalTypes.Finding{
+					{
+						Target:              oscalTypes.FindingTarget{TargetId: "control-1_smt"},
+						RelatedObservations: &[]oscalTypes.RelatedObservation{{ObservationUuid: "obs-none"}, {ObservationUuid: "obs-low"}, {ObservationUuid: "obs-override"}, {ObservationUuid: "obs-high"}},
+					},
+					{
+						Target:              oscalTypes.FindingTarget{TargetId: "control-2_smt"},
+						RelatedObservations: &[]oscalTypes.RelatedObservation{{ObservationUuid: "obs-critical"}},
+					},
+				},
+				Observations: &[]oscalTypes.Observation{
+					observation("obs-none", "rule-none", nil, nil),
+					observation("obs-low", "rule-low", severityProp("low"), nil),
+					// The subject severity overrides the observation severity
+					observation("obs-override", "rule-override", severityProp("high"), severityProp("low")),
+					observation("obs-high", "rule-high", append(severityProp("high"), oscalTypes.Property{
+						Name: actions.RemediationProp, Value: "Add the label", Ns: extensions.TrestleNameSpace,
+					}), nil),
+					// The subject severity is used when the observation has no severity
+					observation("obs-critical", "rule-critical", nil, severityProp("critical")),
+				},
+			},
+		},
+	}
+
+	values, err := CreateResultsValues(oscalTypes.Catalog{Metadata: oscalTypes.Metadata{Title: "Catalog Title"}}, plan, results, hclog.NewNullLogger())
+	require.NoError(t, err)
+	require.Len(t, values.Components, 1)
+	findings := values.Components[0].Findings
+	require.Len(t, findings, 2)
+	require.Equal(t, "control-2", findings[0].ControlID)
+	require.Equal(t, "critical", findings[0].Results[0].Severity)
+
+	require.Equal(t, "control-1", findings[1].ControlID)
+	var ruleIDs, severities []string
+	for _, result := range findings[1].Results {
+		ruleIDs = append(ruleIDs, result.RuleId)
+		severities = append(severities, result.Severity)
+	}
+	require.Equal(t, []string{"rule-high", "rule-low", "rule-override", "rule-none"}, ruleIDs)
+	require.Equal(t, []string{"high", "low", "low", ""}, severities)
+	require.Equal(t, "Add the label", findings[1].Results[0].Remediation)
+}
@@ -46,6 +46,12 @@ cat > "${PLUGINS_DIR}/c2p-kyverno-manifest.json" << EOF
       "description": "The output directory for policies",
       "required": false,
       "default": "."
+    },
+    {
+      "name": "attach-evidence",
+      "description": "Attach the policy report results to the observations as evidence",
+      "required": false,
+      "default": "false"
     }
   ]
 }
@@ -87,6 +93,12 @@ cat > "${PLUGINS_DIR}/c2p-ocm-manifest.json" << EOF
      "required": false,
      "default": "."
    },
+   {
+     "name": "attach-evidence",
+     "description": "Attach the policy status to the observations as evidence",
+     "required": false,
+     "default": "false"
+   },
    {
       "name": "policy-set-name",
       "required": true
@@ -99,6 +99,15 @@ func NilIfEmpty[T any](slice *[]T) *[]T {
 	return slice
 }
 
+// ValueOrEmpty returns the value of the given slice pointer or
+// an empty slice if the pointer is nil.
+func ValueOrEmpty[T any](slice *[]T) []T {
+	if slice == nil {
+		return []T{}
+	}
+	return *slice
+}
+
 func CopyFile(src string, dest string) error {
 	cleanedPath := filepath.Clean(src)
 	input, err := os.ReadFile(cleanedPath)
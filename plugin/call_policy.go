@@ -0,0 +1,206 @@
+/*
+ Copyright 2025 The OSCAL Compass Authors
+ SPDX-License-Identifier: Apache-2.0
+*/
+
+package plugin
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/oscal-compass/compliance-to-policy-go/v2/logging"
+)
+
+const (
+	defaultInitialBackoff    = time.Second
+	defaultMaxBackoff        = 30 * time.Second
+	defaultBackoffMultiplier = 2.0
+)
+
+// defaultRetryableCodes are retried when CallPolicy.RetryableCodes is not set.
+var defaultRetryableCodes = []codes.Code{codes.Unavailable, codes.DeadlineExceeded}
+
+// CallPolicy defines the deadline and retry behavior for calls
+// to a plugin. The zero value calls the plugin once without a deadline
+// other than the deadline of the given context.
+type CallPolicy struct {
+	// Timeout is the maximum duration of a single attempt (e.g. 30s, 5m).
+	Timeout string `json:"timeout,omitempty" yaml:"timeout" mapstructure:"timeout"`
+	// MaxAttempts is the maximum number of attempts for a call,
+	// including the first attempt. Values less than 2 disable retries.
+	MaxAttempts int `json:"maxAttempts,omitempty" yaml:"max-attempts" mapstructure:"max-attempts"`
+	// InitialBackoff is the delay before the first retry. The default is 1s.
+	InitialBackoff string `json:"initialBackoff,omitempty" yaml:"initial-backoff" mapstructure:"initial-backoff"`
+	// MaxBackoff is the maximum delay between retries. The default is 30s.
+	MaxBackoff string `json:"maxBackoff,omitempty" yaml:"max-backoff" mapstructure:"max-backoff"`
+	// BackoffMultiplier is the factor the delay is multiplied by after
+	// each retry. The default is 2.
+	BackoffMultiplier float64 `json:"backoffMultiplier,omitempty" yaml:"backoff-multiplier" mapstructure:"backoff-multiplier"`
+	// RetryableCodes are the names of the gRPC status codes that are retried
+	// (e.g. UNAVAILABLE). The default is UNAVAILABLE and DEADLINE_EXCEEDED.
+	RetryableCodes []string `json:"retryableCodes,omitempty" yaml:"retryable-codes" mapstructure:"retryable-codes"`
+}
+
+// Merge returns a copy of the CallPolicy with the fields
+// that are set in the override applied.
+func (c CallPolicy) Merge(override CallPolicy) CallPolicy {
+	if override.Timeout != "" {
+		c.Timeout = override.Timeout
+	}
+	if override.MaxAttempts != 0 {
+		c.MaxAttempts = override.MaxAttempts
+	}
+	if override.InitialBackoff != "" {
+		c.InitialBackoff = override.InitialBackoff
+	}
+	if override.MaxBackoff != "" {
+		c.MaxBackoff = override.MaxBackoff
+	}
+	if override.BackoffMultiplier != 0 {
+		c.BackoffMultiplier = override.BackoffMultiplier
+	}
+	if len(override.RetryableCodes) > 0 {
+		c.RetryableCodes = override.RetryableCodes
+	}
+	return c
+}
+
+// Validate returns an error if the CallPolicy has invalid fields.
+func (c CallPolicy) Validate() error {
+	_, err := c.retrier()
+	return err
+}
+
+// retrier parses the CallPolicy and applies defaults.
+func (c CallPolicy) retrier() (*retrier, error) {
+	r := &retrier{
+		maxAttempts:    c.MaxAttempts,
+		initialBackoff: defaultInitialBackoff,
+		maxBackoff:     defaultMaxBackoff,
+		multiplier:     defaultBackoffMultiplier,
+		retryableCodes: defaultRetryableCodes,
+	}
+	if r.maxAttempts < 1 {
+		r.maxAttempts = 1
+	}
+
+	var errs []error
+	parseDuration := func(field, value string, dest *time.Duration) {
+		if value == "" {
+			return
+		}
+		d, err := time.ParseDuration(value)
+		if err != nil || d <= 0 {
+			errs = append(errs, fmt.Errorf("invalid %s %q: must be a positive duration", field, value))
+			return
+		}
+		*dest = d
+	}
+	parseDuration("timeout", c.Timeout, &r.timeout)
+	parseDuration("initial backoff", c.InitialBackoff, &r.initialBackoff)
+	parseDuration("max backoff", c.MaxBackoff, &r.maxBackoff)
+
+	if c.BackoffMultiplier < 0 || (c.BackoffMultiplier > 0 && c.BackoffMultiplier < 1) {
+		errs = append(errs, fmt.Errorf("invalid backoff multiplier %v: must be at least 1", c.BackoffMultiplier))
+	} else if c.BackoffMultiplier != 0 {
+		r.multiplier = c.BackoffMultiplier
+	}
+
+	if len(c.RetryableCodes) > 0 {
+		r.retryableCodes = make([]codes.Code, 0, len(c.RetryableCodes))
+		for _, name := range c.RetryableCodes {
+			var code codes.Code
+			if err := code.UnmarshalJSON([]byte(strconv.Quote(strings.ToUpper(strings.TrimSpace(name))))); err != nil {
+				errs = append(errs, fmt.Errorf("invalid retryable code %q", name))
+				continue
+			}
+			r.retryableCodes = append(r.retryableCodes, code)
+		}
+	}
+
+	if len(errs) > 0 {
+		return nil, errors.Join(errs...)
+	}
+	return r, nil
+}
+
+// retrier applies a parsed CallPolicy to plugin calls.
+type retrier struct {
+	timeout        time.Duration
+	maxAttempts    int
+	initialBackoff time.Duration
+	maxBackoff     time.Duration
+	multiplier     float64
+	retryableCodes []codes.Code
+}
+
+// do calls fn until it succeeds, returns an error that is not retryable or
+// the maximum number of attempts is reached. Each attempt is limited by the timeout.
+func (r *retrier) do(ctx context.Context, method string, fn func(ctx context.Context) error) error {
+	log := logging.GetLogger("plugin")
+	backoff := r.initialBackoff
+	for attempt := 1; ; attempt++ {
+		err := r.attempt(ctx, fn)
+		var permanent *permanentError
+		if errors.As(err, &permanent) {
+			return permanent.err
+		}
+		if err == nil || ctx.Err() != nil || attempt >= r.maxAttempts || !r.retryable(err) {
+			return err
+		}
+
+		log.Warn(fmt.Sprintf("%s attempt %d of %d failed, retrying in %s: %v", method, attempt, r.maxAttempts, backoff, err))
+		timer := time.NewTimer(backoff)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return err
+		case <-timer.C:
+		}
+		backoff = time.Duration(float64(backoff) * r.multiplier)
+		if backoff > r.maxBackoff {
+			backoff = r.maxBackoff
+		}
+	}
+}
+
+func (r *retrier) attempt(ctx context.Context, fn func(ctx context.Context) error) error {
+	if r.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, r.timeout)
+		defer cancel()
+	}
+	return fn(ctx)
+}
+
+func (r *retrier) retryable(err error) bool {
+	code := status.Code(err)
+	for _, retryable := range r.retryableCodes {
+		if code == retryable {
+			return true
+		}
+	}
+	return false
+}
+
+// permanentError marks an error returned by a call
+// that must not be retried.
+type permanentError struct {
+	err error
+}
+
+func (e *permanentError) Error() string {
+	return e.err.Error()
+}
+
+func (e *permanentError) Unwrap() error {
+	return e.err
+}
@@ -0,0 +1,187 @@
+/*
+ Copyright 2025 The OSCAL Compass Authors
+ SPDX-License-Identifier: Apache-2.0
+*/
+
+package plugin
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestCallPolicy_Validate(t *testing.T) {
+	tests := []struct {
+		name       string
+		callPolicy CallPolicy
+		wantError  string
+	}{
+		{
+			name: "Valid/Empty",
+		},
+		{
+			name: "Valid/AllFields",
+			callPolicy: CallPolicy{
+				Timeout:           "5m",
+				MaxAttempts:       3,
+				InitialBackoff:    "2s",
+				MaxBackoff:        "1m",
+				BackoffMultiplier: 1.5,
+				RetryableCodes:    []string{"UNAVAILABLE", "internal"},
+			},
+		},
+		{
+			name: "Invalid/Durations",
+			callPolicy: CallPolicy{
+				Timeout:        "five minutes",
+				InitialBackoff: "-1s",
+			},
+			wantError: "invalid timeout \"five minutes\": must be a positive duration\ninvalid initial backoff \"-1s\": must be a positive duration",
+		},
+		{
+			name: "Invalid/BackoffMultiplier",
+			callPolicy: CallPolicy{
+				BackoffMultiplier: 0.5,
+			},
+			wantError: "invalid backoff multiplier 0.5: must be at least 1",
+		},
+		{
+			name: "Invalid/RetryableCode",
+			callPolicy: CallPolicy{
+				RetryableCodes: []string{"UNAVAILABLE", "FLAKY"},
+			},
+			wantError: "invalid retryable code \"FLAKY\"",
+		},
+	}
+
+	for _, c := range tests {
+		t.Run(c.name, func(t *testing.T) {
+			err := c.callPolicy.Validate()
+			if c.wantError != "" {
+				require.EqualError(t, err, c.wantError)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestCallPolicy_Merge(t *testing.T) {
+	manifestPolicy := CallPolicy{
+		Timeout:        "1m",
+		MaxAttempts:    3,
+		RetryableCodes: []string{"UNAVAILABLE"},
+	}
+	merged := manifestPolicy.Merge(CallPolicy{
+		Timeout:    "10m",
+		MaxBackoff: "5s",
+	})
+	require.Equal(t, CallPolicy{
+		Timeout:        "10m",
+		MaxAttempts:    3,
+		MaxBackoff:     "5s",
+		RetryableCodes: []string{"UNAVAILABLE"},
+	}, merged)
+	require.Equal(t, "1m", manifestPolicy.Timeout)
+}
+
+func TestRetrier_Do(t *testing.T) {
+	unavailable := status.Error(codes.Unavailable, "unavailable")
+	tests := []struct {
+		name         string
+		callPolicy   CallPolicy
+		errs         []error
+		wantAttempts int
+		wantError    string
+	}{
+		{
+			name:         "Success/NoRetries",
+			callPolicy:   CallPolicy{MaxAttempts: 3},
+			errs:         []error{nil},
+			wantAttempts: 1,
+		},
+		{
+			name:         "Success/AfterRetries",
+			callPolicy:   CallPolicy{MaxAttempts: 3, InitialBackoff: "1ms"},
+			errs:         []error{unavailable, unavailable, nil},
+			wantAttempts: 3,
+		},
+		{
+			name:         "Failure/MaxAttempts",
+			callPolicy:   CallPolicy{MaxAttempts: 2, InitialBackoff: "1ms"},
+			errs:         []error{unavailable, unavailable, nil},
+			wantAttempts: 2,
+			wantError:    "rpc error: code = Unavailable desc = unavailable",
+		},
+		{
+			name:         "Failure/NotRetryable",
+			callPolicy:   CallPolicy{MaxAttempts: 3, InitialBackoff: "1ms"},
+			errs:         []error{status.Error(codes.Internal, "internal"), nil},
+			wantAttempts: 1,
+			wantError:    "rpc error: code = Internal desc = internal",
+		},
+		{
+			name:         "Success/ConfiguredCodes",
+			callPolicy:   CallPolicy{MaxAttempts: 3, InitialBackoff: "1ms", RetryableCodes: []string{"INTERNAL"}},
+			errs:         []error{status.Error(codes.Internal, "internal"), nil},
+			wantAttempts: 2,
+		},
+		{
+			name:         "Failure/Permanent",
+			callPolicy:   CallPolicy{MaxAttempts: 3, InitialBackoff: "1ms"},
+			errs:         []error{&permanentError{err: unavailable}, nil},
+			wantAttempts: 1,
+			wantError:    "rpc error: code = Unavailable desc = unavailable",
+		},
+	}
+
+	for _, c := range tests {
+		t.Run(c.name, func(t *testing.T) {
+			r, err := c.callPolicy.retrier()
+			require.NoError(t, err)
+
+			var attempts int
+			err = r.do(context.TODO(), "Test", func(_ context.Context) error {
+				err := c.errs[attempts]
+				attempts++
+				return err
+			})
+			require.Equal(t, c.wantAttempts, attempts)
+			if c.wantError != "" {
+				require.EqualError(t, err, c.wantError)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestRetrier_DoTimeout(t *testing.T) {
+	r, err := CallPolicy{Timeout: "10ms"}.retrier()
+	require.NoError(t, err)
+
+	err = r.do(context.TODO(), "Test", func(ctx context.Context) error {
+		<-ctx.Done()
+		return ctx.Err()
+	})
+	require.True(t, errors.Is(err, context.DeadlineExceeded))
+
+	// The parent context deadline stops retries
+	r, err = CallPolicy{MaxAttempts: 5, InitialBackoff: "1h"}.retrier()
+	require.NoError(t, err)
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	var attempts int
+	err = r.do(ctx, "Test", func(_ context.Context) error {
+		attempts++
+		return status.Error(codes.Unavailable, "unavailable")
+	})
+	require.EqualError(t, err, "rpc error: code = Unavailable desc = unavailable")
+	require.Equal(t, 1, attempts)
+}
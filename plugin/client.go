@@ -0,0 +1,517 @@
+/*
+ Copyright 2025 The OSCAL Compass Authors
+ SPDX-License-Identifier: Apache-2.0
+*/
+
+package plugin
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/hashicorp/go-plugin"
+	"github.com/hashicorp/go-plugin/runner"
+	"google.golang.org/grpc/codes"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
+
+	"github.com/oscal-compass/compliance-to-policy-go/v2/logging"
+	"github.com/oscal-compass/compliance-to-policy-go/v2/policy"
+)
+
+const (
+	// maxStderrLines is the number of stderr lines kept for
+	// each plugin process.
+	maxStderrLines = 20
+	// exitGracePeriod is the maximum time to wait for a plugin process
+	// to exit after a lost connection.
+	exitGracePeriod = 500 * time.Millisecond
+)
+
+var (
+	_ policy.Provider       = (*processProvider)(nil)
+	_ policy.Describer      = (*processProvider)(nil)
+	_ policy.ResultStreamer = (*processProvider)(nil)
+	_ policy.Validator      = (*processProvider)(nil)
+	_ policy.CheckLister    = (*processProvider)(nil)
+)
+
+// Client is a go-plugin client for a plugin process that keeps
+// the last lines written by the process to stderr.
+type Client struct {
+	*plugin.Client
+	stderr *stderrTail
+	// detached is set for clients attached to a running plugin.
+	detached *detachedRunner
+	// done is closed when the plugin process exits.
+	done chan struct{}
+}
+
+// NewClient returns a new Client from the given go-plugin client configuration.
+// The configured Stderr and SyncStderr writers are replaced to keep the output
+// of the process and of the plugin after it is served. If the configuration
+// launches a Cmd without a RunnerFunc, the process is started with a runner
+// that notifies the client when the process exits.
+func NewClient(config *plugin.ClientConfig) *Client {
+	stderr := newStderrTail(maxStderrLines)
+	config.Stderr = stderr
+	config.SyncStderr = stderr
+	done := make(chan struct{})
+	if config.Cmd != nil && config.RunnerFunc == nil {
+		// go-plugin only accepts one of Cmd and RunnerFunc, so the command and its
+		// checksum verification are moved to the runner.
+		cmd, secureConfig := config.Cmd, config.SecureConfig
+		config.Cmd, config.SecureConfig = nil, nil
+		config.RunnerFunc = func(_ hclog.Logger, spec *exec.Cmd, _ string) (runner.Runner, error) {
+			if secureConfig != nil {
+				if ok, err := secureConfig.Check(cmd.Path); err != nil {
+					return nil, fmt.Errorf("error verifying checksum: %w", err)
+				} else if !ok {
+					return nil, plugin.ErrChecksumsDoNotMatch
+				}
+			}
+			// The spec has the environment and stdin set by go-plugin for the command.
+			cmd.Env = append(cmd.Env, spec.Env...)
+			cmd.Stdin = spec.Stdin
+			return newProcessRunner(cmd, done)
+		}
+	}
+	return &Client{
+		Client: plugin.NewClient(config),
+		stderr: stderr,
+		done:   done,
+	}
+}
+
+// Kill stops the plugin process. Clients attached to a running plugin with
+// a ReattachConfig close the connection to the plugin and leave it running.
+func (c *Client) Kill() {
+	if c.detached == nil {
+		c.Client.Kill()
+		return
+	}
+	// Closing the go-plugin client would shut down the plugin,
+	// so only the connection is closed.
+	if c.Client.ReattachConfig() != nil {
+		if rpcClient, err := c.Client.Client(); err == nil {
+			if grpcClient, ok := rpcClient.(*plugin.GRPCClient); ok {
+				_ = grpcClient.Conn.Close()
+			}
+		}
+	}
+	_ = c.detached.Kill(context.Background())
+}
+
+// Stderr returns the last lines written to stderr by the plugin process.
+func (c *Client) Stderr() []string {
+	return c.stderr.Lines()
+}
+
+// Done returns a channel that is closed when the plugin process exits.
+// For clients attached to a running plugin, it is closed when the client
+// detaches from the plugin.
+func (c *Client) Done() <-chan struct{} {
+	if c.detached != nil {
+		return c.detached.detached
+	}
+	return c.done
+}
+
+// alive returns true if the plugin answers a health check. The state of the
+// gRPC connection is not used because it is updated after call errors are returned.
+func (c *Client) alive() bool {
+	rpcClient, err := c.Client.Client()
+	if err != nil {
+		return false
+	}
+	grpcClient, ok := rpcClient.(*plugin.GRPCClient)
+	if !ok {
+		return false
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), exitGracePeriod)
+	defer cancel()
+	_, err = healthpb.NewHealthClient(grpcClient.Conn).Check(ctx, &healthpb.HealthCheckRequest{Service: plugin.GRPCServiceName})
+	return err == nil
+}
+
+var _ runner.Runner = (*processRunner)(nil)
+
+// processRunner is a runner.Runner for a plugin process that closes
+// the done channel when the process exits.
+type processRunner struct {
+	cmd    *exec.Cmd
+	stdout io.ReadCloser
+	stderr io.ReadCloser
+	done   chan struct{}
+	pid    int
+}
+
+func newProcessRunner(cmd *exec.Cmd, done chan struct{}) (*processRunner, error) {
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return nil, err
+	}
+	stderr, err := cmd.StderrPipe()
+	if err != nil {
+		return nil, err
+	}
+	return &processRunner{cmd: cmd, stdout: stdout, stderr: stderr, done: done}, nil
+}
+
+func (r *processRunner) Start(_ context.Context) error {
+	if err := r.cmd.Start(); err != nil {
+		return err
+	}
+	r.pid = r.cmd.Process.Pid
+	return nil
+}
+
+func (r *processRunner) Wait(_ context.Context) error {
+	defer close(r.done)
+	return r.cmd.Wait()
+}
+
+func (r *processRunner) Kill(_ context.Context) error {
+	if r.cmd.Process == nil {
+		return nil
+	}
+	// Kill is called again for exited processes.
+	if err := r.cmd.Process.Kill(); !errors.Is(err, os.ErrProcessDone) {
+		return err
+	}
+	return nil
+}
+
+func (r *processRunner) Diagnose(_ context.Context) string {
+	return fmt.Sprintf("the plugin %s failed to start or to negotiate the plugin protocol", r.cmd.Path)
+}
+
+func (r *processRunner) Stdout() io.ReadCloser {
+	return r.stdout
+}
+
+func (r *processRunner) Stderr() io.ReadCloser {
+	return r.stderr
+}
+
+func (r *processRunner) Name() string {
+	return r.cmd.Path
+}
+
+func (r *processRunner) ID() string {
+	return strconv.Itoa(r.pid)
+}
+
+func (r *processRunner) PluginToHost(pluginNet, pluginAddr string) (string, string, error) {
+	return pluginNet, pluginAddr, nil
+}
+
+func (r *processRunner) HostToPlugin(hostNet, hostAddr string) (string, string, error) {
+	return hostNet, hostAddr, nil
+}
+
+// stderrTail is an io.Writer that keeps the last written lines.
+type stderrTail struct {
+	mu       sync.Mutex
+	maxLines int
+	lines    []string
+	partial  bytes.Buffer
+}
+
+func newStderrTail(maxLines int) *stderrTail {
+	return &stderrTail{maxLines: maxLines}
+}
+
+func (s *stderrTail) Write(p []byte) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, b := range p {
+		if b != '\n' {
+			s.partial.WriteByte(b)
+			continue
+		}
+		s.lines = append(s.lines, s.partial.String())
+		s.partial.Reset()
+		if len(s.lines) > s.maxLines {
+			s.lines = s.lines[len(s.lines)-s.maxLines:]
+		}
+	}
+	return len(p), nil
+}
+
+// Lines returns the last complete lines followed by any incomplete line.
+func (s *stderrTail) Lines() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	lines := make([]string, len(s.lines), len(s.lines)+1)
+	copy(lines, s.lines)
+	if s.partial.Len() > 0 {
+		lines = append(lines, s.partial.String())
+	}
+	return lines
+}
+
+// processProvider is a policy.Provider backed by a plugin process. Calls that fail
+// because the process exited return a PluginCrashedError. If relaunch is set, the
+// plugin is relaunched and reconfigured once and the failed call is repeated.
+type processProvider struct {
+	manifest     Manifest
+	createClient ClientFactoryFunc
+	relaunch     bool
+	host         policy.Host
+
+	mu       sync.Mutex
+	client   *Client
+	provider policy.Provider
+	// configuration is the last applied configuration
+	// for reconfiguring a relaunched plugin.
+	configuration map[string]string
+	relaunched    bool
+}
+
+// launch starts a new plugin process and dispenses the policy provider.
+func (p *processProvider) launch() error {
+	client, err := p.createClient(p.manifest)
+	if err != nil {
+		return fmt.Errorf("failed to create plugin client for %s: %w", p.manifest.ID, err)
+	}
+	rpcClient, err := client.Client.Client()
+	if err != nil {
+		client.Kill()
+		return fmt.Errorf("failed to get plugin client for %s: %w", p.manifest.ID, err)
+	}
+
+	raw, err := rpcClient.Dispense(PVPPluginName)
+	if err != nil {
+		client.Kill()
+		return fmt.Errorf("failed to dispense plugin %s: %w", p.manifest.ID, err)
+	}
+
+	provider := raw.(policy.Provider)
+	if c, ok := provider.(*pvpClient); ok {
+		if err := c.setCallPolicy(p.manifest.CallPolicy); err != nil {
+			client.Kill()
+			return fmt.Errorf("invalid call policy for plugin %s: %w", p.manifest.ID, err)
+		}
+		c.exited = func(err error) bool {
+			return exited(client, err)
+		}
+		// The host services are set before the plugin is configured,
+		// including plugins without configuration options.
+		if err := c.setHost(context.Background(), p.host); err != nil {
+			client.Kill()
+			return fmt.Errorf("failed to set host services for plugin %s: %w", p.manifest.ID, err)
+		}
+	}
+	p.client = client
+	p.provider = provider
+	return nil
+}
+
+// current returns the running client and provider.
+func (p *processProvider) current() (*Client, policy.Provider) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.client, p.provider
+}
+
+// call runs fn with the current provider. If the plugin process exited during the
+// call, it is relaunched when allowed and fn is called again with the new provider.
+func (p *processProvider) call(ctx context.Context, method string, fn func(provider policy.Provider) (retry bool, err error)) error {
+	client, provider := p.current()
+	retry, err := fn(provider)
+	if err == nil || !exited(client, err) {
+		return err
+	}
+	crashErr := p.crashed(client, err)
+	if !retry {
+		return crashErr
+	}
+	relaunched, relaunchErr := p.relaunchOnce(ctx, client)
+	if relaunchErr != nil {
+		return fmt.Errorf("%w: failed to relaunch plugin: %v", crashErr, relaunchErr)
+	}
+	if !relaunched {
+		return crashErr
+	}
+	logging.GetLogger("plugin").Warn(fmt.Sprintf("Plugin %s crashed during %s and was relaunched: %v", p.manifest.ID, method, err))
+	client, provider = p.current()
+	if _, err = fn(provider); err != nil && exited(client, err) {
+		return p.crashed(client, err)
+	}
+	return err
+}
+
+// crashed returns a PluginCrashedError for the exited client.
+func (p *processProvider) crashed(client *Client, err error) *PluginCrashedError {
+	// Kill waits for the remaining stderr output of the exited process.
+	client.Kill()
+	return &PluginCrashedError{
+		PluginID: p.manifest.ID.String(),
+		Stderr:   client.Stderr(),
+		Err:      err,
+	}
+}
+
+// relaunchOnce replaces the exited client with a new, configured plugin process.
+// It returns false if the plugin is not allowed to be relaunched.
+func (p *processProvider) relaunchOnce(ctx context.Context, exitedClient *Client) (bool, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.client != exitedClient {
+		// Already relaunched by a concurrent call
+		return true, nil
+	}
+	if !p.relaunch || p.relaunched {
+		return false, nil
+	}
+	p.relaunched = true
+	if err := p.launch(); err != nil {
+		return false, err
+	}
+	if p.configuration != nil {
+		if err := p.provider.Configure(ctx, p.configuration); err != nil {
+			return false, err
+		}
+	}
+	return true, nil
+}
+
+func (p *processProvider) Configure(ctx context.Context, configuration map[string]string) error {
+	err := p.call(ctx, "Configure", func(provider policy.Provider) (bool, error) {
+		return true, provider.Configure(ctx, configuration)
+	})
+	if err == nil {
+		p.mu.Lock()
+		p.configuration = configuration
+		p.mu.Unlock()
+	}
+	return err
+}
+
+func (p *processProvider) Generate(ctx context.Context, pl policy.Policy) ([]policy.Artifact, error) {
+	return p.generate(ctx, pl, nil)
+}
+
+func (p *processProvider) generate(ctx context.Context, pl policy.Policy, metadata policy.Metadata) ([]policy.Artifact, error) {
+	var artifacts []policy.Artifact
+	err := p.call(ctx, "Generate", func(provider policy.Provider) (bool, error) {
+		var err error
+		artifacts, err = bindMetadata(provider, metadata).Generate(ctx, pl)
+		return true, err
+	})
+	return artifacts, err
+}
+
+func (p *processProvider) GetResults(ctx context.Context, pl policy.Policy) (policy.PVPResult, error) {
+	return p.getResults(ctx, pl, nil)
+}
+
+func (p *processProvider) getResults(ctx context.Context, pl policy.Policy, metadata policy.Metadata) (policy.PVPResult, error) {
+	var result policy.PVPResult
+	err := p.call(ctx, "GetResults", func(provider policy.Provider) (bool, error) {
+		var err error
+		result, err = bindMetadata(provider, metadata).GetResults(ctx, pl)
+		return true, err
+	})
+	return result, err
+}
+
+// StreamResults is only repeated after a relaunch if the plugin crashed
+// before any results were received.
+func (p *processProvider) StreamResults(ctx context.Context, pl policy.Policy, handler policy.ResultHandler) error {
+	return p.streamResults(ctx, pl, handler, nil)
+}
+
+func (p *processProvider) streamResults(ctx context.Context, pl policy.Policy, handler policy.ResultHandler, metadata policy.Metadata) error {
+	return p.call(ctx, "StreamResults", func(provider policy.Provider) (bool, error) {
+		streamer, ok := bindMetadata(provider, metadata).(policy.ResultStreamer)
+		if !ok {
+			return false, ErrNotImplemented
+		}
+		var received bool
+		err := streamer.StreamResults(ctx, pl, func(result policy.PVPResult) error {
+			received = true
+			return handler(result)
+		})
+		return !received, err
+	})
+}
+
+func (p *processProvider) Describe(ctx context.Context) (policy.ProviderInfo, error) {
+	var info policy.ProviderInfo
+	err := p.call(ctx, "Describe", func(provider policy.Provider) (bool, error) {
+		describer, ok := provider.(policy.Describer)
+		if !ok {
+			return false, ErrNotImplemented
+		}
+		var err error
+		info, err = describer.Describe(ctx)
+		return true, err
+	})
+	return info, err
+}
+
+func (p *processProvider) Validate(ctx context.Context, pl policy.Policy) ([]policy.Diagnostic, error) {
+	return p.validate(ctx, pl, nil)
+}
+
+func (p *processProvider) validate(ctx context.Context, pl policy.Policy, metadata policy.Metadata) ([]policy.Diagnostic, error) {
+	var diagnostics []policy.Diagnostic
+	err := p.call(ctx, "Validate", func(provider policy.Provider) (bool, error) {
+		validator, ok := bindMetadata(provider, metadata).(policy.Validator)
+		if !ok {
+			return false, ErrNotImplemented
+		}
+		var err error
+		diagnostics, err = validator.Validate(ctx, pl)
+		return true, err
+	})
+	return diagnostics, err
+}
+
+func (p *processProvider) ListChecks(ctx context.Context) (policy.Policy, error) {
+	var checks policy.Policy
+	err := p.call(ctx, "ListChecks", func(provider policy.Provider) (bool, error) {
+		lister, ok := provider.(policy.CheckLister)
+		if !ok {
+			return false, ErrNotImplemented
+		}
+		var err error
+		checks, err = lister.ListChecks(ctx)
+		return true, err
+	})
+	return checks, err
+}
+
+// exited returns true if the call error was caused by the
+// plugin process exiting.
+func exited(client *Client, err error) bool {
+	if client.Exited() {
+		return true
+	}
+	// A plugin that answers a health check is running
+	// and returned the error itself.
+	if status.Code(err) != codes.Unavailable || client.alive() {
+		return false
+	}
+	// The lost connection can be reported before the process exit
+	// is observed by the client.
+	timer := time.NewTimer(exitGracePeriod)
+	defer timer.Stop()
+	select {
+	case <-client.Done():
+		return true
+	case <-timer.C:
+		return false
+	}
+}
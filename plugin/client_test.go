@@ -0,0 +1,200 @@
+/*
+ Copyright 2025 The OSCAL Compass Authors
+ SPDX-License-Identifier: Apache-2.0
+*/
+
+package plugin
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/hashicorp/go-plugin"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/oscal-compass/compliance-to-policy-go/v2/policy"
+)
+
+const (
+	// testPluginEnv runs TestHelperPlugin as a plugin process.
+	testPluginEnv = "C2P_TEST_PLUGIN"
+	// testCrashMarkerEnv is a file created by the plugin process before it
+	// crashes. The plugin only crashes if the file does not exist.
+	testCrashMarkerEnv = "C2P_TEST_CRASH_MARKER"
+)
+
+func TestProcessProvider_Crash(t *testing.T) {
+	tests := []struct {
+		name      string
+		relaunch  bool
+		marker    bool
+		wantCrash bool
+	}{
+		{
+			name:      "Crash/NoRelaunch",
+			marker:    true,
+			wantCrash: true,
+		},
+		{
+			name:      "Crash/RelaunchFails",
+			relaunch:  true,
+			wantCrash: true,
+		},
+		{
+			name:     "Success/Relaunch",
+			relaunch: true,
+			marker:   true,
+		},
+	}
+
+	for _, c := range tests {
+		t.Run(c.name, func(t *testing.T) {
+			var env []string
+			if c.marker {
+				env = append(env, fmt.Sprintf("%s=%s", testCrashMarkerEnv, filepath.Join(t.TempDir(), "crashed")))
+			}
+			var opts []PolicyPluginOption
+			if c.relaunch {
+				opts = append(opts, WithRelaunch())
+			}
+			// Calls to the crashed plugin must not be retried
+			// or the test times out.
+			manifest := Manifest{
+				Metadata:   Metadata{ID: "crashing"},
+				CallPolicy: CallPolicy{MaxAttempts: 3, InitialBackoff: "10m"},
+			}
+			provider, err := NewPolicyPlugin(manifest, testClientFactory(t, env...), opts...)
+			require.NoError(t, err)
+			require.NoError(t, provider.Configure(context.TODO(), map[string]string{}))
+
+			result, err := provider.GetResults(context.TODO(), testPolicy)
+			if c.wantCrash {
+				var crashErr *PluginCrashedError
+				require.True(t, errors.As(err, &crashErr))
+				require.Equal(t, "crashing", crashErr.PluginID)
+				require.Contains(t, crashErr.Stderr, "panic: plugin crashed")
+				require.Contains(t, err.Error(), "panic: plugin crashed")
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, testPolicyPvpResult, result)
+		})
+	}
+}
+
+func TestProcessProvider_Unavailable(t *testing.T) {
+	manifest := Manifest{
+		Metadata:   Metadata{ID: "crashing"},
+		CallPolicy: CallPolicy{MaxAttempts: 3, InitialBackoff: "1ms"},
+	}
+	provider, err := NewPolicyPlugin(manifest, testClientFactory(t))
+	require.NoError(t, err)
+	validator, ok := provider.(policy.Validator)
+	require.True(t, ok)
+
+	// Unavailable errors of a running plugin are retried
+	// without waiting for the process to exit.
+	start := time.Now()
+	_, err = validator.Validate(context.TODO(), testPolicy)
+	require.Equal(t, codes.Unavailable, status.Code(err))
+	var crashErr *PluginCrashedError
+	require.False(t, errors.As(err, &crashErr))
+	require.Less(t, time.Since(start), exitGracePeriod)
+}
+
+func TestProcessProvider_Metadata(t *testing.T) {
+	provider, err := NewPolicyPlugin(Manifest{Metadata: Metadata{ID: "crashing"}}, testClientFactory(t))
+	require.NoError(t, err)
+
+	artifacts, err := WithMetadata(provider, testPolicyMetadata).Generate(context.TODO(), testPolicy)
+	require.NoError(t, err)
+	require.Equal(t, []policy.Artifact{{Path: "test-rule-1"}}, artifacts)
+
+	artifacts, err = provider.Generate(context.TODO(), testPolicy)
+	require.NoError(t, err)
+	require.Empty(t, artifacts)
+}
+
+func TestStderrTail(t *testing.T) {
+	tail := newStderrTail(2)
+	_, err := tail.Write([]byte("line 1\nline 2\nline"))
+	require.NoError(t, err)
+	_, err = tail.Write([]byte(" 3\npartial"))
+	require.NoError(t, err)
+	require.Equal(t, []string{"line 2", "line 3", "partial"}, tail.Lines())
+}
+
+// TestHelperPlugin is not a real test. It serves a crashingProvider
+// when launched as a plugin process by testClientFactory.
+func TestHelperPlugin(t *testing.T) {
+	if os.Getenv(testPluginEnv) != "1" {
+		return
+	}
+	Register(ServeConfig{
+		PluginSet: map[string]plugin.Plugin{
+			PVPPluginName: &PVPPlugin{Impl: &crashingProvider{marker: os.Getenv(testCrashMarkerEnv)}},
+		},
+		Logger: hclog.NewNullLogger(),
+	})
+	os.Exit(0)
+}
+
+// testClientFactory launches the test binary as a plugin process.
+func testClientFactory(t *testing.T, env ...string) ClientFactoryFunc {
+	return func(manifest Manifest) (*Client, error) {
+		cmd := exec.Command(os.Args[0], "-test.run=^TestHelperPlugin$") /* #nosec G204 */
+		cmd.Env = append(os.Environ(), testPluginEnv+"=1")
+		cmd.Env = append(cmd.Env, env...)
+		client := NewClient(&plugin.ClientConfig{
+			HandshakeConfig:  Handshake,
+			Plugins:          SupportedPlugins,
+			Cmd:              cmd,
+			AllowedProtocols: []plugin.Protocol{plugin.ProtocolGRPC},
+			Logger:           hclog.NewNullLogger(),
+		})
+		t.Cleanup(client.Kill)
+		return client, nil
+	}
+}
+
+// crashingProvider crashes the plugin process on GetResults
+// unless the marker file exists.
+type crashingProvider struct {
+	testProvider
+	marker string
+}
+
+func (p *crashingProvider) GetResults(ctx context.Context, pl policy.Policy) (policy.PVPResult, error) {
+	if p.marker != "" {
+		if _, err := os.Stat(p.marker); err == nil {
+			return testPolicyPvpResult, nil
+		}
+		if err := os.WriteFile(p.marker, nil, 0600); err != nil {
+			return policy.PVPResult{}, err
+		}
+	}
+	panic("plugin crashed")
+}
+
+// Generate returns an artifact for each rule with Metadata.
+func (p *crashingProvider) Generate(ctx context.Context, _ policy.Policy) ([]policy.Artifact, error) {
+	var artifacts []policy.Artifact
+	for ruleID := range policy.MetadataFromContext(ctx) {
+		artifacts = append(artifacts, policy.Artifact{Path: ruleID})
+	}
+	return artifacts, nil
+}
+
+// Validate fails with a transient error.
+func (p *crashingProvider) Validate(_ context.Context, _ policy.Policy) ([]policy.Diagnostic, error) {
+	return nil, status.Error(codes.Unavailable, "plugin busy")
+}
@@ -6,12 +6,17 @@
 package plugin
 
 import (
+	"bytes"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io/fs"
 	"os"
 	"path/filepath"
+	"slices"
 	"strings"
+
+	"github.com/oscal-compass/compliance-to-policy-go/v2/logging"
 )
 
 const (
@@ -19,9 +24,50 @@ const (
 	manifestSuffix = "-manifest.json"
 )
 
+// PluginPathEnv is the environment variable with an ordered list of plugin
+// directories separated by os.PathListSeparator (e.g. /usr/share/c2p:~/.c2p/plugins).
+const PluginPathEnv = "C2P_PLUGIN_PATH"
+
+// SearchDir is a directory in the plugin search path.
+type SearchDir struct {
+	// PluginDir is the directory with the plugin executables.
+	PluginDir string
+	// ManifestDir is the directory with the plugin manifests.
+	// If not set, the PluginDir is used.
+	ManifestDir string
+}
+
+func (d SearchDir) manifestDir() string {
+	if d.ManifestDir == "" {
+		return d.PluginDir
+	}
+	return d.ManifestDir
+}
+
+// SearchPathFromEnv returns the search directories set in the PluginPathEnv
+// environment variable. Each directory contains plugins and manifests.
+func SearchPathFromEnv() []SearchDir {
+	var searchPath []SearchDir
+	for _, dir := range filepath.SplitList(os.Getenv(PluginPathEnv)) {
+		dir = strings.TrimSpace(dir)
+		if dir == "" {
+			continue
+		}
+		searchPath = append(searchPath, SearchDir{PluginDir: filepath.Clean(dir)})
+	}
+	return searchPath
+}
+
+// pluginMatch is a plugin manifest found in a search directory.
+type pluginMatch struct {
+	dir          SearchDir
+	manifestName string
+}
+
 type findOptions struct {
 	providerIds []ID
 	pluginType  string
+	verifier    *Verifier
 }
 
 // FindOption represents a filtering criteria for plugin discovery in plugin.FindPlugins.
@@ -42,9 +88,19 @@ func WithPluginType(pluginType string) FindOption {
 	}
 }
 
+// WithVerifier verifies the signatures of the discovered plugins. Plugins
+// that fail verification are returned as a VerificationError.
+func WithVerifier(verifier *Verifier) FindOption {
+	return func(options *findOptions) {
+		options.verifier = verifier
+	}
+}
+
 // FindPlugins searches for plugins in the specified directory, optionally applying filters.
 //
 // The function expects plugin manifests in the format "c2p-$PLUGIN-ID-manifest.json".
+// The returned Manifests are keyed by the requested provider IDs, so named instances
+// of the same plugin each have a copy of the plugin manifest.
 //
 // Available filters:
 //   - `WithProviderIds`: Filters by a list of provider IDs.
@@ -52,31 +108,63 @@ func WithPluginType(pluginType string) FindOption {
 //
 // If no filters are applied, all discovered plugins are returned.
 func FindPlugins(pluginDir, pluginManifestDir string, opts ...FindOption) (Manifests, error) {
+	return FindPluginsInPath([]SearchDir{{PluginDir: pluginDir, ManifestDir: pluginManifestDir}}, opts...)
+}
+
+// FindPluginsInPath searches for plugins in each directory of the ordered search path,
+// optionally applying filters. See FindPlugins for the manifest naming scheme and filters.
+//
+// When a plugin ID is found in more than one directory, the plugin in the first directory
+// is used and the plugins it shadows are reported in the log. Directories that do not exist
+// are skipped.
+func FindPluginsInPath(searchPath []SearchDir, opts ...FindOption) (Manifests, error) {
 	config := &findOptions{}
 	for _, opt := range opts {
 		opt(config)
 	}
+	log := logging.GetLogger("discovery")
 
-	matchingPlugins, err := findAllPluginMatches(pluginManifestDir)
-	if err != nil {
-		return nil, err
+	var searchedDirs []string
+	for _, dir := range searchPath {
+		searchedDirs = append(searchedDirs, dir.PluginDir)
+	}
+	location := strings.Join(searchedDirs, string(os.PathListSeparator))
+
+	matchingPlugins := make(map[ID]pluginMatch)
+	for _, dir := range searchPath {
+		found, err := findAllPluginMatches(dir.manifestDir())
+		if err != nil {
+			if errors.Is(err, fs.ErrNotExist) {
+				log.Debug(fmt.Sprintf("Skipping plugin directory %s: %v", dir.manifestDir(), err))
+				continue
+			}
+			return nil, err
+		}
+		for id, manifestName := range found {
+			if existing, ok := matchingPlugins[id]; ok {
+				log.Warn(fmt.Sprintf("Plugin %s in %s is shadowed by plugin %s in %s", id, dir.manifestDir(), id, existing.dir.manifestDir()))
+				continue
+			}
+			matchingPlugins[id] = pluginMatch{dir: dir, manifestName: manifestName}
+		}
 	}
 
 	if len(matchingPlugins) == 0 {
-		return nil, fmt.Errorf("%w in %s", ErrPluginsNotFound, pluginDir)
+		return nil, fmt.Errorf("%w in %s", ErrPluginsNotFound, location)
 	}
 
 	collectedManifests := make(Manifests)
 	var errs []error
 
-	// Filter plugins by provider IDs if provided
+	// Filter plugins by provider IDs if provided. Instance IDs
+	// are matched to the manifest of the base plugin.
 	if len(config.providerIds) != 0 {
-		filteredIds := make(map[ID]string)
+		filteredIds := make(map[ID]pluginMatch)
 		for _, providerId := range config.providerIds {
-			if _, ok := matchingPlugins[providerId]; !ok {
+			if _, ok := matchingPlugins[providerId.Base()]; !ok {
 				errs = append(errs, &NotFoundError{providerId.String()})
 			}
-			filteredIds[providerId] = matchingPlugins[providerId]
+			filteredIds[providerId] = matchingPlugins[providerId.Base()]
 		}
 		matchingPlugins = filteredIds
 
@@ -87,9 +175,9 @@ func FindPlugins(pluginDir, pluginManifestDir string, opts ...FindOption) (Manif
 	}
 
 	// Process remaining plugins, filtering by plugin type if necessary
-	for id, manifestName := range matchingPlugins {
-		manifestPath := filepath.Join(pluginManifestDir, manifestName)
-		manifest, err := readManifestFile(id, manifestPath)
+	for id, match := range matchingPlugins {
+		manifestPath := filepath.Join(match.dir.manifestDir(), match.manifestName)
+		manifest, manifestData, err := readManifestFile(id.Base(), manifestPath)
 		if err != nil {
 			errs = append(errs, err)
 			continue
@@ -97,8 +185,8 @@ func FindPlugins(pluginDir, pluginManifestDir string, opts ...FindOption) (Manif
 
 		// Ensure consistent naming for the plugin identifier and
 		// that the name meets identifier criteria.
-		if !manifest.ID.Validate() || manifest.ID != id {
-			errs = append(errs, fmt.Errorf("invalid plugin id %q in manifest %s", manifest.ID, manifestName))
+		if !manifest.ID.Validate() || manifest.ID.Instance() != "" || manifest.ID != id.Base() {
+			errs = append(errs, fmt.Errorf("invalid plugin id %q in manifest %s", manifest.ID, match.manifestName))
 			continue
 		}
 
@@ -106,10 +194,20 @@ func FindPlugins(pluginDir, pluginManifestDir string, opts ...FindOption) (Manif
 			continue
 		}
 
-		// sanitize the executable path in the manifest
-		if manifestErr := manifest.ResolvePath(pluginDir); manifestErr != nil {
-			errs = append(errs, manifestErr)
-			continue
+		// sanitize the executable path in the manifest. Attached
+		// plugins are not launched from the plugin directory.
+		if manifest.Reattach == nil {
+			if manifestErr := manifest.ResolvePath(match.dir.PluginDir); manifestErr != nil {
+				errs = append(errs, manifestErr)
+				continue
+			}
+		}
+
+		if config.verifier != nil {
+			if err := config.verifier.verifyFile(manifest, manifestPath, manifestData); err != nil {
+				errs = append(errs, err)
+				continue
+			}
 		}
 		collectedManifests[id] = manifest
 	}
@@ -119,12 +217,42 @@ func FindPlugins(pluginDir, pluginManifestDir string, opts ...FindOption) (Manif
 	}
 
 	if len(collectedManifests) == 0 {
-		return nil, fmt.Errorf("%w in %s with matching criteria", ErrPluginsNotFound, pluginDir)
+		return nil, fmt.Errorf("%w in %s with matching criteria", ErrPluginsNotFound, location)
 	}
 
 	return collectedManifests, nil
 }
 
+// ManifestFileName returns the manifest file name for the plugin ID
+// (e.g. c2p-myplugin-manifest.json).
+func ManifestFileName(id ID) string {
+	return manifestPrefix + id.String() + manifestSuffix
+}
+
+// FindPluginIDs returns the sorted IDs of the plugins with a manifest in the
+// search path. The manifests are not read or validated.
+func FindPluginIDs(searchPath []SearchDir) ([]ID, error) {
+	found := make(map[ID]struct{})
+	for _, dir := range searchPath {
+		matches, err := findAllPluginMatches(dir.manifestDir())
+		if err != nil {
+			if errors.Is(err, fs.ErrNotExist) {
+				continue
+			}
+			return nil, err
+		}
+		for id := range matches {
+			found[id] = struct{}{}
+		}
+	}
+	ids := make([]ID, 0, len(found))
+	for id := range found {
+		ids = append(ids, id)
+	}
+	slices.Sort(ids)
+	return ids, nil
+}
+
 // findAllPluginsMatches locates the manifests in the plugin manifest directory that match
 // the prefix naming scheme and returns the plugin ID and file name.
 func findAllPluginMatches(pluginManifestDir string) (map[ID]string, error) {
@@ -136,7 +264,7 @@ func findAllPluginMatches(pluginManifestDir string) (map[ID]string, error) {
 	matchingPlugins := make(map[ID]string)
 	for _, item := range items {
 		name := item.Name()
-		if !strings.HasPrefix(name, manifestPrefix) {
+		if !strings.HasPrefix(name, manifestPrefix) || !strings.HasSuffix(name, manifestSuffix) {
 			continue
 		}
 		trimmedName := strings.TrimPrefix(name, manifestPrefix)
@@ -159,22 +287,22 @@ func manifestMatchesType(manifest Manifest, pluginType string) bool {
 }
 
 // readManifestFile reads and parses the manifest from JSON.
-func readManifestFile(pluginName ID, manifestPath string) (Manifest, error) {
+// The manifest file content is returned for signature verification.
+func readManifestFile(pluginName ID, manifestPath string) (Manifest, []byte, error) {
 	cleanedPath := filepath.Clean(manifestPath)
-	manifestFile, err := os.Open(cleanedPath)
+	manifestData, err := os.ReadFile(cleanedPath)
 	if err != nil {
 		if os.IsNotExist(err) {
-			return Manifest{}, &ManifestNotFoundError{File: manifestPath, PluginID: pluginName.String()}
+			return Manifest{}, nil, &ManifestNotFoundError{File: manifestPath, PluginID: pluginName.String()}
 		}
-		return Manifest{}, err
+		return Manifest{}, nil, err
 	}
-	defer manifestFile.Close()
 
-	jsonParser := json.NewDecoder(manifestFile)
+	jsonParser := json.NewDecoder(bytes.NewReader(manifestData))
 	var manifest Manifest
 	err = jsonParser.Decode(&manifest)
 	if err != nil {
-		return Manifest{}, err
+		return Manifest{}, nil, err
 	}
-	return manifest, nil
+	return manifest, manifestData, nil
 }
@@ -6,6 +6,8 @@
 package plugin
 
 import (
+	"os"
+	"path/filepath"
 	"sort"
 	"testing"
 
@@ -54,6 +56,27 @@ func TestFindPlugins(t *testing.T) {
 				},
 			},
 		},
+		{
+			name:         "Valid/MatchingPluginInstances",
+			testDataPath: "testdata/plugins",
+			options: []FindOption{
+				WithProviderIds([]ID{"testplugin@prod", "testplugin@staging"}),
+			},
+			wantMeta: []Metadata{
+				{
+					ID:          "testplugin",
+					Description: "My test plugin",
+					Version:     "0.0.0",
+					Types:       []string{"pvp"},
+				},
+				{
+					ID:          "testplugin",
+					Description: "My test plugin",
+					Version:     "0.0.0",
+					Types:       []string{"pvp"},
+				},
+			},
+		},
 		{
 			name:         "Valid/MatchingPluginOfType",
 			testDataPath: "testdata/plugins",
@@ -98,6 +121,14 @@ func TestFindPlugins(t *testing.T) {
 			},
 			wantError: "failed to find plugin \"example\" in plugin installation location",
 		},
+		{
+			name:         "Failure/NoMatchingPluginInstance",
+			testDataPath: "testdata/plugins",
+			options: []FindOption{
+				WithProviderIds([]ID{"example@prod"}),
+			},
+			wantError: "failed to find plugin \"example@prod\" in plugin installation location",
+		},
 		{
 			name:         "Failure/NoPluginsOfType",
 			testDataPath: "testdata/plugins",
@@ -129,3 +160,77 @@ func TestFindPlugins(t *testing.T) {
 		})
 	}
 }
+
+func TestFindPluginsInPath(t *testing.T) {
+	// A second testplugin that is shadowed by or shadows the testdata plugin
+	otherDir := t.TempDir()
+	manifest := `{"metadata": {"id": "testplugin", "description": "Other test plugin", "version": "1.0.0", "types": ["pvp"]}, "executablePath": "testplugin"}`
+	require.NoError(t, os.WriteFile(filepath.Join(otherDir, "c2p-testplugin-manifest.json"), []byte(manifest), 0600))
+	require.NoError(t, os.WriteFile(filepath.Join(otherDir, "testplugin"), []byte{}, 0700)) // #nosec G306
+	notExistDir := filepath.Join(t.TempDir(), "not-exist")
+
+	tests := []struct {
+		name            string
+		searchPath      []SearchDir
+		wantError       string
+		wantDescription string
+		wantPluginDir   string
+	}{
+		{
+			name:            "Valid/FirstDirectoryWins",
+			searchPath:      []SearchDir{{PluginDir: "testdata/plugins"}, {PluginDir: otherDir}},
+			wantDescription: "My test plugin",
+			wantPluginDir:   "testdata/plugins",
+		},
+		{
+			name:            "Valid/ShadowsLaterDirectory",
+			searchPath:      []SearchDir{{PluginDir: otherDir}, {PluginDir: "testdata/plugins"}},
+			wantDescription: "Other test plugin",
+			wantPluginDir:   otherDir,
+		},
+		{
+			name:            "Valid/SkipsMissingDirectory",
+			searchPath:      []SearchDir{{PluginDir: notExistDir}, {PluginDir: otherDir}},
+			wantDescription: "Other test plugin",
+			wantPluginDir:   otherDir,
+		},
+		{
+			name:       "Failure/NoPlugins",
+			searchPath: []SearchDir{{PluginDir: notExistDir}, {PluginDir: "testdata/"}},
+			wantError:  "no plugins found in " + notExistDir + string(os.PathListSeparator) + "testdata/",
+		},
+	}
+
+	for _, c := range tests {
+		t.Run(c.name, func(t *testing.T) {
+			manifests, err := FindPluginsInPath(c.searchPath, WithProviderIds([]ID{"testplugin"}))
+			if c.wantError != "" {
+				require.EqualError(t, err, c.wantError)
+				return
+			}
+			require.NoError(t, err)
+			require.Len(t, manifests, 1)
+			found := manifests["testplugin"]
+			require.Equal(t, c.wantDescription, found.Description)
+			wantPluginDir, err := filepath.Abs(c.wantPluginDir)
+			require.NoError(t, err)
+			require.Equal(t, filepath.Join(wantPluginDir, "testplugin"), found.ExecutablePath)
+		})
+	}
+}
+
+func TestSearchPathFromEnv(t *testing.T) {
+	t.Setenv(PluginPathEnv, "")
+	require.Empty(t, SearchPathFromEnv())
+
+	pluginPath := "/opt/c2p/plugins/" + string(os.PathListSeparator) + string(os.PathListSeparator) + "plugins"
+	t.Setenv(PluginPathEnv, pluginPath)
+	require.Equal(t, []SearchDir{{PluginDir: "/opt/c2p/plugins"}, {PluginDir: "plugins"}}, SearchPathFromEnv())
+}
+
+func TestFindPluginIDs(t *testing.T) {
+	ids, err := FindPluginIDs([]SearchDir{{PluginDir: "testdata/plugins"}, {PluginDir: "testdata/invalid-plugins"}, {PluginDir: "not-exist"}})
+	require.NoError(t, err)
+	require.Equal(t, []ID{"INVALID", "another-testplugin", "testplugin"}, ids)
+	require.Equal(t, "c2p-testplugin-manifest.json", ManifestFileName("testplugin"))
+}
@@ -3,12 +3,29 @@ package plugin
 import (
 	"errors"
 	"fmt"
+	"strings"
 )
 
 // ErrPluginsNotFound should be used when there are no discoverable
 // in the defined location.
 var ErrPluginsNotFound = errors.New("no plugins found")
 
+// ErrNotImplemented should be used when a plugin does not implement
+// an optional method.
+var ErrNotImplemented = errors.New("method not implemented by plugin")
+
+// ErrUnsigned should be used when a plugin manifest does not
+// have a signature and signatures are required.
+var ErrUnsigned = errors.New("plugin manifest is not signed")
+
+// ErrSignatureMismatch should be used when a plugin manifest signature
+// does not match any of the trusted keys.
+var ErrSignatureMismatch = errors.New("manifest signature does not match a trusted key")
+
+// ErrChecksumMismatch should be used when a plugin executable does not
+// match the checksum in the plugin manifest.
+var ErrChecksumMismatch = errors.New("plugin executable does not match the manifest checksum")
+
 // NotFoundError indicates that a requested plugin if not found
 // in the list of discovered plugins.
 type NotFoundError struct {
@@ -29,3 +46,50 @@ type ManifestNotFoundError struct {
 func (e *ManifestNotFoundError) Error() string {
 	return fmt.Sprintf("failed to open manifest file %s for plugin %q", e.File, e.PluginID)
 }
+
+// IncompatibleError indicates that a launched plugin does not match
+// the metadata declared in its manifest.
+type IncompatibleError struct {
+	PluginID string
+	Reason   string
+}
+
+func (e *IncompatibleError) Error() string {
+	return fmt.Sprintf("plugin %q is incompatible: %s", e.PluginID, e.Reason)
+}
+
+// PluginCrashedError indicates that a plugin process exited
+// while a call to the plugin was in progress.
+type PluginCrashedError struct {
+	PluginID string
+	// Stderr contains the last lines written to stderr by the plugin process.
+	Stderr []string
+	Err    error
+}
+
+func (e *PluginCrashedError) Error() string {
+	msg := fmt.Sprintf("plugin %q crashed: %v", e.PluginID, e.Err)
+	if len(e.Stderr) > 0 {
+		msg = fmt.Sprintf("%s\nlast plugin stderr output:\n%s", msg, strings.Join(e.Stderr, "\n"))
+	}
+	return msg
+}
+
+func (e *PluginCrashedError) Unwrap() error {
+	return e.Err
+}
+
+// VerificationError indicates that a plugin failed
+// signature verification.
+type VerificationError struct {
+	PluginID string
+	Err      error
+}
+
+func (e *VerificationError) Error() string {
+	return fmt.Sprintf("failed to verify plugin %q: %v", e.PluginID, e.Err)
+}
+
+func (e *VerificationError) Unwrap() error {
+	return e.Err
+}
@@ -0,0 +1,134 @@
+/*
+ Copyright 2025 The OSCAL Compass Authors
+ SPDX-License-Identifier: Apache-2.0
+*/
+
+package plugin
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/go-plugin"
+	"google.golang.org/grpc"
+
+	"github.com/oscal-compass/compliance-to-policy-go/v2/api/proto"
+	"github.com/oscal-compass/compliance-to-policy-go/v2/policy"
+)
+
+var (
+	_ proto.HostServiceServer = (*hostService)(nil)
+	_ policy.Host             = (*hostClient)(nil)
+)
+
+// hostService serves a policy.Host to a plugin.
+type hostService struct {
+	proto.UnimplementedHostServiceServer
+	Impl policy.Host
+}
+
+// FromHost returns a HostServiceServer for the policy.Host.
+func FromHost(host policy.Host) proto.HostServiceServer {
+	return &hostService{Impl: host}
+}
+
+func (h *hostService) StoreEvidence(ctx context.Context, request *proto.StoreEvidenceRequest) (*proto.StoreEvidenceResponse, error) {
+	link, err := h.Impl.StoreEvidence(ctx, policy.Evidence{
+		Name:        request.Name,
+		Description: request.Description,
+		MediaType:   request.MediaType,
+		Content:     request.Content,
+	})
+	if err != nil {
+		return &proto.StoreEvidenceResponse{}, toStatus(err)
+	}
+	return &proto.StoreEvidenceResponse{Link: &proto.Link{Description: link.Description, Href: link.Href}}, nil
+}
+
+func (h *hostService) ReportProgress(ctx context.Context, request *proto.ReportProgressRequest) (*proto.ReportProgressResponse, error) {
+	err := h.Impl.ReportProgress(ctx, policy.ProgressEvent{
+		Message: request.Message,
+		Current: request.Current,
+		Total:   request.Total,
+	})
+	if err != nil {
+		return &proto.ReportProgressResponse{}, toStatus(err)
+	}
+	return &proto.ReportProgressResponse{}, nil
+}
+
+func (h *hostService) ResolvePath(ctx context.Context, request *proto.ResolvePathRequest) (*proto.ResolvePathResponse, error) {
+	path, err := h.Impl.ResolvePath(ctx, request.Path)
+	if err != nil {
+		return &proto.ResolvePathResponse{}, toStatus(err)
+	}
+	return &proto.ResolvePathResponse{Path: path}, nil
+}
+
+// MaxEvidenceSize is the maximum size in bytes of the evidence content sent
+// to the host with StoreEvidence. Plugins should also keep the evidence in
+// their results below it, so each message stays under the default gRPC
+// message size limit of 4MB.
+const MaxEvidenceSize = 1 << 20
+
+// hostClient is a policy.Host that calls the host services
+// from a plugin.
+type hostClient struct {
+	client proto.HostServiceClient
+}
+
+func (h *hostClient) StoreEvidence(ctx context.Context, evidence policy.Evidence) (policy.Link, error) {
+	if len(evidence.Content) > MaxEvidenceSize {
+		return policy.Link{}, fmt.Errorf("evidence %s of %d bytes exceeds the limit of %d bytes", evidence.Name, len(evidence.Content), MaxEvidenceSize)
+	}
+	resp, err := h.client.StoreEvidence(ctx, &proto.StoreEvidenceRequest{
+		Name:        evidence.Name,
+		Description: evidence.Description,
+		MediaType:   evidence.MediaType,
+		Content:     evidence.Content,
+	})
+	if err != nil {
+		return policy.Link{}, err
+	}
+	return policy.Link{Description: resp.GetLink().GetDescription(), Href: resp.GetLink().GetHref()}, nil
+}
+
+func (h *hostClient) ReportProgress(ctx context.Context, event policy.ProgressEvent) error {
+	_, err := h.client.ReportProgress(ctx, &proto.ReportProgressRequest{
+		Message: event.Message,
+		Current: event.Current,
+		Total:   event.Total,
+	})
+	return err
+}
+
+func (h *hostClient) ResolvePath(ctx context.Context, path string) (string, error) {
+	resp, err := h.client.ResolvePath(ctx, &proto.ResolvePathRequest{Path: path})
+	if err != nil {
+		return "", err
+	}
+	return resp.Path, nil
+}
+
+// serveHost serves the policy.Host to the plugin with the broker
+// and returns the broker ID for the plugin to dial. The server is
+// stopped when the broker is closed.
+func serveHost(broker *plugin.GRPCBroker, host policy.Host) uint32 {
+	id := broker.NextId()
+	go broker.AcceptAndServe(id, func(opts []grpc.ServerOption) *grpc.Server {
+		server := grpc.NewServer(opts...)
+		proto.RegisterHostServiceServer(server, FromHost(host))
+		return server
+	})
+	return id
+}
+
+// dialHost returns a policy.Host for the host services
+// with the given broker ID.
+func dialHost(broker *plugin.GRPCBroker, id uint32) (policy.Host, error) {
+	conn, err := broker.Dial(id)
+	if err != nil {
+		return nil, err
+	}
+	return &hostClient{client: proto.NewHostServiceClient(conn)}, nil
+}
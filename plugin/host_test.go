@@ -0,0 +1,117 @@
+/*
+ Copyright 2025 The OSCAL Compass Authors
+ SPDX-License-Identifier: Apache-2.0
+*/
+
+package plugin
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/oscal-compass/compliance-to-policy-go/v2/policy"
+)
+
+func TestHostServices(t *testing.T) {
+	impl := &hostConsumerProvider{}
+	provider := dispenseTestProvider(t, impl)
+	host := &testHost{}
+	client, ok := provider.(*pvpClient)
+	require.True(t, ok)
+	// The host is set without configuring the plugin
+	require.NoError(t, client.setHost(context.TODO(), host))
+	require.NotNil(t, impl.host)
+	result, err := provider.GetResults(context.TODO(), testPolicy)
+	require.NoError(t, err)
+
+	require.Equal(t, []policy.Evidence{{Name: "report.json", Description: "Policy report", MediaType: "application/json", Content: []byte("{}")}}, host.evidence)
+	require.Equal(t, []policy.ProgressEvent{{Message: "Collected results", Current: 1, Total: 1}}, host.progress)
+	require.Len(t, result.ObservationsByCheck, 1)
+	require.Equal(t, []policy.Link{{Description: "Policy report", Href: "evidence/report.json"}}, result.ObservationsByCheck[0].RelevantEvidences)
+	require.Equal(t, []policy.Property{{Name: "policy-dir", Value: "/work/policies"}}, result.ObservationsByCheck[0].Props)
+
+	// Configure does not replace the host
+	previous := impl.host
+	require.NoError(t, provider.Configure(context.TODO(), map[string]string{}))
+	require.Same(t, previous, impl.host)
+
+	// Host errors are returned to the plugin
+	host.err = errors.New("disk full")
+	_, err = provider.GetResults(context.TODO(), testPolicy)
+	require.ErrorContains(t, err, "disk full")
+}
+
+func TestHostClient_EvidenceLimit(t *testing.T) {
+	client := &hostClient{}
+	_, err := client.StoreEvidence(context.TODO(), policy.Evidence{Name: "report.json", Content: make([]byte, MaxEvidenceSize+1)})
+	require.EqualError(t, err, "evidence report.json of 1048577 bytes exceeds the limit of 1048576 bytes")
+}
+
+func TestHostServices_NoHost(t *testing.T) {
+	impl := &hostConsumerProvider{}
+	provider := dispenseTestProvider(t, impl)
+	require.NoError(t, provider.Configure(context.TODO(), map[string]string{}))
+	require.Nil(t, impl.host)
+}
+
+// hostConsumerProvider uses the host services in GetResults.
+type hostConsumerProvider struct {
+	testProvider
+	host policy.Host
+}
+
+func (p *hostConsumerProvider) SetHost(host policy.Host) {
+	p.host = host
+}
+
+func (p *hostConsumerProvider) GetResults(ctx context.Context, _ policy.Policy) (policy.PVPResult, error) {
+	link, err := p.host.StoreEvidence(ctx, policy.Evidence{Name: "report.json", Description: "Policy report", MediaType: "application/json", Content: []byte("{}")})
+	if err != nil {
+		return policy.PVPResult{}, err
+	}
+	if err := p.host.ReportProgress(ctx, policy.ProgressEvent{Message: "Collected results", Current: 1, Total: 1}); err != nil {
+		return policy.PVPResult{}, err
+	}
+	policyDir, err := p.host.ResolvePath(ctx, "policies")
+	if err != nil {
+		return policy.PVPResult{}, err
+	}
+	return policy.PVPResult{
+		ObservationsByCheck: []policy.ObservationByCheck{
+			{
+				Title:             "check",
+				CheckID:           "check",
+				RelevantEvidences: []policy.Link{link},
+				Props:             []policy.Property{{Name: "policy-dir", Value: policyDir}},
+			},
+		},
+	}, nil
+}
+
+// testHost records the evidence and progress events from a plugin.
+type testHost struct {
+	evidence []policy.Evidence
+	progress []policy.ProgressEvent
+	err      error
+}
+
+func (h *testHost) StoreEvidence(_ context.Context, evidence policy.Evidence) (policy.Link, error) {
+	if h.err != nil {
+		return policy.Link{}, h.err
+	}
+	h.evidence = append(h.evidence, evidence)
+	return policy.Link{Description: evidence.Description, Href: "evidence/" + evidence.Name}, nil
+}
+
+func (h *testHost) ReportProgress(_ context.Context, event policy.ProgressEvent) error {
+	h.progress = append(h.progress, event)
+	return nil
+}
+
+func (h *testHost) ResolvePath(_ context.Context, path string) (string, error) {
+	return filepath.Join("/work", path), nil
+}
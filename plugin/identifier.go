@@ -5,7 +5,10 @@
 
 package plugin
 
-import "regexp"
+import (
+	"regexp"
+	"strings"
+)
 
 // IdentifierPattern defines criteria the plugin id must comply with.
 // It includes the following criteria:
@@ -13,7 +16,14 @@ import "regexp"
 //  2. May contain underscore (_) or hyphen (-) characters.
 var IdentifierPattern = regexp.MustCompile("^[a-z0-9_-]+$")
 
+// InstanceSeparator separates the plugin id from the instance
+// name in an instance ID (e.g. kyverno@prod).
+const InstanceSeparator = "@"
+
 // ID is a unique identifier for a plugin.
+//
+// An ID can optionally identify a named instance of a plugin in the
+// format "$PLUGIN-ID@$INSTANCE". Each instance is launched and configured separately.
 type ID string
 
 // String implements the Stringer interface
@@ -21,8 +31,25 @@ func (i ID) String() string {
 	return string(i)
 }
 
-// Validate ensures the plugin id is valid based on the
-// plugin IdentifierPattern.
+// Base returns the plugin id without the instance name.
+func (i ID) Base() ID {
+	base, _, _ := strings.Cut(i.String(), InstanceSeparator)
+	return ID(base)
+}
+
+// Instance returns the instance name or an empty string if the
+// ID does not identify a named instance.
+func (i ID) Instance() string {
+	_, instance, _ := strings.Cut(i.String(), InstanceSeparator)
+	return instance
+}
+
+// Validate ensures the plugin id and instance name, if set, are
+// valid based on the plugin IdentifierPattern.
 func (i ID) Validate() bool {
-	return IdentifierPattern.MatchString(i.String())
+	base, instance, found := strings.Cut(i.String(), InstanceSeparator)
+	if found && !IdentifierPattern.MatchString(instance) {
+		return false
+	}
+	return IdentifierPattern.MatchString(base)
 }
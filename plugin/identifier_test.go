@@ -21,3 +21,48 @@ func TestID_Validate(t *testing.T) {
 	}
 	require.False(t, failingMetadata.ID.Validate())
 }
+
+func TestID_Instance(t *testing.T) {
+	tests := []struct {
+		name         string
+		id           ID
+		wantBase     ID
+		wantInstance string
+		wantValid    bool
+	}{
+		{
+			name:      "Valid/NoInstance",
+			id:        "kyverno",
+			wantBase:  "kyverno",
+			wantValid: true,
+		},
+		{
+			name:         "Valid/Instance",
+			id:           "kyverno@prod",
+			wantBase:     "kyverno",
+			wantInstance: "prod",
+			wantValid:    true,
+		},
+		{
+			name:         "Invalid/EmptyInstance",
+			id:           "kyverno@",
+			wantBase:     "kyverno",
+			wantInstance: "",
+			wantValid:    false,
+		},
+		{
+			name:         "Invalid/NestedInstance",
+			id:           "kyverno@prod@east",
+			wantBase:     "kyverno",
+			wantInstance: "prod@east",
+			wantValid:    false,
+		},
+	}
+	for _, c := range tests {
+		t.Run(c.name, func(t *testing.T) {
+			require.Equal(t, c.wantBase, c.id.Base())
+			require.Equal(t, c.wantInstance, c.id.Instance())
+			require.Equal(t, c.wantValid, c.id.Validate())
+		})
+	}
+}
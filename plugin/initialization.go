@@ -6,10 +6,14 @@
 package plugin
 
 import (
+	"context"
 	"crypto/sha256"
 	"encoding/hex"
 	"fmt"
+	"os"
 	"os/exec"
+	"os/signal"
+	"syscall"
 
 	"github.com/hashicorp/go-hclog"
 	"github.com/hashicorp/go-plugin"
@@ -22,11 +26,32 @@ import (
 type ServeConfig struct {
 	PluginSet map[string]plugin.Plugin
 	Logger    hclog.Logger
+	// TLS requires mutual TLS for hosts attaching to a plugin
+	// served with ServeReattach.
+	TLS *TLSConfig
 }
 
 // Register a set of implemented plugins.
 // This function should be called last during plugin initialization in the main function.
+//
+// If the ReattachFileEnv environment variable is set and the plugin is not launched
+// by a host, the plugins are served with ServeReattach until the process is interrupted.
+// The TLS configuration is read from the environment if ServeConfig.TLS is not set.
 func Register(config ServeConfig) {
+	launched := os.Getenv(Handshake.MagicCookieKey) == Handshake.MagicCookieValue
+	if reattachFile := os.Getenv(ReattachFileEnv); reattachFile != "" && !launched {
+		if config.TLS == nil {
+			config.TLS = TLSConfigFromEnv()
+		}
+		ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+		defer stop()
+		if err := ServeReattach(ctx, config, reattachFile); err != nil {
+			fmt.Fprintf(os.Stderr, "failed to serve plugin: %v\n", err)
+			stop()
+			os.Exit(1)
+		}
+		return
+	}
 	plugin.Serve(&plugin.ServeConfig{
 		HandshakeConfig: Handshake,
 		Plugins:         config.PluginSet,
@@ -35,21 +60,41 @@ func Register(config ServeConfig) {
 	})
 }
 
-// Cleanup clean up all plugin clients created by the ClientFactory.
+// Cleanup clean up all managed go-plugin clients in the process.
+//
+// Deprecated: Clients created by the ClientFactory are not managed globally.
+// Use framework.PluginManager.Close to stop the plugins launched by a PluginManager.
 var Cleanup func() = plugin.CleanupClients
 
 // ClientFactoryFunc defines a function signature for creating
 // new go-plugin clients.
-type ClientFactoryFunc func(manifest Manifest) (*plugin.Client, error)
+type ClientFactoryFunc func(manifest Manifest) (*Client, error)
 
 // ClientFactory returns a factory function for creating new plugin-specific
 // clients with consistent plugin config settings.
 //
 // The returned factory function takes a Manifest object as input and returns
 // a new plugin client configured with the specified logger, allowed protocols,
-// and security settings.
+// and security settings. The caller must stop the returned client.
+//
+// If the manifest has a ReattachConfig, the client attaches to the running
+// plugin instead of launching the plugin executable.
 func ClientFactory(logger hclog.Logger) ClientFactoryFunc {
-	return func(manifest Manifest) (*plugin.Client, error) {
+	return func(manifest Manifest) (*Client, error) {
+		if manifest.Reattach != nil {
+			config, detached, err := reattachClientConfig(*manifest.Reattach)
+			if err != nil {
+				return nil, fmt.Errorf("invalid reattach configuration for plugin %s: %w", manifest.ID, err)
+			}
+			config.HandshakeConfig = Handshake
+			config.Logger = logger.Named(manifest.ID.String())
+			config.Managed = false
+			config.AllowedProtocols = []plugin.Protocol{plugin.ProtocolGRPC}
+			config.Plugins = SupportedPlugins
+			client := NewClient(config)
+			client.detached = detached
+			return client, nil
+		}
 		manifestSum, err := hex.DecodeString(manifest.Checksum)
 		if err != nil {
 			return nil, err
@@ -57,8 +102,9 @@ func ClientFactory(logger hclog.Logger) ClientFactoryFunc {
 		config := &plugin.ClientConfig{
 			HandshakeConfig: Handshake,
 			Logger:          logger.Named(manifest.ID.String()),
-			// Enabling this will ensure that client.Kill() is run when this is cleaned up.
-			Managed:          true,
+			// Clients are not tracked globally. The caller is responsible
+			// for stopping the client with client.Kill().
+			Managed:          false,
 			AutoMTLS:         true,
 			AllowedProtocols: []plugin.Protocol{plugin.ProtocolGRPC},
 			// The #nosec comment is added with justification that by using manifest.ResolvePath()
@@ -72,27 +118,45 @@ func ClientFactory(logger hclog.Logger) ClientFactoryFunc {
 			},
 		}
 
-		client := plugin.NewClient(config)
-		return client, nil
+		return NewClient(config), nil
 	}
 }
 
-// NewPolicyPlugin dispenses a new instance of a policy plugin.
-func NewPolicyPlugin(pluginManifest Manifest, createClient ClientFactoryFunc) (policy.Provider, error) {
-	client, err := createClient(pluginManifest)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create plugin client for %s: %w", pluginManifest.ID, err)
-	}
-	rpcClient, err := client.Client()
-	if err != nil {
-		return nil, fmt.Errorf("failed to get plugin client for %s: %w", pluginManifest.ID, err)
+// PolicyPluginOption configures a policy plugin created with NewPolicyPlugin.
+type PolicyPluginOption func(p *processProvider)
+
+// WithRelaunch relaunches and reconfigures a crashed plugin once
+// and repeats the failed call.
+func WithRelaunch() PolicyPluginOption {
+	return func(p *processProvider) {
+		p.relaunch = true
 	}
+}
 
-	raw, err := rpcClient.Dispense(PVPPluginName)
-	if err != nil {
-		return nil, fmt.Errorf("failed to dispense plugin %s: %w", pluginManifest.ID, err)
+// WithHost serves the host services to the plugin. Plugins
+// that implement policy.HostConsumer receive the Host before
+// they are configured.
+func WithHost(host policy.Host) PolicyPluginOption {
+	return func(p *processProvider) {
+		p.host = host
 	}
+}
 
-	p := raw.(policy.Provider)
+// NewPolicyPlugin dispenses a new instance of a policy plugin.
+// Calls to the plugin follow the CallPolicy in the manifest.
+//
+// Calls that fail because the plugin process exited return a PluginCrashedError
+// with the last stderr output of the plugin.
+func NewPolicyPlugin(pluginManifest Manifest, createClient ClientFactoryFunc, opts ...PolicyPluginOption) (policy.Provider, error) {
+	p := &processProvider{
+		manifest:     pluginManifest,
+		createClient: createClient,
+	}
+	for _, opt := range opts {
+		opt(p)
+	}
+	if err := p.launch(); err != nil {
+		return nil, err
+	}
 	return p, nil
 }
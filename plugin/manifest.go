@@ -6,14 +6,28 @@
 package plugin
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
+	"regexp"
+	"slices"
+	"sort"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/hashicorp/go-hclog"
+
+	"github.com/oscal-compass/compliance-to-policy-go/v2/policy"
 )
 
+// RedactedValue replaces the values of sensitive configuration options for display.
+const RedactedValue = "<redacted>"
+
 // Manifest is metadata about a plugin to support discovering and
 // launching plugins. This should be provided with the plugin on-disk.
 type Manifest struct {
@@ -27,6 +41,13 @@ type Manifest struct {
 	// Configuration is an optional section to add plugin
 	// configuration options and default values.
 	Configuration []ConfigurationOption `json:"configuration,omitempty"`
+	// CallPolicy is an optional section to set the deadline and
+	// retry behavior for calls to the plugin.
+	CallPolicy CallPolicy `json:"callPolicy,omitzero"`
+	// Reattach is an optional section to attach to a running plugin
+	// instead of launching the plugin executable. The executable path
+	// and checksum are not used for attached plugins.
+	Reattach *ReattachConfig `json:"reattach,omitempty"`
 }
 
 // ResolvePath validates and sanitizes the Manifest.ExecutablePath.
@@ -71,11 +92,47 @@ func (m *Manifest) ResolvePath(pluginDir string) error {
 	return nil
 }
 
+// VerifyChecksum compares the SHA256 checksum of the plugin executable with
+// the manifest Checksum. The executable path must be resolved with ResolvePath.
+func (m *Manifest) VerifyChecksum() error {
+	manifestSum, err := hex.DecodeString(m.Checksum)
+	if err != nil {
+		return fmt.Errorf("invalid manifest checksum: %w", err)
+	}
+	checksum, err := ComputeChecksum(m.ExecutablePath)
+	if err != nil {
+		return err
+	}
+	if checksum != hex.EncodeToString(manifestSum) {
+		return ErrChecksumMismatch
+	}
+	return nil
+}
+
+// ComputeChecksum returns the hex encoded SHA256 checksum of the file
+// for use as the Manifest Checksum.
+func ComputeChecksum(path string) (string, error) {
+	file, err := os.Open(filepath.Clean(path))
+	if err != nil {
+		return "", err
+	}
+	defer file.Close()
+	hash := sha256.New()
+	if _, err := io.Copy(hash, file); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
+
 // ResolveOptions validates and applies given configuration selections against the manifest
 // declared configuration and returns the resolved options.
+//
+// All missing required options and values that are not valid for the option type
+// and constraints are returned as a single joined error.
 func (m *Manifest) ResolveOptions(configSelections map[string]string, log hclog.Logger) (map[string]string, error) {
 	configMap := make(map[string]string)
 	processedOptions := make(map[string]struct{})
+	var errs []error
 	for _, option := range m.Configuration {
 		// Grab the defaults for each
 		if option.Default != nil {
@@ -89,10 +146,20 @@ func (m *Manifest) ResolveOptions(configSelections map[string]string, log hclog.
 			configMap[option.Name] = selected
 			processedOptions[option.Name] = struct{}{}
 		} else if option.Required {
-			return nil,
-				fmt.Errorf("required value not supplied for option %q", option.Name)
+			errs = append(errs, fmt.Errorf("required value not supplied for option %q", option.Name))
+			continue
+		}
+
+		if value, ok := configMap[option.Name]; ok {
+			if err := option.Validate(value); err != nil {
+				errs = append(errs, err)
+			}
 		}
 	}
+	if len(errs) > 0 {
+		return nil, errors.Join(errs...)
+	}
+
 	var unknownKeys []string
 	for key := range configSelections {
 		if _, found := processedOptions[key]; !found {
@@ -100,11 +167,87 @@ func (m *Manifest) ResolveOptions(configSelections map[string]string, log hclog.
 		}
 	}
 	if len(unknownKeys) > 0 {
+		// Only option names are logged as values of unknown
+		// options cannot be checked for sensitivity.
+		sort.Strings(unknownKeys)
 		log.Warn(fmt.Sprintf("Unknown configuration options found: %s", strings.Join(unknownKeys, ", ")))
 	}
 	return configMap, nil
 }
 
+// RedactOptions returns a copy of the given options with the values
+// of sensitive options replaced for display.
+func (m *Manifest) RedactOptions(options map[string]string) map[string]string {
+	sensitive := make(map[string]struct{})
+	for _, option := range m.Configuration {
+		if option.Sensitive {
+			sensitive[option.Name] = struct{}{}
+		}
+	}
+	redacted := make(map[string]string, len(options))
+	for name, value := range options {
+		if _, ok := sensitive[name]; ok {
+			value = RedactedValue
+		}
+		redacted[name] = value
+	}
+	return redacted
+}
+
+// CheckCompatibility validates the capabilities reported by a running plugin
+// against the manifest. Fields that are not reported by the plugin are not checked.
+//
+// The plugin must report the manifest version, all manifest types and features,
+// and accept all manifest configuration options. All mismatches are returned
+// in a single IncompatibleError.
+func (m *Manifest) CheckCompatibility(info policy.ProviderInfo) error {
+	var reasons []string
+	if info.Version != "" && m.Version != "" && info.Version != m.Version {
+		reasons = append(reasons, fmt.Sprintf("plugin version %s does not match manifest version %s", info.Version, m.Version))
+	}
+	if len(info.Types) > 0 {
+		if missing := missingValues(m.Types, info.Types); len(missing) > 0 {
+			reasons = append(reasons, fmt.Sprintf("plugin does not implement manifest types %s", strings.Join(missing, ", ")))
+		}
+	}
+	var features []string
+	for _, feature := range m.Features {
+		if !info.HasFeature(feature) {
+			features = append(features, string(feature))
+		}
+	}
+	if len(features) > 0 {
+		reasons = append(reasons, fmt.Sprintf("plugin does not implement manifest features %s", strings.Join(features, ", ")))
+	}
+	if len(info.ConfigurationOptions) > 0 {
+		var options []string
+		for _, option := range m.Configuration {
+			options = append(options, option.Name)
+		}
+		if missing := missingValues(options, info.ConfigurationOptions); len(missing) > 0 {
+			reasons = append(reasons, fmt.Sprintf("plugin does not accept manifest configuration options %s", strings.Join(missing, ", ")))
+		}
+	}
+	if len(reasons) > 0 {
+		return &IncompatibleError{
+			PluginID: m.ID.String(),
+			Reason:   strings.Join(reasons, "; "),
+		}
+	}
+	return nil
+}
+
+// missingValues returns the values that are not in the reported values.
+func missingValues(values, reported []string) []string {
+	var missing []string
+	for _, value := range values {
+		if !slices.Contains(reported, value) {
+			missing = append(missing, value)
+		}
+	}
+	return missing
+}
+
 // Metadata has required information for plugin launch and discovery.
 type Metadata struct {
 	// ID is the name of the plugin. This is the information used
@@ -119,8 +262,31 @@ type Metadata struct {
 	// are implemented by this plugin. It should match
 	// on or more of the values in plugin.SupportedPlugin.
 	Types []string `json:"types"`
+	// Features are the optional capabilities the plugin
+	// is expected to implement, e.g. validate.
+	Features []policy.Feature `json:"features,omitempty"`
 }
 
+// OptionType defines the kind of value accepted by a ConfigurationOption.
+type OptionType string
+
+const (
+	// OptionTypeString accepts any string value. This is the default.
+	OptionTypeString OptionType = "string"
+	// OptionTypeInt accepts an integer value.
+	OptionTypeInt OptionType = "int"
+	// OptionTypeBool accepts a boolean value (e.g. true, false, 1, 0).
+	OptionTypeBool OptionType = "bool"
+	// OptionTypeDuration accepts a Go duration string (e.g. 30s, 5m).
+	OptionTypeDuration OptionType = "duration"
+	// OptionTypePath accepts a path to an existing file or directory.
+	OptionTypePath OptionType = "path"
+	// OptionTypeEnum accepts one of the values in ConfigurationOption.AllowedValues.
+	OptionTypeEnum OptionType = "enum"
+	// OptionTypeStringList accepts a comma-separated list of strings.
+	OptionTypeStringList OptionType = "string-list"
+)
+
 // ConfigurationOption defines an option for configuring plugin behavior.
 type ConfigurationOption struct {
 	// Name is the human-readable name of the option.
@@ -131,6 +297,75 @@ type ConfigurationOption struct {
 	Required bool `json:"required"`
 	// Default is an optional parameter with the default selected value.
 	Default *string `json:"default,omitempty"`
+	// Type is the kind of value accepted by the option.
+	// If not set, any string value is accepted.
+	Type OptionType `json:"type,omitempty"`
+	// AllowedValues are the accepted values for enum options.
+	AllowedValues []string `json:"allowedValues,omitempty"`
+	// Pattern is an optional regular expression the value must match.
+	// For string-list options, each item must match.
+	Pattern string `json:"pattern,omitempty"`
+	// Sensitive indicates the value must not be displayed
+	// in logs or errors.
+	Sensitive bool `json:"sensitive,omitempty"`
+}
+
+// Validate checks the given value against the option type and constraints.
+func (o ConfigurationOption) Validate(value string) error {
+	if err := o.validate(value); err != nil {
+		if o.Sensitive {
+			return fmt.Errorf("invalid value for option %q: %w", o.Name, err)
+		}
+		return fmt.Errorf("invalid value %q for option %q: %w", value, o.Name, err)
+	}
+	return nil
+}
+
+func (o ConfigurationOption) validate(value string) error {
+	values := []string{value}
+	switch o.Type {
+	case "", OptionTypeString:
+	case OptionTypeInt:
+		if _, err := strconv.Atoi(value); err != nil {
+			return errors.New("must be an integer")
+		}
+	case OptionTypeBool:
+		if _, err := strconv.ParseBool(value); err != nil {
+			return errors.New("must be a boolean")
+		}
+	case OptionTypeDuration:
+		if _, err := time.ParseDuration(value); err != nil {
+			return errors.New("must be a duration")
+		}
+	case OptionTypePath:
+		if _, err := os.Stat(filepath.Clean(value)); err != nil {
+			return fmt.Errorf("must be an existing path: %w", errors.Unwrap(err))
+		}
+	case OptionTypeEnum:
+		if !slices.Contains(o.AllowedValues, value) {
+			return fmt.Errorf("must be one of %s", strings.Join(o.AllowedValues, ", "))
+		}
+	case OptionTypeStringList:
+		values = strings.Split(value, ",")
+		for i := range values {
+			values[i] = strings.TrimSpace(values[i])
+		}
+	default:
+		return fmt.Errorf("unsupported option type %q", o.Type)
+	}
+
+	if o.Pattern != "" {
+		pattern, err := regexp.Compile(o.Pattern)
+		if err != nil {
+			return fmt.Errorf("invalid pattern %q: %w", o.Pattern, err)
+		}
+		for _, v := range values {
+			if !pattern.MatchString(v) {
+				return fmt.Errorf("must match pattern %q", o.Pattern)
+			}
+		}
+	}
+	return nil
 }
 
 // Manifests defines the Manifest by plugin id.
@@ -14,6 +14,8 @@ import (
 
 	"github.com/hashicorp/go-hclog"
 	"github.com/stretchr/testify/require"
+
+	"github.com/oscal-compass/compliance-to-policy-go/v2/policy"
 )
 
 func TestManifest_ResolvePath(t *testing.T) {
@@ -170,6 +172,92 @@ func TestManifest_ResolveOptions(t *testing.T) {
 			},
 			wantError: "required value not supplied for option \"required\"",
 		},
+		{
+			name: "Success/TypedOptions",
+			testManifest: Manifest{
+				ExecutablePath: "testplugin",
+				Configuration: []ConfigurationOption{
+					{Name: "int", Type: OptionTypeInt},
+					{Name: "bool", Type: OptionTypeBool},
+					{Name: "duration", Type: OptionTypeDuration},
+					{Name: "path", Type: OptionTypePath},
+					{Name: "enum", Type: OptionTypeEnum, AllowedValues: []string{"a", "b"}},
+					{Name: "list", Type: OptionTypeStringList, Pattern: "^[a-z]+$"},
+					{Name: "string", Pattern: "^v[0-9]+$"},
+				},
+			},
+			selections: map[string]string{
+				"int":      "10",
+				"bool":     "true",
+				"duration": "30s",
+				"path":     "testdata",
+				"enum":     "b",
+				"list":     "one, two",
+				"string":   "v1",
+			},
+			wantOptions: map[string]string{
+				"int":      "10",
+				"bool":     "true",
+				"duration": "30s",
+				"path":     "testdata",
+				"enum":     "b",
+				"list":     "one, two",
+				"string":   "v1",
+			},
+		},
+		{
+			name: "Failure/InvalidDefault",
+			testManifest: Manifest{
+				ExecutablePath: "testplugin",
+				Configuration: []ConfigurationOption{
+					{Name: "int", Type: OptionTypeInt, Default: &defaultValue},
+				},
+			},
+			wantError: "invalid value \"default\" for option \"int\": must be an integer",
+		},
+		{
+			name: "Failure/AggregatedErrors",
+			testManifest: Manifest{
+				ExecutablePath: "testplugin",
+				Configuration: []ConfigurationOption{
+					{Name: "required", Required: true},
+					{Name: "bool", Type: OptionTypeBool},
+					{Name: "duration", Type: OptionTypeDuration},
+					{Name: "path", Type: OptionTypePath},
+					{Name: "enum", Type: OptionTypeEnum, AllowedValues: []string{"a", "b"}},
+					{Name: "list", Type: OptionTypeStringList, Pattern: "^[a-z]+$"},
+					{Name: "unsupported", Type: "float"},
+				},
+			},
+			selections: map[string]string{
+				"bool":        "maybe",
+				"duration":    "10",
+				"path":        "not-exist",
+				"enum":        "c",
+				"list":        "one,TWO",
+				"unsupported": "1.0",
+			},
+			wantError: "required value not supplied for option \"required\"\n" +
+				"invalid value \"maybe\" for option \"bool\": must be a boolean\n" +
+				"invalid value \"10\" for option \"duration\": must be a duration\n" +
+				"invalid value \"not-exist\" for option \"path\": must be an existing path: no such file or directory\n" +
+				"invalid value \"c\" for option \"enum\": must be one of a, b\n" +
+				"invalid value \"one,TWO\" for option \"list\": must match pattern \"^[a-z]+$\"\n" +
+				"invalid value \"1.0\" for option \"unsupported\": unsupported option type \"float\"",
+		},
+		{
+			name: "Failure/SensitiveValueRedacted",
+			testManifest: Manifest{
+				ExecutablePath: "testplugin",
+				Configuration: []ConfigurationOption{
+					{Name: "token", Pattern: "^[0-9a-f]{8}$", Sensitive: true},
+				},
+			},
+			selections: map[string]string{
+				"token": "supersecret",
+			},
+			wantError: "invalid value for option \"token\": must match pattern \"^[0-9a-f]{8}$\"",
+		},
 	}
 
 	for _, c := range tests {
@@ -185,6 +273,42 @@ func TestManifest_ResolveOptions(t *testing.T) {
 	}
 }
 
+func TestManifest_CheckCompatibility(t *testing.T) {
+	manifest := Manifest{
+		Metadata: Metadata{
+			ID:      "testplugin",
+			Version: "0.0.1",
+		},
+	}
+
+	require.NoError(t, manifest.CheckCompatibility(policy.ProviderInfo{}))
+	require.NoError(t, manifest.CheckCompatibility(policy.ProviderInfo{Version: "0.0.1"}))
+
+	err := manifest.CheckCompatibility(policy.ProviderInfo{Version: "0.0.2"})
+	var incompatibleErr *IncompatibleError
+	require.ErrorAs(t, err, &incompatibleErr)
+	require.EqualError(t, err, "plugin \"testplugin\" is incompatible: plugin version 0.0.2 does not match manifest version 0.0.1")
+
+	manifest.Types = []string{"pvp"}
+	manifest.Features = []policy.Feature{policy.FeatureValidate}
+	manifest.Configuration = []ConfigurationOption{{Name: "policy-dir"}, {Name: "output-dir"}}
+	info := policy.ProviderInfo{
+		Version:              "0.0.1",
+		Types:                []string{"pvp"},
+		Features:             []policy.Feature{policy.FeatureValidate},
+		ConfigurationOptions: []string{"policy-dir", "output-dir"},
+	}
+	require.NoError(t, manifest.CheckCompatibility(info))
+
+	info.Types = []string{"remediation"}
+	info.Features = nil
+	info.ConfigurationOptions = []string{"policy-dir"}
+	err = manifest.CheckCompatibility(info)
+	require.ErrorAs(t, err, &incompatibleErr)
+	require.EqualError(t, err, "plugin \"testplugin\" is incompatible: plugin does not implement manifest types pvp; "+
+		"plugin does not implement manifest features validate; plugin does not accept manifest configuration options output-dir")
+}
+
 func copyPlugin(t *testing.T, tmpDir, srcFile string) {
 	dstFile := filepath.Join(tmpDir, filepath.Base(srcFile))
 
@@ -207,3 +331,15 @@ func copyPlugin(t *testing.T, tmpDir, srcFile string) {
 	err = os.Chmod(dstFile, srcMode)
 	require.NoError(t, err)
 }
+
+func TestManifest_RedactOptions(t *testing.T) {
+	manifest := Manifest{
+		Configuration: []ConfigurationOption{
+			{Name: "token", Sensitive: true},
+			{Name: "region"},
+		},
+	}
+	options := map[string]string{"token": "secret", "region": "us-east"}
+	require.Equal(t, map[string]string{"token": RedactedValue, "region": "us-east"}, manifest.RedactOptions(options))
+	require.Equal(t, "secret", options["token"])
+}
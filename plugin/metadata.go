@@ -0,0 +1,103 @@
+/*
+ Copyright 2025 The OSCAL Compass Authors
+ SPDX-License-Identifier: Apache-2.0
+*/
+
+package plugin
+
+import (
+	"context"
+
+	"github.com/oscal-compass/compliance-to-policy-go/v2/policy"
+)
+
+var (
+	_ metadataBinder = (*pvpClient)(nil)
+	_ metadataBinder = (*processProvider)(nil)
+)
+
+// WithMetadata returns the Provider with the rule Metadata for the Policy of
+// the following calls. The Metadata should be built from the same rule sets as
+// the Policy, so both reflect the applied settings.
+//
+// Plugins receive the Metadata in the protobuf Rules of each request. In-process
+// providers receive it with the context of each call. In both cases, providers
+// read it with policy.MetadataFromContext.
+func WithMetadata(provider policy.Provider, metadata policy.Metadata) policy.Provider {
+	if binder, ok := provider.(metadataBinder); ok {
+		return binder.withMetadata(metadata)
+	}
+	return &contextProvider{Provider: provider, metadata: metadata}
+}
+
+// bindMetadata returns the provider with the Metadata, if any.
+func bindMetadata(provider policy.Provider, metadata policy.Metadata) policy.Provider {
+	if metadata == nil {
+		return provider
+	}
+	return WithMetadata(provider, metadata)
+}
+
+// metadataBinder is implemented by providers that send the Metadata
+// in the request messages.
+type metadataBinder interface {
+	withMetadata(metadata policy.Metadata) policy.Provider
+}
+
+func (p *processProvider) withMetadata(metadata policy.Metadata) policy.Provider {
+	return &boundProcessProvider{processProvider: p, metadata: metadata}
+}
+
+// boundProcessProvider sends the Metadata with the Policy of each
+// call to the current plugin process.
+type boundProcessProvider struct {
+	*processProvider
+	metadata policy.Metadata
+}
+
+func (p *boundProcessProvider) Generate(ctx context.Context, pl policy.Policy) ([]policy.Artifact, error) {
+	return p.generate(ctx, pl, p.metadata)
+}
+
+func (p *boundProcessProvider) GetResults(ctx context.Context, pl policy.Policy) (policy.PVPResult, error) {
+	return p.getResults(ctx, pl, p.metadata)
+}
+
+func (p *boundProcessProvider) StreamResults(ctx context.Context, pl policy.Policy, handler policy.ResultHandler) error {
+	return p.streamResults(ctx, pl, handler, p.metadata)
+}
+
+func (p *boundProcessProvider) Validate(ctx context.Context, pl policy.Policy) ([]policy.Diagnostic, error) {
+	return p.validate(ctx, pl, p.metadata)
+}
+
+// contextProvider sends the Metadata to an in-process provider with
+// the context of each call, like the server of a plugin does.
+type contextProvider struct {
+	policy.Provider
+	metadata policy.Metadata
+}
+
+func (p *contextProvider) Generate(ctx context.Context, pl policy.Policy) ([]policy.Artifact, error) {
+	return p.Provider.Generate(policy.WithMetadata(ctx, p.metadata), pl)
+}
+
+func (p *contextProvider) GetResults(ctx context.Context, pl policy.Policy) (policy.PVPResult, error) {
+	return p.Provider.GetResults(policy.WithMetadata(ctx, p.metadata), pl)
+}
+
+func (p *contextProvider) StreamResults(ctx context.Context, pl policy.Policy, handler policy.ResultHandler) error {
+	streamer, ok := p.Provider.(policy.ResultStreamer)
+	if !ok {
+		return ErrNotImplemented
+	}
+	return streamer.StreamResults(policy.WithMetadata(ctx, p.metadata), pl, handler)
+}
+
+func (p *contextProvider) Validate(ctx context.Context, pl policy.Policy) ([]policy.Diagnostic, error) {
+	validator, ok := p.Provider.(policy.Validator)
+	if !ok {
+		return nil, ErrNotImplemented
+	}
+	return validator.Validate(policy.WithMetadata(ctx, p.metadata), pl)
+}
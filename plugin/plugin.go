@@ -48,10 +48,10 @@ type PVPPlugin struct {
 }
 
 func (p *PVPPlugin) GRPCServer(broker *plugin.GRPCBroker, s *grpc.Server) error {
-	proto.RegisterPolicyEngineServiceServer(s, FromPVP(p.Impl))
+	proto.RegisterPolicyEngineServiceServer(s, &pvpService{Impl: p.Impl, broker: broker})
 	return nil
 }
 
 func (p *PVPPlugin) GRPCClient(ctx context.Context, broker *plugin.GRPCBroker, c *grpc.ClientConn) (interface{}, error) {
-	return &pvpClient{client: proto.NewPolicyEngineServiceClient(c)}, nil
+	return &pvpClient{client: proto.NewPolicyEngineServiceClient(c), broker: broker}, nil
 }
@@ -7,50 +7,249 @@ package plugin
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/hashicorp/go-plugin"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 
 	"github.com/oscal-compass/compliance-to-policy-go/v2/api/proto"
+	"github.com/oscal-compass/compliance-to-policy-go/v2/logging"
 	"github.com/oscal-compass/compliance-to-policy-go/v2/policy"
 )
 
 // Client must return an implementation of the corresponding interface that communicates over an RPC client.
-var _ policy.Provider = (*pvpClient)(nil)
+var (
+	_ policy.Provider       = (*pvpClient)(nil)
+	_ policy.Describer      = (*pvpClient)(nil)
+	_ policy.ResultStreamer = (*pvpClient)(nil)
+	_ policy.Validator      = (*pvpClient)(nil)
+	_ policy.CheckLister    = (*pvpClient)(nil)
+	_ policy.HostConsumer   = (*pvpClient)(nil)
+)
 
 type pvpClient struct {
 	client proto.PolicyEngineServiceClient
+	// retrier applies the CallPolicy of the plugin. If not set,
+	// each call is attempted once.
+	retrier *retrier
+	// broker serves the host services to the plugin.
+	broker *plugin.GRPCBroker
+	// exited returns true if the call error was caused by the
+	// plugin process exiting. Calls to an exited plugin are not retried.
+	exited func(err error) bool
+	// metadata is the rule Metadata sent with the Policy
+	// of each call.
+	metadata policy.Metadata
+}
+
+// withMetadata returns a copy of the client that sends the Metadata
+// in the protobuf Rules of each request.
+func (pvp *pvpClient) withMetadata(metadata policy.Metadata) policy.Provider {
+	bound := *pvp
+	bound.metadata = metadata
+	return &bound
+}
+
+// setCallPolicy applies the CallPolicy to all subsequent calls.
+func (pvp *pvpClient) setCallPolicy(callPolicy CallPolicy) error {
+	r, err := callPolicy.retrier()
+	if err != nil {
+		return err
+	}
+	pvp.retrier = r
+	return nil
+}
+
+// SetHost serves the host services to the plugin. Errors are logged
+// because policy.HostConsumer does not return them.
+func (pvp *pvpClient) SetHost(host policy.Host) {
+	if err := pvp.setHost(context.Background(), host); err != nil {
+		logging.GetLogger("plugin").Error(fmt.Sprintf("Failed to set host services: %v", err))
+	}
+}
+
+// setHost serves the host services to the plugin and sends the broker ID
+// to the plugin to connect to them. Plugins that do not support host
+// services are not changed.
+func (pvp *pvpClient) setHost(ctx context.Context, host policy.Host) error {
+	if host == nil || pvp.broker == nil {
+		return nil
+	}
+	request := proto.SetHostRequest{
+		HostServiceId: serveHost(pvp.broker, host),
+	}
+	err := pvp.call(ctx, "SetHost", func(ctx context.Context) error {
+		_, err := pvp.client.SetHost(ctx, &request)
+		return err
+	})
+	if err = fromStatus(err); errors.Is(err, ErrNotImplemented) {
+		return nil
+	}
+	return err
+}
+
+// call runs fn with the deadline and retry behavior of the CallPolicy.
+func (pvp *pvpClient) call(ctx context.Context, method string, fn func(ctx context.Context) error) error {
+	if pvp.retrier == nil {
+		return fn(ctx)
+	}
+	return pvp.retrier.do(ctx, method, func(ctx context.Context) error {
+		err := fn(ctx)
+		if err != nil && pvp.exited != nil && pvp.exited(err) {
+			return &permanentError{err: err}
+		}
+		return err
+	})
 }
 
 func (pvp *pvpClient) Configure(ctx context.Context, configuration map[string]string) error {
 	request := proto.ConfigureRequest{
 		Settings: configuration,
 	}
-	_, err := pvp.client.Configure(ctx, &request)
-	if err != nil {
+	err := pvp.call(ctx, "Configure", func(ctx context.Context) error {
+		_, err := pvp.client.Configure(ctx, &request)
 		return err
-	}
-	return nil
+	})
+	return fromStatus(err)
 }
 
-func (pvp *pvpClient) Generate(ctx context.Context, p policy.Policy) error {
-	rules := PolicyToProto(p)
+func (pvp *pvpClient) Generate(ctx context.Context, p policy.Policy) ([]policy.Artifact, error) {
+	rules := pvp.rulesToProto(p)
 	policyRequest := &proto.GenerateRequest{
 		Rule: rules,
 	}
-	_, err := pvp.client.Generate(ctx, policyRequest)
-	if err != nil {
+	var resp *proto.GenerateResponse
+	err := pvp.call(ctx, "Generate", func(ctx context.Context) error {
+		var err error
+		resp, err = pvp.client.Generate(ctx, policyRequest)
 		return err
+	})
+	if err != nil {
+		return nil, fromStatus(err)
 	}
-	return nil
+	return NewArtifactsFromProto(resp.Artifacts), nil
 }
 
 func (pvp *pvpClient) GetResults(ctx context.Context, p policy.Policy) (policy.PVPResult, error) {
-	rules := PolicyToProto(p)
+	rules := pvp.rulesToProto(p)
 	resultsRequest := &proto.GetResultsRequest{
 		Rule: rules,
 	}
-	resp, err := pvp.client.GetResults(ctx, resultsRequest)
+	var resp *proto.GetResultsResponse
+	err := pvp.call(ctx, "GetResults", func(ctx context.Context) error {
+		var err error
+		resp, err = pvp.client.GetResults(ctx, resultsRequest)
+		return err
+	})
 	if err != nil {
-		return policy.PVPResult{}, err
+		return policy.PVPResult{}, fromStatus(err)
 	}
 	pvpResult := NewResultFromProto(resp.Result)
 	return pvpResult, nil
 }
+
+// StreamResults applies the CallPolicy timeout to the whole stream. The stream is
+// only retried if it fails before any results are received.
+func (pvp *pvpClient) StreamResults(ctx context.Context, p policy.Policy, handler policy.ResultHandler) error {
+	rules := pvp.rulesToProto(p)
+	resultsRequest := &proto.StreamResultsRequest{
+		Rule: rules,
+	}
+	var received bool
+	var handlerErr error
+	err := pvp.call(ctx, "StreamResults", func(ctx context.Context) error {
+		stream, err := pvp.client.StreamResults(ctx, resultsRequest)
+		if err != nil {
+			return err
+		}
+		for {
+			resp, err := stream.Recv()
+			if errors.Is(err, io.EOF) {
+				return nil
+			}
+			if err != nil {
+				if received {
+					// Results already passed to the handler cannot be
+					// taken back, so the error is not retried.
+					return &permanentError{err: err}
+				}
+				return err
+			}
+			received = true
+			partial := &proto.PVPResult{Links: resp.Links}
+			if resp.Observation != nil {
+				partial.Observations = []*proto.ObservationByCheck{resp.Observation}
+			}
+			if handlerErr = handler(NewResultFromProto(partial)); handlerErr != nil {
+				return &permanentError{err: handlerErr}
+			}
+		}
+	})
+	if handlerErr != nil {
+		return handlerErr
+	}
+	return fromStatus(err)
+}
+
+func (pvp *pvpClient) Describe(ctx context.Context) (policy.ProviderInfo, error) {
+	var resp *proto.DescribeResponse
+	err := pvp.call(ctx, "Describe", func(ctx context.Context) error {
+		var err error
+		resp, err = pvp.client.Describe(ctx, &proto.DescribeRequest{})
+		return err
+	})
+	if err != nil {
+		return policy.ProviderInfo{}, fromStatus(err)
+	}
+	return NewProviderInfoFromProto(resp), nil
+}
+
+func (pvp *pvpClient) Validate(ctx context.Context, p policy.Policy) ([]policy.Diagnostic, error) {
+	rules := pvp.rulesToProto(p)
+	validateRequest := &proto.ValidateRequest{
+		Rule: rules,
+	}
+	var resp *proto.ValidateResponse
+	err := pvp.call(ctx, "Validate", func(ctx context.Context) error {
+		var err error
+		resp, err = pvp.client.Validate(ctx, validateRequest)
+		return err
+	})
+	if err != nil {
+		return nil, fromStatus(err)
+	}
+	return NewDiagnosticsFromProto(resp.Diagnostics), nil
+}
+
+func (pvp *pvpClient) ListChecks(ctx context.Context) (policy.Policy, error) {
+	var resp *proto.ListChecksResponse
+	err := pvp.call(ctx, "ListChecks", func(ctx context.Context) error {
+		var err error
+		resp, err = pvp.client.ListChecks(ctx, &proto.ListChecksRequest{})
+		return err
+	})
+	if err != nil {
+		return nil, fromStatus(err)
+	}
+	return NewPolicyFromProto(resp.Rules), nil
+}
+
+// rulesToProto transforms the Policy into protobuf Rules with
+// the Metadata of the client.
+func (pvp *pvpClient) rulesToProto(p policy.Policy) []*proto.Rule {
+	rules := PolicyToProto(p)
+	ApplyMetadataToProto(rules, pvp.metadata)
+	return rules
+}
+
+// fromStatus maps gRPC status errors to plugin errors where
+// a matching error exists.
+func fromStatus(err error) error {
+	if status.Code(err) == codes.Unimplemented {
+		return ErrNotImplemented
+	}
+	return err
+}
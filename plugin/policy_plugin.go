@@ -7,7 +7,10 @@ package plugin
 
 import (
 	"context"
+	"fmt"
+	"sync"
 
+	"github.com/hashicorp/go-plugin"
 	"google.golang.org/grpc/status"
 
 	"google.golang.org/grpc/codes"
@@ -19,9 +22,22 @@ import (
 // Plugin must return an RPC server for this plugin type.
 var _ proto.PolicyEngineServiceServer = (*pvpService)(nil)
 
+// MaxInlineArtifactSize is the maximum total size in bytes of the inline
+// artifact content returned by Generate over gRPC. It leaves room for the
+// artifact metadata under the default gRPC message size limit of 4MB.
+const MaxInlineArtifactSize = 3 << 20
+
 type pvpService struct {
 	proto.UnimplementedPolicyEngineServiceServer
 	Impl policy.Provider
+	// broker dials the host services. If not set,
+	// host services are not available.
+	broker *plugin.GRPCBroker
+
+	mu sync.Mutex
+	// hostServiceID is the broker ID of the host
+	// services set on the provider.
+	hostServiceID uint32
 }
 
 func FromPVP(pe policy.Provider) proto.PolicyEngineServiceServer {
@@ -32,28 +48,154 @@ func FromPVP(pe policy.Provider) proto.PolicyEngineServiceServer {
 
 func (p *pvpService) Configure(ctx context.Context, request *proto.ConfigureRequest) (*proto.ConfigureResponse, error) {
 	if err := p.Impl.Configure(ctx, request.Settings); err != nil {
-		return &proto.ConfigureResponse{}, status.Error(codes.Internal, err.Error())
+		return &proto.ConfigureResponse{}, toStatus(err)
 	}
 
 	// policy.Provider.Configure currently only returns an error, so using an empty proto.ConifgureResponse
 	return &proto.ConfigureResponse{}, nil
 }
 
+// SetHost dials the host services with the broker ID and sets them on
+// providers that implement policy.HostConsumer.
+func (p *pvpService) SetHost(_ context.Context, request *proto.SetHostRequest) (*proto.SetHostResponse, error) {
+	consumer, ok := p.Impl.(policy.HostConsumer)
+	if !ok || request.HostServiceId == 0 || p.broker == nil {
+		return &proto.SetHostResponse{}, nil
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if request.HostServiceId == p.hostServiceID {
+		return &proto.SetHostResponse{}, nil
+	}
+	host, err := dialHost(p.broker, request.HostServiceId)
+	if err != nil {
+		return &proto.SetHostResponse{}, toStatus(fmt.Errorf("failed to connect to host services: %w", err))
+	}
+	consumer.SetHost(host)
+	p.hostServiceID = request.HostServiceId
+	return &proto.SetHostResponse{}, nil
+}
+
 func (p *pvpService) Generate(ctx context.Context, request *proto.GenerateRequest) (*proto.GenerateResponse, error) {
 	rules := NewPolicyFromProto(request.Rule)
-	if err := p.Impl.Generate(ctx, rules); err != nil {
-		return &proto.GenerateResponse{}, status.Error(codes.Internal, err.Error())
+	artifacts, err := p.Impl.Generate(withMetadata(ctx, request.Rule), rules)
+	if err != nil {
+		return &proto.GenerateResponse{}, toStatus(err)
 	}
+	return &proto.GenerateResponse{Artifacts: ArtifactsToProto(limitInlineContent(artifacts))}, nil
+}
 
-	// policy.Provider.Generate currently only returns an error, so using an empty proto.GenerateResponse
-	return &proto.GenerateResponse{}, nil
+// limitInlineContent drops the inline content of the artifacts that exceed
+// the MaxInlineArtifactSize. The artifacts are still reported with their
+// path and checksum, so plugins must write them to an output location.
+func limitInlineContent(artifacts []policy.Artifact) []policy.Artifact {
+	var size int
+	limited := make([]policy.Artifact, len(artifacts))
+	for i, artifact := range artifacts {
+		if size+len(artifact.Content) > MaxInlineArtifactSize {
+			artifact.Content = nil
+		}
+		size += len(artifact.Content)
+		limited[i] = artifact
+	}
+	return limited
 }
 
 func (p *pvpService) GetResults(ctx context.Context, request *proto.GetResultsRequest) (*proto.GetResultsResponse, error) {
 	rules := NewPolicyFromProto(request.Rule)
-	result, err := p.Impl.GetResults(ctx, rules)
+	result, err := p.Impl.GetResults(withMetadata(ctx, request.Rule), rules)
 	if err != nil {
-		return &proto.GetResultsResponse{}, status.Error(codes.Internal, err.Error())
+		return &proto.GetResultsResponse{}, toStatus(err)
 	}
 	return &proto.GetResultsResponse{Result: ResultsToProto(result)}, nil
 }
+
+func (p *pvpService) StreamResults(request *proto.StreamResultsRequest, stream proto.PolicyEngineService_StreamResultsServer) error {
+	rules := NewPolicyFromProto(request.Rule)
+	ctx := withMetadata(stream.Context(), request.Rule)
+	send := func(result policy.PVPResult) error {
+		pvpResult := ResultsToProto(result)
+		for _, observation := range pvpResult.Observations {
+			if err := stream.Send(&proto.StreamResultsResponse{Observation: observation}); err != nil {
+				return err
+			}
+		}
+		if len(pvpResult.Links) > 0 {
+			return stream.Send(&proto.StreamResultsResponse{Links: pvpResult.Links})
+		}
+		return nil
+	}
+
+	// Providers that do not stream natively have their results
+	// sent one observation at a time.
+	var err error
+	if streamer, ok := p.Impl.(policy.ResultStreamer); ok {
+		err = streamer.StreamResults(ctx, rules, send)
+	} else {
+		var result policy.PVPResult
+		result, err = p.Impl.GetResults(ctx, rules)
+		if err == nil {
+			err = send(result)
+		}
+	}
+	if err != nil {
+		return toStatus(err)
+	}
+	return nil
+}
+
+func (p *pvpService) Describe(ctx context.Context, _ *proto.DescribeRequest) (*proto.DescribeResponse, error) {
+	describer, ok := p.Impl.(policy.Describer)
+	if !ok {
+		return &proto.DescribeResponse{}, status.Error(codes.Unimplemented, "plugin does not implement Describe")
+	}
+	info, err := describer.Describe(ctx)
+	if err != nil {
+		return &proto.DescribeResponse{}, toStatus(err)
+	}
+	return ProviderInfoToProto(info), nil
+}
+
+func (p *pvpService) Validate(ctx context.Context, request *proto.ValidateRequest) (*proto.ValidateResponse, error) {
+	validator, ok := p.Impl.(policy.Validator)
+	if !ok {
+		return &proto.ValidateResponse{}, status.Error(codes.Unimplemented, "plugin does not implement Validate")
+	}
+	rules := NewPolicyFromProto(request.Rule)
+	diagnostics, err := validator.Validate(withMetadata(ctx, request.Rule), rules)
+	if err != nil {
+		return &proto.ValidateResponse{}, toStatus(err)
+	}
+	return &proto.ValidateResponse{Diagnostics: DiagnosticsToProto(diagnostics)}, nil
+}
+
+func (p *pvpService) ListChecks(ctx context.Context, _ *proto.ListChecksRequest) (*proto.ListChecksResponse, error) {
+	lister, ok := p.Impl.(policy.CheckLister)
+	if !ok {
+		return &proto.ListChecksResponse{}, status.Error(codes.Unimplemented, "plugin does not implement ListChecks")
+	}
+	checks, err := lister.ListChecks(ctx)
+	if err != nil {
+		return &proto.ListChecksResponse{}, toStatus(err)
+	}
+	return &proto.ListChecksResponse{Rules: PolicyToProto(checks)}, nil
+}
+
+// withMetadata returns the context for a provider call with
+// the Metadata of the protobuf Rules, if any.
+func withMetadata(ctx context.Context, rules []*proto.Rule) context.Context {
+	if metadata := NewMetadataFromProto(rules); metadata != nil {
+		return policy.WithMetadata(ctx, metadata)
+	}
+	return ctx
+}
+
+// toStatus converts a provider error to a gRPC status error. Errors that
+// already have a status (e.g. codes.Unavailable for transient failures) keep
+// their code so the client can decide whether to retry the call.
+func toStatus(err error) error {
+	if _, ok := status.FromError(err); ok {
+		return err
+	}
+	return status.Error(codes.Internal, err.Error())
+}
@@ -0,0 +1,333 @@
+/*
+ Copyright 2025 The OSCAL Compass Authors
+ SPDX-License-Identifier: Apache-2.0
+*/
+
+package plugin
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/hashicorp/go-plugin"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/oscal-compass/compliance-to-policy-go/v2/policy"
+)
+
+func TestPVPPlugin_StreamResults(t *testing.T) {
+	tests := []struct {
+		name      string
+		impl      policy.Provider
+		wantCount int
+		wantError string
+	}{
+		{
+			name:      "Valid/StreamingProvider",
+			impl:      &testStreamingProvider{testProvider{result: testPolicyPvpResult}},
+			wantCount: 2,
+		},
+		{
+			name:      "Valid/NonStreamingProvider",
+			impl:      &testProvider{result: testPolicyPvpResult},
+			wantCount: 2,
+		},
+		{
+			name:      "Invalid/ProviderError",
+			impl:      &testProvider{err: errors.New("results failed")},
+			wantError: "rpc error: code = Internal desc = results failed",
+		},
+	}
+
+	for _, c := range tests {
+		t.Run(c.name, func(t *testing.T) {
+			provider := dispenseTestProvider(t, c.impl)
+			streamer, ok := provider.(policy.ResultStreamer)
+			require.True(t, ok)
+
+			var merged policy.PVPResult
+			var count int
+			err := streamer.StreamResults(context.TODO(), testPolicy, func(partial policy.PVPResult) error {
+				count++
+				merged.ObservationsByCheck = append(merged.ObservationsByCheck, partial.ObservationsByCheck...)
+				merged.Links = append(merged.Links, partial.Links...)
+				return nil
+			})
+			if c.wantError != "" {
+				require.EqualError(t, err, c.wantError)
+				return
+			}
+			require.NoError(t, err)
+			// One message per observation and one for links
+			require.Equal(t, c.wantCount, count)
+			require.Equal(t, testPolicyPvpResult, merged)
+		})
+	}
+}
+
+func TestPVPPlugin_Generate(t *testing.T) {
+	artifact := policy.NewArtifact("test-rule-1/policy.yaml", "application/yaml", []byte("test"))
+	artifact.RuleIDs = []string{"test-rule-1"}
+	wantArtifacts := []policy.Artifact{artifact}
+
+	provider := dispenseTestProvider(t, &testProvider{artifacts: wantArtifacts})
+	artifacts, err := provider.Generate(context.TODO(), testPolicy)
+	require.NoError(t, err)
+	require.Equal(t, wantArtifacts, artifacts)
+
+	provider = dispenseTestProvider(t, &testProvider{err: errors.New("generate failed")})
+	_, err = provider.Generate(context.TODO(), testPolicy)
+	require.EqualError(t, err, "rpc error: code = Internal desc = generate failed")
+
+	provider = dispenseTestProvider(t, &testProvider{err: status.Error(codes.Unimplemented, "not implemented")})
+	_, err = provider.Generate(context.TODO(), testPolicy)
+	require.ErrorIs(t, err, ErrNotImplemented)
+}
+
+func TestPVPPlugin_GenerateInlineLimit(t *testing.T) {
+	content := make([]byte, MaxInlineArtifactSize/2+1)
+	wantArtifacts := []policy.Artifact{
+		policy.NewArtifact("test-rule-1/policy.yaml", "application/yaml", content),
+		policy.NewArtifact("test-rule-2/policy.yaml", "application/yaml", content),
+	}
+
+	provider := dispenseTestProvider(t, &testProvider{artifacts: wantArtifacts})
+	artifacts, err := provider.Generate(context.TODO(), testPolicy)
+	require.NoError(t, err)
+	require.Len(t, artifacts, 2)
+	require.Equal(t, content, artifacts[0].Content)
+	// Content beyond the limit is dropped, the checksum is kept
+	require.Nil(t, artifacts[1].Content)
+	require.Equal(t, wantArtifacts[1].Checksum, artifacts[1].Checksum)
+}
+
+func TestLimitInlineContent(t *testing.T) {
+	large := policy.NewArtifact("large/policy.yaml", "application/yaml", make([]byte, MaxInlineArtifactSize+1))
+	small := policy.NewArtifact("small/policy.yaml", "application/yaml", []byte("kind: Policy"))
+
+	// Dropped content does not count towards the limit of later artifacts
+	limited := limitInlineContent([]policy.Artifact{large, small})
+	require.Nil(t, limited[0].Content)
+	require.Equal(t, large.Checksum, limited[0].Checksum)
+	require.Equal(t, small.Content, limited[1].Content)
+}
+
+func TestPVPPlugin_Validate(t *testing.T) {
+	wantDiagnostics := []policy.Diagnostic{
+		{
+			RuleID:    "test-rule-1",
+			Parameter: "test-param-1",
+			Type:      policy.DiagnosticUnsupportedParameterValue,
+			Message:   "unsupported value \"1\"",
+		},
+	}
+	provider := dispenseTestProvider(t, &testValidatingProvider{diagnostics: wantDiagnostics})
+	validator, ok := provider.(policy.Validator)
+	require.True(t, ok)
+	diagnostics, err := validator.Validate(context.TODO(), testPolicy)
+	require.NoError(t, err)
+	require.Equal(t, wantDiagnostics, diagnostics)
+
+	provider = dispenseTestProvider(t, &testValidatingProvider{testProvider: testProvider{err: errors.New("validate failed")}})
+	validator, ok = provider.(policy.Validator)
+	require.True(t, ok)
+	_, err = validator.Validate(context.TODO(), testPolicy)
+	require.EqualError(t, err, "rpc error: code = Internal desc = validate failed")
+
+	provider = dispenseTestProvider(t, &testProvider{})
+	validator, ok = provider.(policy.Validator)
+	require.True(t, ok)
+	_, err = validator.Validate(context.TODO(), testPolicy)
+	require.ErrorIs(t, err, ErrNotImplemented)
+}
+
+func TestPVPPlugin_ListChecks(t *testing.T) {
+	provider := dispenseTestProvider(t, &testCheckLister{checks: testPolicy})
+	lister, ok := provider.(policy.CheckLister)
+	require.True(t, ok)
+	checks, err := lister.ListChecks(context.TODO())
+	require.NoError(t, err)
+	require.Equal(t, testPolicy, checks)
+
+	provider = dispenseTestProvider(t, &testProvider{})
+	lister, ok = provider.(policy.CheckLister)
+	require.True(t, ok)
+	_, err = lister.ListChecks(context.TODO())
+	require.ErrorIs(t, err, ErrNotImplemented)
+}
+
+func TestPVPPlugin_Metadata(t *testing.T) {
+	impl := &metadataProvider{}
+	provider := dispenseTestProvider(t, impl)
+
+	_, err := WithMetadata(provider, testPolicyMetadata).GetResults(context.TODO(), testPolicy)
+	require.NoError(t, err)
+	require.Equal(t, testPolicyMetadata, impl.metadata)
+
+	// Metadata is only sent in the requests of the bound provider,
+	// not with the context of the host
+	ctx := policy.WithMetadata(context.TODO(), testPolicyMetadata)
+	_, err = provider.GetResults(ctx, testPolicy)
+	require.NoError(t, err)
+	require.Nil(t, impl.metadata)
+
+	// In-process providers receive the metadata with the context
+	inProcess := &metadataProvider{}
+	_, err = WithMetadata(inProcess, testPolicyMetadata).GetResults(context.TODO(), testPolicy)
+	require.NoError(t, err)
+	require.Equal(t, testPolicyMetadata, inProcess.metadata)
+}
+
+func TestPVPPlugin_CallPolicy(t *testing.T) {
+	impl := &flakyProvider{
+		testProvider: testProvider{result: testPolicyPvpResult},
+		failures:     2,
+	}
+	provider := dispenseTestProvider(t, impl)
+	client, ok := provider.(*pvpClient)
+	require.True(t, ok)
+	require.NoError(t, client.setCallPolicy(CallPolicy{MaxAttempts: 3, InitialBackoff: "1ms"}))
+
+	result, err := provider.GetResults(context.TODO(), testPolicy)
+	require.NoError(t, err)
+	require.Equal(t, testPolicyPvpResult, result)
+	require.Equal(t, 3, impl.calls)
+
+	// The stream is retried when it fails before any results are received
+	impl.calls = 0
+	var count int
+	err = client.StreamResults(context.TODO(), testPolicy, func(policy.PVPResult) error {
+		count++
+		return nil
+	})
+	require.NoError(t, err)
+	require.Equal(t, 3, impl.calls)
+	require.Equal(t, 2, count)
+
+	impl.calls = 0
+	impl.failures = 3
+	_, err = provider.GetResults(context.TODO(), testPolicy)
+	require.EqualError(t, err, "rpc error: code = Unavailable desc = plugin unavailable")
+	require.Equal(t, 3, impl.calls)
+}
+
+func TestPVPPlugin_DescribeTimeout(t *testing.T) {
+	provider := dispenseTestProvider(t, &stuckDescriber{})
+	client, ok := provider.(*pvpClient)
+	require.True(t, ok)
+	require.NoError(t, client.setCallPolicy(CallPolicy{Timeout: "10ms"}))
+
+	// The call policy timeout stops a plugin that does not respond
+	_, err := client.Describe(context.TODO())
+	require.Equal(t, codes.DeadlineExceeded, status.Code(err))
+}
+
+func dispenseTestProvider(t *testing.T, impl policy.Provider) policy.Provider {
+	client, _ := plugin.TestPluginGRPCConn(t, false, map[string]plugin.Plugin{
+		PVPPluginName: &PVPPlugin{Impl: impl},
+	})
+	// Closing the client also stops the server.
+	t.Cleanup(func() {
+		_ = client.Close()
+	})
+	raw, err := client.Dispense(PVPPluginName)
+	require.NoError(t, err)
+	provider, ok := raw.(policy.Provider)
+	require.True(t, ok)
+	return provider
+}
+
+// testProvider is a static implementation of policy.Provider.
+type testProvider struct {
+	artifacts []policy.Artifact
+	result    policy.PVPResult
+	err       error
+}
+
+func (p *testProvider) Configure(context.Context, map[string]string) error {
+	return nil
+}
+
+func (p *testProvider) Generate(context.Context, policy.Policy) ([]policy.Artifact, error) {
+	return p.artifacts, p.err
+}
+
+func (p *testProvider) GetResults(context.Context, policy.Policy) (policy.PVPResult, error) {
+	return p.result, p.err
+}
+
+// testStreamingProvider sends each observation of the static result separately.
+type testStreamingProvider struct {
+	testProvider
+}
+
+func (p *testStreamingProvider) StreamResults(_ context.Context, _ policy.Policy, handler policy.ResultHandler) error {
+	if p.err != nil {
+		return p.err
+	}
+	for _, obs := range p.result.ObservationsByCheck {
+		if err := handler(policy.PVPResult{ObservationsByCheck: []policy.ObservationByCheck{obs}}); err != nil {
+			return err
+		}
+	}
+	return handler(policy.PVPResult{Links: p.result.Links})
+}
+
+// testValidatingProvider returns static diagnostics.
+type testValidatingProvider struct {
+	testProvider
+	diagnostics []policy.Diagnostic
+}
+
+func (p *testValidatingProvider) Validate(context.Context, policy.Policy) ([]policy.Diagnostic, error) {
+	return p.diagnostics, p.err
+}
+
+// testCheckLister returns static checks.
+type testCheckLister struct {
+	testProvider
+	checks policy.Policy
+}
+
+func (p *testCheckLister) ListChecks(context.Context) (policy.Policy, error) {
+	return p.checks, p.err
+}
+
+// metadataProvider records the Metadata sent with GetResults.
+type metadataProvider struct {
+	testProvider
+	metadata policy.Metadata
+}
+
+func (p *metadataProvider) GetResults(ctx context.Context, _ policy.Policy) (policy.PVPResult, error) {
+	p.metadata = policy.MetadataFromContext(ctx)
+	return p.result, p.err
+}
+
+// flakyProvider fails with codes.Unavailable for the first failures calls.
+type flakyProvider struct {
+	testProvider
+	failures int
+	calls    int
+}
+
+func (p *flakyProvider) GetResults(ctx context.Context, pl policy.Policy) (policy.PVPResult, error) {
+	p.calls++
+	if p.calls <= p.failures {
+		return policy.PVPResult{}, status.Error(codes.Unavailable, "plugin unavailable")
+	}
+	return p.testProvider.GetResults(ctx, pl)
+}
+
+// stuckDescriber does not respond to Describe until the call is cancelled.
+type stuckDescriber struct {
+	testProvider
+}
+
+func (p *stuckDescriber) Describe(ctx context.Context) (policy.ProviderInfo, error) {
+	<-ctx.Done()
+	return policy.ProviderInfo{}, ctx.Err()
+}
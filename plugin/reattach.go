@@ -0,0 +1,364 @@
+/*
+ Copyright 2025 The OSCAL Compass Authors
+ SPDX-License-Identifier: Apache-2.0
+*/
+
+package plugin
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/hashicorp/go-plugin"
+	"github.com/hashicorp/go-plugin/runner"
+)
+
+const (
+	// ReattachFileEnv is the environment variable with the path of the reattach
+	// file. If set, Register serves the plugin with ServeReattach until the
+	// plugin process is interrupted.
+	ReattachFileEnv = "C2P_PLUGIN_REATTACH_FILE"
+	// TLSCertFileEnv, TLSKeyFileEnv and TLSCAFileEnv are the environment
+	// variables with the TLS configuration used by Register for plugins
+	// served with ServeReattach.
+	TLSCertFileEnv = "C2P_PLUGIN_TLS_CERT_FILE"
+	TLSKeyFileEnv  = "C2P_PLUGIN_TLS_KEY_FILE"
+	TLSCAFileEnv   = "C2P_PLUGIN_TLS_CA_FILE"
+
+	// defaultServerName is the expected server name in the plugin
+	// certificate if no server name is set.
+	defaultServerName = "localhost"
+)
+
+// ReattachConfig configures the host to attach to a plugin that is already
+// running, such as in a sidecar, instead of launching the plugin executable.
+// The plugin address is set with Address or read from the reattach File
+// written by ServeReattach.
+type ReattachConfig struct {
+	// Network is the network of the plugin address: "unix" (default) or "tcp".
+	Network string `json:"network,omitempty" yaml:"network" mapstructure:"network"`
+	// Address is the path of the Unix socket or the localhost TCP address
+	// of the plugin.
+	Address string `json:"address,omitempty" yaml:"address" mapstructure:"address"`
+	// File is the path to the reattach file written by ServeReattach.
+	File string `json:"file,omitempty" yaml:"file" mapstructure:"file"`
+	// TLS configures mutual TLS for the connection to the plugin.
+	// It is required for TCP addresses.
+	TLS *TLSConfig `json:"tls,omitempty" yaml:"tls" mapstructure:"tls"`
+}
+
+// TLSConfig sets the certificates for mutual TLS between the host
+// and a plugin served with ServeReattach.
+type TLSConfig struct {
+	// CertFile is the PEM encoded certificate presented to the peer.
+	CertFile string `json:"certFile" yaml:"cert-file" mapstructure:"cert-file"`
+	// KeyFile is the PEM encoded private key for the CertFile.
+	KeyFile string `json:"keyFile" yaml:"key-file" mapstructure:"key-file"`
+	// CAFile is the PEM encoded CA bundle used to verify the peer certificate.
+	CAFile string `json:"caFile" yaml:"ca-file" mapstructure:"ca-file"`
+	// ServerName is the expected server name in the plugin certificate.
+	// It defaults to localhost.
+	ServerName string `json:"serverName,omitempty" yaml:"server-name" mapstructure:"server-name"`
+}
+
+// Validate returns an error if the ReattachConfig has invalid fields.
+func (r *ReattachConfig) Validate() error {
+	if (r.Address == "") == (r.File == "") {
+		return errors.New("exactly one of address or file must be set")
+	}
+	if r.TLS != nil {
+		if r.TLS.CertFile == "" || r.TLS.KeyFile == "" || r.TLS.CAFile == "" {
+			return errors.New("tls requires a certificate, key and CA file")
+		}
+	}
+	if r.Address == "" {
+		return nil
+	}
+	switch r.Network {
+	case "", "unix":
+	case "tcp":
+		host, _, err := net.SplitHostPort(r.Address)
+		if err != nil {
+			return fmt.Errorf("invalid tcp address %q: %w", r.Address, err)
+		}
+		if ip := net.ParseIP(host); host != "localhost" && (ip == nil || !ip.IsLoopback()) {
+			return fmt.Errorf("tcp address %q must be a localhost address", r.Address)
+		}
+		if r.TLS == nil {
+			return fmt.Errorf("tls is required for tcp address %q", r.Address)
+		}
+	default:
+		return fmt.Errorf("unsupported network %q: must be unix or tcp", r.Network)
+	}
+	return nil
+}
+
+// Resolve returns the ReattachConfig with the address from the reattach
+// File, if set, and validates it.
+func (r *ReattachConfig) Resolve() (ReattachConfig, error) {
+	if err := r.Validate(); err != nil {
+		return ReattachConfig{}, err
+	}
+	resolved := *r
+	if r.File != "" {
+		data, err := os.ReadFile(filepath.Clean(r.File))
+		if err != nil {
+			return ReattachConfig{}, fmt.Errorf("failed to read reattach file: %w", err)
+		}
+		var served ReattachConfig
+		if err := json.Unmarshal(data, &served); err != nil {
+			return ReattachConfig{}, fmt.Errorf("invalid reattach file %s: %w", r.File, err)
+		}
+		resolved.Network = served.Network
+		resolved.Address = served.Address
+		resolved.File = ""
+		if err := resolved.Validate(); err != nil {
+			return ReattachConfig{}, fmt.Errorf("invalid reattach file %s: %w", r.File, err)
+		}
+	}
+	if resolved.Network == "" {
+		resolved.Network = "unix"
+	}
+	return resolved, nil
+}
+
+// TLSConfigFromEnv returns the TLSConfig set in the TLSCertFileEnv, TLSKeyFileEnv
+// and TLSCAFileEnv environment variables or nil if none are set.
+func TLSConfigFromEnv() *TLSConfig {
+	config := &TLSConfig{
+		CertFile: os.Getenv(TLSCertFileEnv),
+		KeyFile:  os.Getenv(TLSKeyFileEnv),
+		CAFile:   os.Getenv(TLSCAFileEnv),
+	}
+	if *config == (TLSConfig{}) {
+		return nil
+	}
+	return config
+}
+
+// serverConfig returns the TLS configuration for a plugin that
+// requires verified client certificates.
+func (c *TLSConfig) serverConfig() (*tls.Config, error) {
+	certificate, pool, err := c.load()
+	if err != nil {
+		return nil, err
+	}
+	return &tls.Config{
+		Certificates: []tls.Certificate{certificate},
+		ClientCAs:    pool,
+		ClientAuth:   tls.RequireAndVerifyClientCert,
+		MinVersion:   tls.VersionTLS12,
+	}, nil
+}
+
+// clientConfig returns the TLS configuration for the host connection to a plugin.
+func (c *TLSConfig) clientConfig() (*tls.Config, error) {
+	certificate, pool, err := c.load()
+	if err != nil {
+		return nil, err
+	}
+	serverName := c.ServerName
+	if serverName == "" {
+		serverName = defaultServerName
+	}
+	return &tls.Config{
+		Certificates: []tls.Certificate{certificate},
+		RootCAs:      pool,
+		ServerName:   serverName,
+		MinVersion:   tls.VersionTLS12,
+	}, nil
+}
+
+func (c *TLSConfig) load() (tls.Certificate, *x509.CertPool, error) {
+	if c.CertFile == "" || c.KeyFile == "" || c.CAFile == "" {
+		return tls.Certificate{}, nil, errors.New("tls requires a certificate, key and CA file")
+	}
+	certificate, err := tls.LoadX509KeyPair(c.CertFile, c.KeyFile)
+	if err != nil {
+		return tls.Certificate{}, nil, fmt.Errorf("failed to load tls certificate: %w", err)
+	}
+	caData, err := os.ReadFile(filepath.Clean(c.CAFile))
+	if err != nil {
+		return tls.Certificate{}, nil, fmt.Errorf("failed to read tls CA file: %w", err)
+	}
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(caData) {
+		return tls.Certificate{}, nil, fmt.Errorf("no certificates found in tls CA file %s", c.CAFile)
+	}
+	return certificate, pool, nil
+}
+
+// ServeReattach serves the plugins until the context is done for hosts to attach
+// to with a ReattachConfig. Unlike Register, the plugin keeps running when a host
+// disconnects, so state such as caches is kept between hosts.
+//
+// The plugin address is written to the reattach file, which is removed when the
+// plugin stops. Plugins listen on a Unix socket in the directory set in the
+// PLUGIN_UNIX_SOCKET_DIR environment variable or the default temporary directory.
+// If ServeConfig.TLS is set, hosts must present a certificate signed by the CA.
+//
+// Host services are served by the host on a Unix socket that the plugin connects to,
+// so hosts that provide services must set PLUGIN_UNIX_SOCKET_DIR to a directory that
+// is shared with the plugin. This also applies to plugins served on a TCP address.
+func ServeReattach(ctx context.Context, config ServeConfig, reattachFile string) error {
+	var tlsProvider func() (*tls.Config, error)
+	if config.TLS != nil {
+		tlsProvider = config.TLS.serverConfig
+	}
+	served, closeCh := serveDetached(ctx, &plugin.ServeConfig{
+		HandshakeConfig: Handshake,
+		Plugins:         config.PluginSet,
+		Logger:          config.Logger,
+		GRPCServer:      plugin.DefaultGRPCServer,
+		TLSProvider:     tlsProvider,
+	})
+	if served == nil {
+		return errors.New("plugin server stopped before listening, see the plugin logs for details")
+	}
+	data, err := json.Marshal(ReattachConfig{
+		Network: served.Network(),
+		Address: served.String(),
+	})
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(reattachFile, data, 0600); err != nil {
+		return fmt.Errorf("failed to write reattach file: %w", err)
+	}
+	<-closeCh
+	if err := os.Remove(reattachFile); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("failed to remove reattach file: %w", err)
+	}
+	return nil
+}
+
+// serveDetached serves the plugins until the context is done and returns the
+// plugin address, or nil if the server stopped before listening, and a channel
+// that is closed when the server stops.
+//
+// go-plugin only serves plugins that are not launched by a host in its test
+// mode (ServeTestConfig), which is documented for tests. It is used for
+// detached plugins with the following limits:
+//   - The handshake with the host process is skipped, so the plugin does not
+//     need to be launched by a host.
+//   - Plugin stdout and stderr are not forwarded to the host and stay on the
+//     stdio of the plugin process.
+//   - The server is stopped by closing the listener when the context is done,
+//     which aborts calls in progress.
+//
+// Hosts attach to these plugins with the detachedReattachConfig.
+func serveDetached(ctx context.Context, config *plugin.ServeConfig) (net.Addr, <-chan struct{}) {
+	reattachCh := make(chan *plugin.ReattachConfig, 1)
+	closeCh := make(chan struct{})
+	config.Test = &plugin.ServeTestConfig{
+		Context:          ctx,
+		ReattachConfigCh: reattachCh,
+		CloseCh:          closeCh,
+	}
+	go plugin.Serve(config)
+
+	select {
+	case served := <-reattachCh:
+		return served.Addr, closeCh
+	case <-closeCh:
+		return nil, closeCh
+	}
+}
+
+// detachedReattachConfig returns the go-plugin configuration to attach to a
+// plugin served with serveDetached.
+//
+// The go-plugin reattach configuration is marked as a test to match the serving
+// mode of the plugin. go-plugin then uses the protocol version of the configuration
+// instead of the version negotiated by a launched plugin, and does not stop the
+// plugin when the client is killed. Client.Kill only disconnects from these
+// plugins, and crashes are not detected because there is no plugin process.
+func detachedReattachConfig(addr net.Addr, detached *detachedRunner) *plugin.ReattachConfig {
+	return &plugin.ReattachConfig{
+		Protocol:        plugin.ProtocolGRPC,
+		ProtocolVersion: ProtocolVersion,
+		Addr:            addr,
+		Test:            true,
+		ReattachFunc: func() (runner.AttachedRunner, error) {
+			return detached, nil
+		},
+	}
+}
+
+// reattachClientConfig returns the go-plugin client configuration to attach to a running
+// plugin and the runner that detaches the client from the plugin when it is killed.
+func reattachClientConfig(reattach ReattachConfig) (*plugin.ClientConfig, *detachedRunner, error) {
+	resolved, err := reattach.Resolve()
+	if err != nil {
+		return nil, nil, err
+	}
+	var addr net.Addr
+	if resolved.Network == "unix" {
+		addr, err = net.ResolveUnixAddr("unix", resolved.Address)
+	} else {
+		addr, err = net.ResolveTCPAddr("tcp", resolved.Address)
+	}
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid plugin address %q: %w", resolved.Address, err)
+	}
+	detached := newDetachedRunner(addr.String())
+	config := &plugin.ClientConfig{
+		Reattach: detachedReattachConfig(addr, detached),
+	}
+	if resolved.TLS != nil {
+		if config.TLSConfig, err = resolved.TLS.clientConfig(); err != nil {
+			return nil, nil, err
+		}
+	}
+	return config, detached, nil
+}
+
+var _ runner.AttachedRunner = (*detachedRunner)(nil)
+
+// detachedRunner is a runner.AttachedRunner for a plugin that is not
+// launched by the host. The plugin is reported as exited once the host
+// detaches from the plugin.
+type detachedRunner struct {
+	id       string
+	once     sync.Once
+	detached chan struct{}
+}
+
+func newDetachedRunner(id string) *detachedRunner {
+	return &detachedRunner{id: id, detached: make(chan struct{})}
+}
+
+func (r *detachedRunner) Wait(ctx context.Context) error {
+	select {
+	case <-r.detached:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Kill detaches from the plugin without stopping it.
+func (r *detachedRunner) Kill(_ context.Context) error {
+	r.once.Do(func() { close(r.detached) })
+	return nil
+}
+
+func (r *detachedRunner) ID() string {
+	return r.id
+}
+
+func (r *detachedRunner) PluginToHost(pluginNet, pluginAddr string) (string, string, error) {
+	return pluginNet, pluginAddr, nil
+}
+
+func (r *detachedRunner) HostToPlugin(hostNet, hostAddr string) (string, string, error) {
+	return hostNet, hostAddr, nil
+}
@@ -0,0 +1,240 @@
+/*
+ Copyright 2025 The OSCAL Compass Authors
+ SPDX-License-Identifier: Apache-2.0
+*/
+
+package plugin
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/hashicorp/go-plugin"
+	"github.com/stretchr/testify/require"
+
+	"github.com/oscal-compass/compliance-to-policy-go/v2/policy"
+)
+
+func TestReattachConfig_Validate(t *testing.T) {
+	tlsConfig := &TLSConfig{CertFile: "cert.pem", KeyFile: "key.pem", CAFile: "ca.pem"}
+	tests := []struct {
+		name      string
+		reattach  ReattachConfig
+		wantError string
+	}{
+		{
+			name:     "Valid/Unix",
+			reattach: ReattachConfig{Address: "/run/c2p/plugin.sock"},
+		},
+		{
+			name:     "Valid/File",
+			reattach: ReattachConfig{File: "/run/c2p/plugin.json"},
+		},
+		{
+			name:     "Valid/TCP",
+			reattach: ReattachConfig{Network: "tcp", Address: "127.0.0.1:7777", TLS: tlsConfig},
+		},
+		{
+			name:      "Invalid/NoAddress",
+			reattach:  ReattachConfig{},
+			wantError: "exactly one of address or file must be set",
+		},
+		{
+			name:      "Invalid/AddressAndFile",
+			reattach:  ReattachConfig{Address: "/run/c2p/plugin.sock", File: "/run/c2p/plugin.json"},
+			wantError: "exactly one of address or file must be set",
+		},
+		{
+			name:      "Invalid/TCPWithoutTLS",
+			reattach:  ReattachConfig{Network: "tcp", Address: "localhost:7777"},
+			wantError: "tls is required for tcp address \"localhost:7777\"",
+		},
+		{
+			name:      "Invalid/RemoteTCP",
+			reattach:  ReattachConfig{Network: "tcp", Address: "10.0.0.1:7777", TLS: tlsConfig},
+			wantError: "tcp address \"10.0.0.1:7777\" must be a localhost address",
+		},
+		{
+			name:      "Invalid/Network",
+			reattach:  ReattachConfig{Network: "udp", Address: "localhost:7777"},
+			wantError: "unsupported network \"udp\": must be unix or tcp",
+		},
+		{
+			name:      "Invalid/TLS",
+			reattach:  ReattachConfig{Address: "/run/c2p/plugin.sock", TLS: &TLSConfig{CertFile: "cert.pem"}},
+			wantError: "tls requires a certificate, key and CA file",
+		},
+	}
+
+	for _, c := range tests {
+		t.Run(c.name, func(t *testing.T) {
+			err := c.reattach.Validate()
+			if c.wantError != "" {
+				require.EqualError(t, err, c.wantError)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestServeReattach(t *testing.T) {
+	serverTLS, clientTLS, untrustedTLS := testTLSConfigs(t)
+	tests := []struct {
+		name       string
+		serverTLS  *TLSConfig
+		clientTLS  *TLSConfig
+		wantAttach bool
+	}{
+		{
+			name:       "Success/Insecure",
+			wantAttach: true,
+		},
+		{
+			name:       "Success/MTLS",
+			serverTLS:  serverTLS,
+			clientTLS:  clientTLS,
+			wantAttach: true,
+		},
+		{
+			name:      "Failure/UntrustedClient",
+			serverTLS: serverTLS,
+			clientTLS: untrustedTLS,
+		},
+	}
+
+	for _, c := range tests {
+		t.Run(c.name, func(t *testing.T) {
+			provider := &countingProvider{}
+			reattachFile := filepath.Join(t.TempDir(), "plugin.json")
+			ctx, cancel := context.WithCancel(context.Background())
+			served := make(chan error, 1)
+			go func() {
+				served <- ServeReattach(ctx, ServeConfig{
+					PluginSet: map[string]plugin.Plugin{PVPPluginName: &PVPPlugin{Impl: provider}},
+					Logger:    hclog.NewNullLogger(),
+					TLS:       c.serverTLS,
+				}, reattachFile)
+			}()
+			require.Eventually(t, func() bool {
+				_, err := os.Stat(reattachFile)
+				return err == nil
+			}, 5*time.Second, 10*time.Millisecond)
+
+			manifest := Manifest{
+				Metadata: Metadata{ID: "attached"},
+				Reattach: &ReattachConfig{File: reattachFile, TLS: c.clientTLS},
+			}
+			clientFactory := ClientFactory(hclog.NewNullLogger())
+			// Each host connects and disconnects without stopping the plugin
+			for i := 1; i <= 2; i++ {
+				client, err := clientFactory(manifest)
+				require.NoError(t, err)
+				attached, err := NewPolicyPlugin(manifest, func(Manifest) (*Client, error) { return client, nil })
+				require.NoError(t, err)
+				result, err := attached.GetResults(context.TODO(), testPolicy)
+				if !c.wantAttach {
+					require.ErrorContains(t, err, "tls")
+					client.Kill()
+					require.Zero(t, provider.calls.Load())
+					break
+				}
+				require.NoError(t, err)
+				require.Equal(t, testPolicyPvpResult, result)
+				client.Kill()
+				require.Eventually(t, client.Exited, time.Second, 10*time.Millisecond)
+				require.Equal(t, int32(i), provider.calls.Load())
+			}
+
+			cancel()
+			require.NoError(t, <-served)
+			require.NoFileExists(t, reattachFile)
+		})
+	}
+}
+
+// countingProvider counts the GetResults calls over the
+// lifetime of the plugin.
+type countingProvider struct {
+	testProvider
+	calls atomic.Int32
+}
+
+func (p *countingProvider) GetResults(context.Context, policy.Policy) (policy.PVPResult, error) {
+	p.calls.Add(1)
+	return testPolicyPvpResult, nil
+}
+
+// testTLSConfigs returns the TLS configurations for a plugin and a host with
+// certificates signed by the same CA, and for a host with a certificate signed
+// by another CA.
+func testTLSConfigs(t *testing.T) (server, client, untrusted *TLSConfig) {
+	dir := t.TempDir()
+	caFile, caCert, caKey := writeTestCA(t, dir, "ca")
+	untrustedCAFile, untrustedCert, untrustedKey := writeTestCA(t, dir, "untrusted-ca")
+	server = writeTestCertificate(t, dir, "server", caFile, caCert, caKey)
+	client = writeTestCertificate(t, dir, "client", caFile, caCert, caKey)
+	untrusted = writeTestCertificate(t, dir, "untrusted", untrustedCAFile, untrustedCert, untrustedKey)
+	// The untrusted host still trusts the plugin certificate.
+	untrusted.CAFile = caFile
+	return server, client, untrusted
+}
+
+func writeTestCA(t *testing.T, dir, name string) (string, *x509.Certificate, *ecdsa.PrivateKey) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: name},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		KeyUsage:              x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+	path := filepath.Join(dir, name+".pem")
+	require.NoError(t, os.WriteFile(path, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0600))
+	return path, cert, key
+}
+
+func writeTestCertificate(t *testing.T, dir, name, caFile string, ca *x509.Certificate, caKey *ecdsa.PrivateKey) *TLSConfig {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(2),
+		Subject:      pkix.Name{CommonName: name},
+		DNSNames:     []string{"localhost"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, ca, &key.PublicKey, caKey)
+	require.NoError(t, err)
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+	config := &TLSConfig{
+		CertFile: filepath.Join(dir, name+"-cert.pem"),
+		KeyFile:  filepath.Join(dir, name+"-key.pem"),
+		CAFile:   caFile,
+	}
+	require.NoError(t, os.WriteFile(config.CertFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0600))
+	require.NoError(t, os.WriteFile(config.KeyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}), 0600))
+	return config
+}
@@ -0,0 +1,117 @@
+/*
+ Copyright 2025 The OSCAL Compass Authors
+ SPDX-License-Identifier: Apache-2.0
+*/
+
+package plugin
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/oscal-compass/compliance-to-policy-go/v2/policy"
+)
+
+// ProviderFactory creates a new policy.Provider for an in-process plugin.
+// Providers that implement io.Closer are closed when the plugins are stopped.
+type ProviderFactory func() (policy.Provider, error)
+
+// Registry contains plugins that run in the current process instead of
+// being launched as a plugin process. It is safe for concurrent use.
+type Registry struct {
+	mu      sync.RWMutex
+	plugins map[ID]registeredPlugin
+}
+
+type registeredPlugin struct {
+	manifest Manifest
+	factory  ProviderFactory
+}
+
+// NewRegistry returns an empty Registry.
+func NewRegistry() *Registry {
+	return &Registry{plugins: make(map[ID]registeredPlugin)}
+}
+
+// Register adds an in-process plugin with the ID in the manifest. The manifest
+// metadata and configuration options are used to find and configure the plugin
+// the same way as a discovered plugin. The executable path, checksum, call
+// policy and reattach configuration are not used. If no types are set, the plugin is registered as a
+// PVP plugin.
+func (r *Registry) Register(manifest Manifest, factory ProviderFactory) error {
+	if !manifest.ID.Validate() || manifest.ID.Instance() != "" {
+		return fmt.Errorf("invalid plugin id %q", manifest.ID)
+	}
+	if factory == nil {
+		return fmt.Errorf("provider factory for plugin %s cannot be nil", manifest.ID)
+	}
+	if len(manifest.Types) == 0 {
+		manifest.Types = []string{PVPPluginName}
+	}
+	manifest.ExecutablePath = ""
+	manifest.Checksum = ""
+	manifest.Reattach = nil
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, ok := r.plugins[manifest.ID]; ok {
+		return fmt.Errorf("plugin %s is already registered", manifest.ID)
+	}
+	r.plugins[manifest.ID] = registeredPlugin{manifest: manifest, factory: factory}
+	return nil
+}
+
+// Has returns true if the plugin for the base ID of the given ID is registered.
+func (r *Registry) Has(id ID) bool {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	_, ok := r.plugins[id.Base()]
+	return ok
+}
+
+// Find returns the manifests of the registered plugins, optionally applying
+// filters. See FindPlugins for the filters. Requested plugins that are not
+// registered are not returned.
+func (r *Registry) Find(opts ...FindOption) Manifests {
+	config := &findOptions{}
+	for _, opt := range opts {
+		opt(config)
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	ids := config.providerIds
+	if len(ids) == 0 {
+		for id := range r.plugins {
+			ids = append(ids, id)
+		}
+	}
+	manifests := make(Manifests)
+	for _, id := range ids {
+		registered, ok := r.plugins[id.Base()]
+		if !ok {
+			continue
+		}
+		if config.pluginType != "" && !manifestMatchesType(registered.manifest, config.pluginType) {
+			continue
+		}
+		manifests[id] = registered.manifest
+	}
+	return manifests
+}
+
+// NewProvider creates a new provider for the plugin with the base ID of
+// the given ID. A NotFoundError is returned if the plugin is not registered.
+func (r *Registry) NewProvider(id ID) (policy.Provider, error) {
+	r.mu.RLock()
+	registered, ok := r.plugins[id.Base()]
+	r.mu.RUnlock()
+	if !ok {
+		return nil, &NotFoundError{PluginID: id.String()}
+	}
+	provider, err := registered.factory()
+	if err != nil {
+		return nil, fmt.Errorf("failed to create in-process plugin %s: %w", id, err)
+	}
+	return provider, nil
+}
@@ -0,0 +1,44 @@
+/*
+ Copyright 2025 The OSCAL Compass Authors
+ SPDX-License-Identifier: Apache-2.0
+*/
+
+package plugin
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/oscal-compass/compliance-to-policy-go/v2/policy"
+)
+
+func TestRegistry(t *testing.T) {
+	registry := NewRegistry()
+	factory := func() (policy.Provider, error) {
+		return &testProvider{}, nil
+	}
+	require.NoError(t, registry.Register(Manifest{Metadata: Metadata{ID: "inproc"}}, factory))
+	require.NoError(t, registry.Register(Manifest{Metadata: Metadata{ID: "remediator", Types: []string{"remediation"}}}, factory))
+	require.EqualError(t, registry.Register(Manifest{Metadata: Metadata{ID: "inproc"}}, factory), "plugin inproc is already registered")
+	require.EqualError(t, registry.Register(Manifest{Metadata: Metadata{ID: "inproc@prod"}}, factory), "invalid plugin id \"inproc@prod\"")
+	require.EqualError(t, registry.Register(Manifest{Metadata: Metadata{ID: "other"}}, nil), "provider factory for plugin other cannot be nil")
+
+	require.True(t, registry.Has("inproc@prod"))
+	require.False(t, registry.Has("other"))
+
+	manifests := registry.Find(WithProviderIds([]ID{"inproc", "inproc@prod", "other"}), WithPluginType(PVPPluginName))
+	require.Len(t, manifests, 2)
+	require.Equal(t, []string{PVPPluginName}, manifests["inproc@prod"].Types)
+	require.Len(t, registry.Find(), 2)
+	require.Len(t, registry.Find(WithPluginType("remediation")), 1)
+
+	provider, err := registry.NewProvider("inproc@prod")
+	require.NoError(t, err)
+	require.IsType(t, &testProvider{}, provider)
+
+	_, err = registry.NewProvider("other")
+	var notFound *NotFoundError
+	require.True(t, errors.As(err, &notFound))
+}
@@ -0,0 +1,167 @@
+/*
+ Copyright 2025 The OSCAL Compass Authors
+ SPDX-License-Identifier: Apache-2.0
+*/
+
+package plugin
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	"github.com/oscal-compass/compliance-to-policy-go/v2/logging"
+)
+
+// SignatureSuffix is the suffix of the detached signature file
+// for a plugin manifest (e.g. c2p-myplugin-manifest.json.sig).
+const SignatureSuffix = ".sig"
+
+// Verifier verifies the detached signatures of plugin manifests
+// with trusted public keys.
+//
+// The signature file contains the base64 encoded signature of the manifest
+// file. Ed25519 signatures are over the manifest file. ECDSA signatures are
+// ASN.1 encoded and over the SHA256 digest of the manifest file. Because the
+// manifest contains the checksum of the plugin executable, the executable
+// is verified against the signed checksum.
+type Verifier struct {
+	keys              []crypto.PublicKey
+	requireSignatures bool
+}
+
+// VerifierOption configures a Verifier created with NewVerifier.
+type VerifierOption func(v *Verifier)
+
+// WithRequiredSignatures fails verification of plugins without
+// a signature. By default, unsigned plugins are allowed and logged.
+func WithRequiredSignatures() VerifierOption {
+	return func(v *Verifier) {
+		v.requireSignatures = true
+	}
+}
+
+// NewVerifier returns a Verifier for the given trusted Ed25519 or ECDSA public keys.
+func NewVerifier(keys []crypto.PublicKey, opts ...VerifierOption) (*Verifier, error) {
+	if len(keys) == 0 {
+		return nil, errors.New("at least one trusted key is required")
+	}
+	for _, key := range keys {
+		if err := checkKeyType(key); err != nil {
+			return nil, err
+		}
+	}
+	v := &Verifier{keys: keys}
+	for _, opt := range opts {
+		opt(v)
+	}
+	return v, nil
+}
+
+// LoadPublicKeys reads PEM encoded public keys from the given files.
+func LoadPublicKeys(paths ...string) ([]crypto.PublicKey, error) {
+	keys := make([]crypto.PublicKey, 0, len(paths))
+	for _, path := range paths {
+		data, err := os.ReadFile(filepath.Clean(path))
+		if err != nil {
+			return nil, fmt.Errorf("failed to read trusted key: %w", err)
+		}
+		key, err := ParsePublicKey(data)
+		if err != nil {
+			return nil, fmt.Errorf("invalid trusted key %s: %w", path, err)
+		}
+		keys = append(keys, key)
+	}
+	return keys, nil
+}
+
+// ParsePublicKey parses a PEM encoded PKIX Ed25519 or ECDSA public key.
+func ParsePublicKey(data []byte) (crypto.PublicKey, error) {
+	block, _ := pem.Decode(data)
+	if block == nil || block.Type != "PUBLIC KEY" {
+		return nil, errors.New("expected a PEM encoded public key")
+	}
+	key, err := x509.ParsePKIXPublicKey(block.Bytes)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkKeyType(key); err != nil {
+		return nil, err
+	}
+	return key, nil
+}
+
+func checkKeyType(key crypto.PublicKey) error {
+	switch key.(type) {
+	case ed25519.PublicKey, *ecdsa.PublicKey:
+		return nil
+	default:
+		return fmt.Errorf("unsupported key type %T: must be an Ed25519 or ECDSA public key", key)
+	}
+}
+
+// Verify verifies the manifest signature with the trusted keys and the checksum of
+// the plugin executable. The executable path of the manifest must be resolved with
+// Manifest.ResolvePath. The checksum is not verified for attached plugins.
+// Errors are returned as a VerificationError.
+func (v *Verifier) Verify(manifest Manifest, manifestData, signature []byte) error {
+	if !v.verifySignature(manifestData, signature) {
+		return &VerificationError{PluginID: manifest.ID.String(), Err: ErrSignatureMismatch}
+	}
+	if manifest.Reattach != nil {
+		return nil
+	}
+	if err := manifest.VerifyChecksum(); err != nil {
+		return &VerificationError{PluginID: manifest.ID.String(), Err: err}
+	}
+	return nil
+}
+
+// verifyFile verifies the manifest with the signature file next to the manifest file.
+func (v *Verifier) verifyFile(manifest Manifest, manifestPath string, manifestData []byte) error {
+	signaturePath := filepath.Clean(manifestPath + SignatureSuffix)
+	encoded, err := os.ReadFile(signaturePath)
+	if err != nil {
+		if !errors.Is(err, fs.ErrNotExist) {
+			return &VerificationError{PluginID: manifest.ID.String(), Err: err}
+		}
+		if v.requireSignatures {
+			return &VerificationError{PluginID: manifest.ID.String(), Err: ErrUnsigned}
+		}
+		logging.GetLogger("verifier").Warn(fmt.Sprintf("Plugin %s is not signed: %s not found", manifest.ID, signaturePath))
+		return nil
+	}
+	signature, err := base64.StdEncoding.DecodeString(string(bytes.TrimSpace(encoded)))
+	if err != nil {
+		return &VerificationError{PluginID: manifest.ID.String(), Err: fmt.Errorf("invalid signature file %s: %w", signaturePath, err)}
+	}
+	return v.Verify(manifest, manifestData, signature)
+}
+
+// verifySignature returns true if the signature matches a trusted key.
+func (v *Verifier) verifySignature(data, signature []byte) bool {
+	digest := sha256.Sum256(data)
+	for _, key := range v.keys {
+		switch k := key.(type) {
+		case ed25519.PublicKey:
+			if ed25519.Verify(k, data, signature) {
+				return true
+			}
+		case *ecdsa.PublicKey:
+			if ecdsa.VerifyASN1(k, digest[:], signature) {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,168 @@
+/*
+ Copyright 2025 The OSCAL Compass Authors
+ SPDX-License-Identifier: Apache-2.0
+*/
+
+package plugin
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestVerifier(t *testing.T) {
+	edPublic, edPrivate, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	ecPrivate, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	otherPublic, _, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	signEd25519 := func(data []byte) []byte {
+		return ed25519.Sign(edPrivate, data)
+	}
+	signECDSA := func(data []byte) []byte {
+		digest := sha256.Sum256(data)
+		signature, err := ecdsa.SignASN1(rand.Reader, ecPrivate, digest[:])
+		require.NoError(t, err)
+		return signature
+	}
+
+	tests := []struct {
+		name       string
+		keys       []crypto.PublicKey
+		opts       []VerifierOption
+		sign       func(data []byte) []byte
+		tamper     func(t *testing.T, pluginDir string)
+		wantError  error
+		wantPlugin bool
+	}{
+		{
+			name:       "Valid/Ed25519",
+			keys:       []crypto.PublicKey{otherPublic, edPublic},
+			sign:       signEd25519,
+			wantPlugin: true,
+		},
+		{
+			name:       "Valid/ECDSA",
+			keys:       []crypto.PublicKey{&ecPrivate.PublicKey},
+			sign:       signECDSA,
+			wantPlugin: true,
+		},
+		{
+			name:       "Valid/Unsigned",
+			keys:       []crypto.PublicKey{edPublic},
+			wantPlugin: true,
+		},
+		{
+			name:      "Invalid/UnsignedRequired",
+			keys:      []crypto.PublicKey{edPublic},
+			opts:      []VerifierOption{WithRequiredSignatures()},
+			wantError: ErrUnsigned,
+		},
+		{
+			name:      "Invalid/UntrustedKey",
+			keys:      []crypto.PublicKey{otherPublic},
+			sign:      signEd25519,
+			wantError: ErrSignatureMismatch,
+		},
+		{
+			name: "Invalid/ModifiedManifest",
+			keys: []crypto.PublicKey{edPublic},
+			sign: signEd25519,
+			tamper: func(t *testing.T, pluginDir string) {
+				manifestPath := filepath.Join(pluginDir, "c2p-testplugin-manifest.json")
+				manifest, err := os.ReadFile(manifestPath)
+				require.NoError(t, err)
+				require.NoError(t, os.WriteFile(manifestPath, append(manifest, ' '), 0600))
+			},
+			wantError: ErrSignatureMismatch,
+		},
+		{
+			name: "Invalid/ModifiedExecutable",
+			keys: []crypto.PublicKey{edPublic},
+			sign: signEd25519,
+			tamper: func(t *testing.T, pluginDir string) {
+				require.NoError(t, os.WriteFile(filepath.Join(pluginDir, "testplugin"), []byte("replaced"), 0700)) // #nosec G306
+			},
+			wantError: ErrChecksumMismatch,
+		},
+	}
+
+	for _, c := range tests {
+		t.Run(c.name, func(t *testing.T) {
+			pluginDir := t.TempDir()
+			executable := []byte("#!/bin/sh\n")
+			require.NoError(t, os.WriteFile(filepath.Join(pluginDir, "testplugin"), executable, 0700)) // #nosec G306
+			checksum := sha256.Sum256(executable)
+			manifest := fmt.Sprintf(`{"metadata": {"id": "testplugin", "version": "0.0.1", "types": ["pvp"]}, "executablePath": "testplugin", "sha256": %q}`, hex.EncodeToString(checksum[:]))
+			manifestPath := filepath.Join(pluginDir, "c2p-testplugin-manifest.json")
+			require.NoError(t, os.WriteFile(manifestPath, []byte(manifest), 0600))
+			if c.sign != nil {
+				signature := base64.StdEncoding.EncodeToString(c.sign([]byte(manifest)))
+				require.NoError(t, os.WriteFile(manifestPath+SignatureSuffix, []byte(signature+"\n"), 0600))
+			}
+			if c.tamper != nil {
+				c.tamper(t, pluginDir)
+			}
+
+			verifier, err := NewVerifier(c.keys, c.opts...)
+			require.NoError(t, err)
+			manifests, err := FindPlugins(pluginDir, pluginDir, WithVerifier(verifier))
+			if c.wantError != nil {
+				var verificationErr *VerificationError
+				require.True(t, errors.As(err, &verificationErr))
+				require.Equal(t, "testplugin", verificationErr.PluginID)
+				require.ErrorIs(t, err, c.wantError)
+				return
+			}
+			require.NoError(t, err)
+			require.Contains(t, manifests, ID("testplugin"))
+		})
+	}
+}
+
+func TestParsePublicKey(t *testing.T) {
+	edPublic, _, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	rsaPrivate, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	encode := func(key crypto.PublicKey) []byte {
+		der, err := x509.MarshalPKIXPublicKey(key)
+		require.NoError(t, err)
+		return pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der})
+	}
+
+	key, err := ParsePublicKey(encode(edPublic))
+	require.NoError(t, err)
+	require.Equal(t, edPublic, key)
+
+	_, err = ParsePublicKey(encode(&rsaPrivate.PublicKey))
+	require.EqualError(t, err, "unsupported key type *rsa.PublicKey: must be an Ed25519 or ECDSA public key")
+
+	_, err = ParsePublicKey([]byte("not a key"))
+	require.EqualError(t, err, "expected a PEM encoded public key")
+
+	keyPath := filepath.Join(t.TempDir(), "trusted.pem")
+	require.NoError(t, os.WriteFile(keyPath, encode(edPublic), 0600))
+	keys, err := LoadPublicKeys(keyPath)
+	require.NoError(t, err)
+	require.Equal(t, []crypto.PublicKey{edPublic}, keys)
+}
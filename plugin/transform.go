@@ -84,19 +84,43 @@ func NewPolicyFromProto(rules []*proto.Rule) policy.Policy {
 }
 
 var protoByResult = map[policy.Result]proto.Result{
-	policy.ResultPass:    proto.Result_RESULT_PASS,
-	policy.ResultInvalid: proto.Result_RESULT_UNSPECIFIED,
-	policy.ResultError:   proto.Result_RESULT_ERROR,
-	policy.ResultWarning: proto.Result_RESULT_WARNING,
-	policy.ResultFail:    proto.Result_RESULT_FAILURE,
+	policy.ResultPass:          proto.Result_RESULT_PASS,
+	policy.ResultInvalid:       proto.Result_RESULT_UNSPECIFIED,
+	policy.ResultError:         proto.Result_RESULT_ERROR,
+	policy.ResultWarning:       proto.Result_RESULT_WARNING,
+	policy.ResultFail:          proto.Result_RESULT_FAILURE,
+	policy.ResultNotApplicable: proto.Result_RESULT_NOT_APPLICABLE,
+	policy.ResultSkipped:       proto.Result_RESULT_SKIPPED,
+	policy.ResultManual:        proto.Result_RESULT_MANUAL,
 }
 
 var resultByProto = map[proto.Result]policy.Result{
-	proto.Result_RESULT_UNSPECIFIED: policy.ResultInvalid,
-	proto.Result_RESULT_ERROR:       policy.ResultError,
-	proto.Result_RESULT_WARNING:     policy.ResultWarning,
-	proto.Result_RESULT_PASS:        policy.ResultPass,
-	proto.Result_RESULT_FAILURE:     policy.ResultFail,
+	proto.Result_RESULT_UNSPECIFIED:    policy.ResultInvalid,
+	proto.Result_RESULT_ERROR:          policy.ResultError,
+	proto.Result_RESULT_WARNING:        policy.ResultWarning,
+	proto.Result_RESULT_PASS:           policy.ResultPass,
+	proto.Result_RESULT_FAILURE:        policy.ResultFail,
+	proto.Result_RESULT_NOT_APPLICABLE: policy.ResultNotApplicable,
+	proto.Result_RESULT_SKIPPED:        policy.ResultSkipped,
+	proto.Result_RESULT_MANUAL:         policy.ResultManual,
+}
+
+var protoBySeverity = map[policy.Severity]proto.Severity{
+	policy.SeverityUnspecified: proto.Severity_SEVERITY_UNSPECIFIED,
+	policy.SeverityInfo:        proto.Severity_SEVERITY_INFO,
+	policy.SeverityLow:         proto.Severity_SEVERITY_LOW,
+	policy.SeverityMedium:      proto.Severity_SEVERITY_MEDIUM,
+	policy.SeverityHigh:        proto.Severity_SEVERITY_HIGH,
+	policy.SeverityCritical:    proto.Severity_SEVERITY_CRITICAL,
+}
+
+var severityByProto = map[proto.Severity]policy.Severity{
+	proto.Severity_SEVERITY_UNSPECIFIED: policy.SeverityUnspecified,
+	proto.Severity_SEVERITY_INFO:        policy.SeverityInfo,
+	proto.Severity_SEVERITY_LOW:         policy.SeverityLow,
+	proto.Severity_SEVERITY_MEDIUM:      policy.SeverityMedium,
+	proto.Severity_SEVERITY_HIGH:        policy.SeverityHigh,
+	proto.Severity_SEVERITY_CRITICAL:    policy.SeverityCritical,
 }
 
 func NewResultFromProto(pb *proto.PVPResult) policy.PVPResult {
@@ -109,6 +133,8 @@ func NewResultFromProto(pb *proto.PVPResult) policy.PVPResult {
 			Methods:     o.Methods,
 			Collected:   o.CollectedAt.AsTime(),
 			CheckID:     o.CheckId,
+			Severity:    severityByProto[o.Severity],
+			Remediation: o.Remediation,
 		}
 		var links []policy.Link
 		for _, ref := range o.EvidenceRefs {
@@ -126,6 +152,8 @@ func NewResultFromProto(pb *proto.PVPResult) policy.PVPResult {
 				Result:      resultByProto[s.Result],
 				EvaluatedOn: s.EvaluatedOn.AsTime(),
 				Reason:      s.Reason,
+				Severity:    severityByProto[s.Severity],
+				Remediation: s.Remediation,
 			}
 			var subjectProps []policy.Property
 			for _, sp := range s.Props {
@@ -143,6 +171,17 @@ func NewResultFromProto(pb *proto.PVPResult) policy.PVPResult {
 			props = append(props, prop)
 		}
 		observation.Props = props
+
+		var evidence []policy.Evidence
+		for _, e := range o.Evidence {
+			evidence = append(evidence, policy.Evidence{
+				Name:        e.Name,
+				Description: e.Description,
+				MediaType:   e.MediaType,
+				Content:     e.Content,
+			})
+		}
+		observation.Evidence = evidence
 		result.ObservationsByCheck = append(result.ObservationsByCheck, observation)
 	}
 
@@ -163,6 +202,8 @@ func ResultsToProto(result policy.PVPResult) *proto.PVPResult {
 			CheckId:     o.CheckID,
 			Methods:     o.Methods,
 			CollectedAt: timestamppb.New(o.Collected),
+			Severity:    protoBySeverity[o.Severity],
+			Remediation: o.Remediation,
 		}
 		var subjects []*proto.Subject
 		for _, s := range o.Subjects {
@@ -173,6 +214,8 @@ func ResultsToProto(result policy.PVPResult) *proto.PVPResult {
 				Result:      protoByResult[s.Result],
 				EvaluatedOn: timestamppb.New(s.EvaluatedOn),
 				Reason:      s.Reason,
+				Severity:    protoBySeverity[s.Severity],
+				Remediation: s.Remediation,
 			}
 			var subjectProps []*proto.Property
 			for _, sp := range s.Props {
@@ -195,6 +238,14 @@ func ResultsToProto(result policy.PVPResult) *proto.PVPResult {
 		observation.EvidenceRefs = evidences
 		observation.Subjects = subjects
 		observation.Props = props
+		for _, e := range o.Evidence {
+			observation.Evidence = append(observation.Evidence, &proto.Evidence{
+				Name:        e.Name,
+				Description: e.Description,
+				MediaType:   e.MediaType,
+				Content:     e.Content,
+			})
+		}
 		pvpResult.Observations = append(pvpResult.Observations, observation)
 	}
 
@@ -208,3 +259,194 @@ func ResultsToProto(result policy.PVPResult) *proto.PVPResult {
 	}
 	return pvpResult
 }
+
+// ProviderInfoToProto transforms a plugin ProviderInfo to a protobuf DescribeResponse.
+func ProviderInfoToProto(info policy.ProviderInfo) *proto.DescribeResponse {
+	var features []string
+	for _, f := range info.Features {
+		features = append(features, string(f))
+	}
+	return &proto.DescribeResponse{
+		Version:              info.Version,
+		SupportedChecks:      info.SupportedChecks,
+		SupportedParameters:  info.SupportedParameters,
+		Features:             features,
+		Types:                info.Types,
+		ConfigurationOptions: info.ConfigurationOptions,
+	}
+}
+
+// NewProviderInfoFromProto transforms a protobuf DescribeResponse into a plugin ProviderInfo.
+func NewProviderInfoFromProto(pb *proto.DescribeResponse) policy.ProviderInfo {
+	var features []policy.Feature
+	for _, f := range pb.Features {
+		features = append(features, policy.Feature(f))
+	}
+	return policy.ProviderInfo{
+		Version:              pb.Version,
+		SupportedChecks:      pb.SupportedChecks,
+		SupportedParameters:  pb.SupportedParameters,
+		Features:             features,
+		Types:                pb.Types,
+		ConfigurationOptions: pb.ConfigurationOptions,
+	}
+}
+
+// ArtifactsToProto transforms plugin Artifacts to protobuf Artifacts.
+func ArtifactsToProto(artifacts []policy.Artifact) []*proto.Artifact {
+	var pbArtifacts []*proto.Artifact
+	for _, a := range artifacts {
+		pbArtifact := &proto.Artifact{
+			Path:      a.Path,
+			MediaType: a.MediaType,
+			Sha256:    a.Checksum,
+			RuleIds:   a.RuleIDs,
+			CheckIds:  a.CheckIDs,
+			Content:   a.Content,
+		}
+		pbArtifacts = append(pbArtifacts, pbArtifact)
+	}
+	return pbArtifacts
+}
+
+// NewArtifactsFromProto transforms protobuf Artifacts into plugin Artifacts.
+func NewArtifactsFromProto(pb []*proto.Artifact) []policy.Artifact {
+	var artifacts []policy.Artifact
+	for _, a := range pb {
+		artifact := policy.Artifact{
+			Path:      a.Path,
+			MediaType: a.MediaType,
+			Checksum:  a.Sha256,
+			RuleIDs:   a.RuleIds,
+			CheckIDs:  a.CheckIds,
+			Content:   a.Content,
+		}
+		artifacts = append(artifacts, artifact)
+	}
+	return artifacts
+}
+
+var protoByDiagnosticType = map[policy.DiagnosticType]proto.DiagnosticType{
+	policy.DiagnosticInvalid:                   proto.DiagnosticType_DIAGNOSTIC_TYPE_UNSPECIFIED,
+	policy.DiagnosticUnknownCheck:              proto.DiagnosticType_DIAGNOSTIC_TYPE_UNKNOWN_CHECK,
+	policy.DiagnosticMissingParameter:          proto.DiagnosticType_DIAGNOSTIC_TYPE_MISSING_PARAMETER,
+	policy.DiagnosticUnsupportedParameterValue: proto.DiagnosticType_DIAGNOSTIC_TYPE_UNSUPPORTED_PARAMETER_VALUE,
+}
+
+var diagnosticTypeByProto = map[proto.DiagnosticType]policy.DiagnosticType{
+	proto.DiagnosticType_DIAGNOSTIC_TYPE_UNSPECIFIED:                 policy.DiagnosticInvalid,
+	proto.DiagnosticType_DIAGNOSTIC_TYPE_UNKNOWN_CHECK:               policy.DiagnosticUnknownCheck,
+	proto.DiagnosticType_DIAGNOSTIC_TYPE_MISSING_PARAMETER:           policy.DiagnosticMissingParameter,
+	proto.DiagnosticType_DIAGNOSTIC_TYPE_UNSUPPORTED_PARAMETER_VALUE: policy.DiagnosticUnsupportedParameterValue,
+}
+
+// DiagnosticsToProto transforms plugin Diagnostics to protobuf Diagnostics.
+func DiagnosticsToProto(diagnostics []policy.Diagnostic) []*proto.Diagnostic {
+	var pbDiagnostics []*proto.Diagnostic
+	for _, d := range diagnostics {
+		pbDiagnostic := &proto.Diagnostic{
+			RuleId:    d.RuleID,
+			CheckId:   d.CheckID,
+			Parameter: d.Parameter,
+			Type:      protoByDiagnosticType[d.Type],
+			Message:   d.Message,
+		}
+		pbDiagnostics = append(pbDiagnostics, pbDiagnostic)
+	}
+	return pbDiagnostics
+}
+
+// NewDiagnosticsFromProto transforms protobuf Diagnostics into plugin Diagnostics.
+func NewDiagnosticsFromProto(pb []*proto.Diagnostic) []policy.Diagnostic {
+	var diagnostics []policy.Diagnostic
+	for _, d := range pb {
+		diagnostic := policy.Diagnostic{
+			RuleID:    d.RuleId,
+			CheckID:   d.CheckId,
+			Parameter: d.Parameter,
+			Type:      diagnosticTypeByProto[d.Type],
+			Message:   d.Message,
+		}
+		diagnostics = append(diagnostics, diagnostic)
+	}
+	return diagnostics
+}
+
+// ApplyMetadataToProto sets the Metadata on the protobuf Rules
+// with a matching rule ID.
+func ApplyMetadataToProto(rules []*proto.Rule, metadata policy.Metadata) {
+	if len(metadata) == 0 {
+		return
+	}
+	for _, r := range rules {
+		ruleMetadata, ok := metadata[r.Name]
+		if !ok {
+			continue
+		}
+		r.Props = propertiesToProto(ruleMetadata.Props)
+		for _, ch := range r.Checks {
+			ch.Props = propertiesToProto(ruleMetadata.CheckProps[ch.Name])
+		}
+		for _, prm := range r.Parameters {
+			parameterMetadata := ruleMetadata.Parameters[prm.Name]
+			prm.Alternatives = parameterMetadata.Alternatives
+			prm.SelectedValues = parameterMetadata.Values
+		}
+	}
+}
+
+// NewMetadataFromProto returns the Metadata of the protobuf Rules
+// or nil if the Rules have no metadata.
+func NewMetadataFromProto(rules []*proto.Rule) policy.Metadata {
+	metadata := make(policy.Metadata)
+	for _, r := range rules {
+		ruleMetadata := policy.RuleMetadata{
+			Props: newPropertiesFromProto(r.Props),
+		}
+		for _, ch := range r.Checks {
+			if len(ch.Props) == 0 {
+				continue
+			}
+			if ruleMetadata.CheckProps == nil {
+				ruleMetadata.CheckProps = make(map[string][]policy.Property)
+			}
+			ruleMetadata.CheckProps[ch.Name] = newPropertiesFromProto(ch.Props)
+		}
+		for _, prm := range r.Parameters {
+			if len(prm.Alternatives) == 0 && len(prm.SelectedValues) == 0 {
+				continue
+			}
+			if ruleMetadata.Parameters == nil {
+				ruleMetadata.Parameters = make(map[string]policy.ParameterMetadata)
+			}
+			ruleMetadata.Parameters[prm.Name] = policy.ParameterMetadata{
+				Alternatives: prm.Alternatives,
+				Values:       prm.SelectedValues,
+			}
+		}
+		if ruleMetadata.Props == nil && ruleMetadata.CheckProps == nil && ruleMetadata.Parameters == nil {
+			continue
+		}
+		metadata[r.Name] = ruleMetadata
+	}
+	if len(metadata) == 0 {
+		return nil
+	}
+	return metadata
+}
+
+func propertiesToProto(props []policy.Property) []*proto.Property {
+	var pbProps []*proto.Property
+	for _, p := range props {
+		pbProps = append(pbProps, &proto.Property{Name: p.Name, Value: p.Value})
+	}
+	return pbProps
+}
+
+func newPropertiesFromProto(pb []*proto.Property) []policy.Property {
+	var props []policy.Property
+	for _, p := range pb {
+		props = append(props, policy.Property{Name: p.Name, Value: p.Value})
+	}
+	return props
+}
@@ -6,6 +6,7 @@
 package plugin
 
 import (
+	"strings"
 	"testing"
 	"time"
 
@@ -69,6 +70,8 @@ var testProtoPvpResult = &proto.PVPResult{
 			CheckId:     "test-check-1",
 			Methods:     []string{"method-1", "method-2"},
 			CollectedAt: timestamppb.New(testTimeString),
+			Severity:    proto.Severity_SEVERITY_HIGH,
+			Remediation: "test remediation",
 			Subjects: []*proto.Subject{
 				{
 					Title:       "test-subject-1",
@@ -77,6 +80,8 @@ var testProtoPvpResult = &proto.PVPResult{
 					Result:      proto.Result_RESULT_PASS,
 					EvaluatedOn: timestamppb.New(testTimeString),
 					Reason:      "test reason",
+					Severity:    proto.Severity_SEVERITY_LOW,
+					Remediation: "test subject remediation",
 					Props: []*proto.Property{
 						{
 							Name:  "test-subject-prop-1",
@@ -97,6 +102,14 @@ var testProtoPvpResult = &proto.PVPResult{
 					Value: "test value 1",
 				},
 			},
+			Evidence: []*proto.Evidence{
+				{
+					Name:        "test-evidence-1.yaml",
+					Description: "test evidence 1",
+					MediaType:   "application/yaml",
+					Content:     []byte("result: pass"),
+				},
+			},
 		},
 	},
 	Links: []*proto.Link{
@@ -114,6 +127,8 @@ var testPolicyPvpResult = policy.PVPResult{
 			Description: "test obs 1",
 			CheckID:     "test-check-1",
 			Methods:     []string{"method-1", "method-2"},
+			Severity:    policy.SeverityHigh,
+			Remediation: "test remediation",
 			Subjects: []policy.Subject{
 				{
 					Title:       "test-subject-1",
@@ -122,6 +137,8 @@ var testPolicyPvpResult = policy.PVPResult{
 					Result:      policy.ResultPass,
 					EvaluatedOn: timestamppb.New(testTimeString).AsTime(),
 					Reason:      "test reason",
+					Severity:    policy.SeverityLow,
+					Remediation: "test subject remediation",
 					Props: []policy.Property{
 						{
 							Name:  "test-subject-prop-1",
@@ -143,6 +160,14 @@ var testPolicyPvpResult = policy.PVPResult{
 					Value: "test value 1",
 				},
 			},
+			Evidence: []policy.Evidence{
+				{
+					Name:        "test-evidence-1.yaml",
+					Description: "test evidence 1",
+					MediaType:   "application/yaml",
+					Content:     []byte("result: pass"),
+				},
+			},
 		},
 	},
 	Links: []policy.Link{
@@ -172,3 +197,87 @@ func TestNewResultFromProto(t *testing.T) {
 	output := NewResultFromProto(testProtoPvpResult)
 	require.Equal(t, testPolicyPvpResult, output)
 }
+
+func TestResultRoundTrip(t *testing.T) {
+	for result := policy.ResultInvalid; result <= policy.ResultManual; result++ {
+		pb, ok := protoByResult[result]
+		require.True(t, ok, "result %s has no proto value", result)
+		require.Equal(t, result, resultByProto[pb])
+	}
+}
+
+func TestSeverityRoundTrip(t *testing.T) {
+	for severity := policy.SeverityUnspecified; severity <= policy.SeverityCritical; severity++ {
+		pb, ok := protoBySeverity[severity]
+		require.True(t, ok, "severity %s has no proto value", severity)
+		require.Equal(t, severity, severityByProto[pb])
+		require.Equal(t, severity, policy.ParseSeverity(strings.ToUpper(severity.String())))
+	}
+}
+
+func TestProviderInfoRoundTrip(t *testing.T) {
+	info := policy.ProviderInfo{
+		Version:              "0.0.1",
+		SupportedChecks:      []string{"test-check-1"},
+		SupportedParameters:  []string{"test-param-1"},
+		Features:             []policy.Feature{policy.FeatureGenerate, policy.FeatureStreaming},
+		Types:                []string{"pvp"},
+		ConfigurationOptions: []string{"policy-dir"},
+	}
+	pb := ProviderInfoToProto(info)
+	require.Equal(t, []string{"generate", "streaming"}, pb.Features)
+	require.Equal(t, info, NewProviderInfoFromProto(pb))
+}
+
+func TestArtifactsRoundTrip(t *testing.T) {
+	artifact := policy.NewArtifact("test-rule-1/policy.yaml", "application/yaml", []byte("test"))
+	artifact.RuleIDs = []string{"test-rule-1"}
+	artifact.CheckIDs = []string{"test-check-1"}
+	artifacts := []policy.Artifact{artifact}
+
+	pb := ArtifactsToProto(artifacts)
+	require.Len(t, pb, 1)
+	require.Equal(t, "9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08", pb[0].Sha256)
+	require.Equal(t, artifacts, NewArtifactsFromProto(pb))
+}
+
+func TestDiagnosticsRoundTrip(t *testing.T) {
+	diagnostics := []policy.Diagnostic{
+		{RuleID: "test-rule-1", CheckID: "test-check-1", Type: policy.DiagnosticUnknownCheck, Message: "unknown check"},
+		{RuleID: "test-rule-1", Parameter: "test-param-1", Type: policy.DiagnosticMissingParameter, Message: "missing parameter"},
+	}
+	pb := DiagnosticsToProto(diagnostics)
+	require.Len(t, pb, 2)
+	require.Equal(t, proto.DiagnosticType_DIAGNOSTIC_TYPE_UNKNOWN_CHECK, pb[0].Type)
+	require.Equal(t, diagnostics, NewDiagnosticsFromProto(pb))
+}
+
+var testPolicyMetadata = policy.Metadata{
+	"test-rule-1": {
+		Props: []policy.Property{{Name: "Rule_Severity", Value: "high"}},
+		CheckProps: map[string][]policy.Property{
+			"test-check-1": {{Name: "Remediation", Value: "Set the value"}},
+		},
+		Parameters: map[string]policy.ParameterMetadata{
+			"test-param-1": {
+				Alternatives: []string{"test param value", "other value"},
+				Values:       []string{"test param value", "other value"},
+			},
+		},
+	},
+}
+
+func TestMetadataRoundTrip(t *testing.T) {
+	rules := PolicyToProto(testPolicy)
+	require.Nil(t, NewMetadataFromProto(rules))
+
+	ApplyMetadataToProto(rules, testPolicyMetadata)
+	require.Equal(t, []string{"other value"}, rules[0].Parameters[0].Alternatives[1:])
+	require.Equal(t, testPolicyMetadata, NewMetadataFromProto(rules))
+	require.Equal(t, testPolicy, NewPolicyFromProto(rules))
+
+	// Metadata for other rules is ignored
+	rules = PolicyToProto(testPolicy)
+	ApplyMetadataToProto(rules, policy.Metadata{"test-rule-2": testPolicyMetadata["test-rule-1"]})
+	require.Nil(t, NewMetadataFromProto(rules))
+}
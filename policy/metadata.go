@@ -0,0 +1,56 @@
+/*
+ Copyright 2025 The OSCAL Compass Authors
+ SPDX-License-Identifier: Apache-2.0
+*/
+
+package policy
+
+import "context"
+
+// Metadata maps rule IDs to the RuleMetadata of the rules in a Policy.
+//
+// The extensions.RuleSet in a Policy only holds the IDs, descriptions and
+// single selected values. The remaining OSCAL metadata for the rules, checks
+// and parameters of the Policy is sent to plugins in the request messages of
+// each call and passed on to Providers with the context of the call, so it can
+// be read by Providers with MetadataFromContext.
+type Metadata map[string]RuleMetadata
+
+// RuleMetadata is the OSCAL metadata for a single rule that is
+// not part of extensions.RuleSet.
+type RuleMetadata struct {
+	// Props are the additional properties of the rule.
+	Props []Property
+	// CheckProps are the additional properties of each check
+	// by check ID.
+	CheckProps map[string][]Property
+	// Parameters are the metadata of each parameter by
+	// parameter ID.
+	Parameters map[string]ParameterMetadata
+}
+
+// ParameterMetadata is the metadata for a single rule parameter.
+type ParameterMetadata struct {
+	// Alternatives are the allowed values for the parameter.
+	Alternatives []string
+	// Values are the selected values for the parameter. For single
+	// value parameters, it holds the selected value of the
+	// extensions.Parameter.
+	Values []string
+}
+
+type metadataKey struct{}
+
+// WithMetadata returns a copy of the context with the Metadata for the
+// Policy of a Provider call. It is used by plugin servers and hosts to
+// pass the Metadata of a request to a Provider.
+func WithMetadata(ctx context.Context, metadata Metadata) context.Context {
+	return context.WithValue(ctx, metadataKey{}, metadata)
+}
+
+// MetadataFromContext returns the Metadata for the Policy of a Provider
+// call or nil if no metadata was sent.
+func MetadataFromContext(ctx context.Context) Metadata {
+	metadata, _ := ctx.Value(metadataKey{}).(Metadata)
+	return metadata
+}
@@ -0,0 +1,19 @@
+/*
+ Copyright 2025 The OSCAL Compass Authors
+ SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package policytest provides a conformance suite for policy.Provider
+// implementations. Plugin authors can run the suite in their tests
+// to check results are compatible with the C2P framework:
+//
+//	func TestConformance(t *testing.T) {
+//		policytest.Run(t, policytest.Config{
+//			NewProvider: func(t *testing.T) policy.Provider {
+//				return server.NewPlugin()
+//			},
+//			Configuration: map[string]string{"policy-dir": "testdata/policies"},
+//			Policy:        testPolicy,
+//		})
+//	}
+package policytest
@@ -0,0 +1,42 @@
+/*
+ Copyright 2025 The OSCAL Compass Authors
+ SPDX-License-Identifier: Apache-2.0
+*/
+
+package policytest
+
+import (
+	"context"
+	"sync"
+
+	"github.com/oscal-compass/compliance-to-policy-go/v2/policy"
+)
+
+var _ policy.Host = (*RecordingHost)(nil)
+
+// RecordingHost is a policy.Host that records the evidence and progress
+// events from a provider. Evidence is linked as "evidence/<name>" and
+// paths are returned unchanged.
+type RecordingHost struct {
+	mu       sync.Mutex
+	Evidence []policy.Evidence
+	Progress []policy.ProgressEvent
+}
+
+func (h *RecordingHost) StoreEvidence(_ context.Context, evidence policy.Evidence) (policy.Link, error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.Evidence = append(h.Evidence, evidence)
+	return policy.Link{Description: evidence.Description, Href: "evidence/" + evidence.Name}, nil
+}
+
+func (h *RecordingHost) ReportProgress(_ context.Context, event policy.ProgressEvent) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.Progress = append(h.Progress, event)
+	return nil
+}
+
+func (h *RecordingHost) ResolvePath(_ context.Context, path string) (string, error) {
+	return path, nil
+}
@@ -0,0 +1,283 @@
+/*
+ Copyright 2025 The OSCAL Compass Authors
+ SPDX-License-Identifier: Apache-2.0
+*/
+
+package policytest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"sync"
+	"testing"
+
+	hplugin "github.com/hashicorp/go-plugin"
+	"github.com/stretchr/testify/require"
+
+	"github.com/oscal-compass/compliance-to-policy-go/v2/framework/actions"
+	"github.com/oscal-compass/compliance-to-policy-go/v2/plugin"
+	"github.com/oscal-compass/compliance-to-policy-go/v2/policy"
+)
+
+// Config configures the conformance suite for a policy.Provider.
+type Config struct {
+	// NewProvider returns a new provider for each test.
+	NewProvider func(t *testing.T) policy.Provider
+	// Configuration is applied to the provider with Configure
+	// before each test.
+	Configuration map[string]string
+	// Policy is passed to Generate and GetResults. Results may only
+	// reference the checks in the Policy.
+	Policy policy.Policy
+	// SkipGenerate skips the Generate tests for providers that
+	// do not generate policy artifacts.
+	SkipGenerate bool
+}
+
+// Run runs the conformance suite against the provider in-process and
+// over gRPC with the plugin.PVPPlugin.
+//
+// The suite checks that:
+//   - Results only reference the check IDs in the Policy
+//   - Observation and subject timestamps are set
+//   - Subject types and results are valid for the actions.Report
+//   - Calls cancelled during a progress event return an error
+//   - Results are not changed by the protobuf transformation
+//   - Validate returns no diagnostics for the Policy
+func Run(t *testing.T, config Config) {
+	require.NotNil(t, config.NewProvider, "NewProvider must be set")
+
+	t.Run("InProcess", func(t *testing.T) {
+		runSuite(t, config, config.NewProvider(t), true)
+	})
+	t.Run("GRPC", func(t *testing.T) {
+		runSuite(t, config, Dispense(t, config.NewProvider(t)), false)
+	})
+}
+
+func runSuite(t *testing.T, config Config, provider policy.Provider, inProcess bool) {
+	host := &testHost{}
+	consumer, usesHost := provider.(policy.HostConsumer)
+	if usesHost {
+		consumer.SetHost(host)
+	}
+	require.NoError(t, provider.Configure(context.Background(), config.Configuration))
+
+	if validator, ok := provider.(policy.Validator); ok {
+		t.Run("Validate", func(t *testing.T) {
+			diagnostics, err := validator.Validate(context.Background(), config.Policy)
+			if !errors.Is(err, plugin.ErrNotImplemented) {
+				require.NoError(t, err)
+				require.Empty(t, diagnostics, "Validate must not return diagnostics for the Policy")
+			}
+		})
+	}
+
+	if !config.SkipGenerate {
+		t.Run("Generate", func(t *testing.T) {
+			_, err := provider.Generate(context.Background(), config.Policy)
+			require.NoError(t, err)
+		})
+	}
+
+	t.Run("GetResults", func(t *testing.T) {
+		result, err := provider.GetResults(context.Background(), config.Policy)
+		require.NoError(t, err)
+		require.NoError(t, ValidateResult(config.Policy, result))
+		if inProcess {
+			require.NoError(t, ValidateRoundTrip(result))
+		}
+	})
+
+	if streamer, ok := provider.(policy.ResultStreamer); ok {
+		t.Run("StreamResults", func(t *testing.T) {
+			err := streamer.StreamResults(context.Background(), config.Policy, func(result policy.PVPResult) error {
+				if err := ValidateResult(config.Policy, result); err != nil {
+					return err
+				}
+				if inProcess {
+					return ValidateRoundTrip(result)
+				}
+				return nil
+			})
+			if !errors.Is(err, plugin.ErrNotImplemented) {
+				require.NoError(t, err)
+			}
+		})
+	}
+
+	// Calls are cancelled by the host while the provider reports progress,
+	// so the provider must stop the call after the context is cancelled.
+	t.Run("Cancellation", func(t *testing.T) {
+		if !usesHost {
+			t.Skip("provider does not use the host to report progress")
+		}
+		t.Run("GetResults", func(t *testing.T) {
+			ctx := host.cancelOnProgress(t)
+			_, err := provider.GetResults(ctx, config.Policy)
+			host.requireCancelled(t, err, "GetResults")
+		})
+		if !config.SkipGenerate {
+			t.Run("Generate", func(t *testing.T) {
+				ctx := host.cancelOnProgress(t)
+				_, err := provider.Generate(ctx, config.Policy)
+				host.requireCancelled(t, err, "Generate")
+			})
+		}
+	})
+}
+
+// testHost is the policy.Host of the providers in the suite. Relative paths are
+// resolved against the working directory and evidence is kept in memory.
+type testHost struct {
+	mu       sync.Mutex
+	cancel   context.CancelFunc
+	progress int
+}
+
+// cancelOnProgress returns a context that is cancelled by the
+// next progress event.
+func (h *testHost) cancelOnProgress(t *testing.T) context.Context {
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.cancel = cancel
+	h.progress = 0
+	return ctx
+}
+
+// requireCancelled skips the test if the call did not report progress
+// and otherwise requires the call to fail.
+func (h *testHost) requireCancelled(t *testing.T, err error, method string) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.cancel = nil
+	if h.progress == 0 {
+		t.Skipf("%s did not report progress", method)
+	}
+	require.Error(t, err, "%s must return an error when cancelled during the call", method)
+}
+
+func (h *testHost) StoreEvidence(_ context.Context, evidence policy.Evidence) (policy.Link, error) {
+	return policy.Link{Description: evidence.Description, Href: evidence.Name}, nil
+}
+
+// ReportProgress cancels the call if requested and returns the
+// context error like a host that stopped waiting for the provider.
+func (h *testHost) ReportProgress(ctx context.Context, _ policy.ProgressEvent) error {
+	h.mu.Lock()
+	h.progress++
+	if h.cancel != nil {
+		h.cancel()
+	}
+	h.mu.Unlock()
+	return ctx.Err()
+}
+
+func (h *testHost) ResolvePath(_ context.Context, path string) (string, error) {
+	return filepath.Abs(path)
+}
+
+// Dispense returns a client for the provider served over gRPC
+// with the plugin.PVPPlugin in the test process.
+func Dispense(t testing.TB, provider policy.Provider) policy.Provider {
+	client, _ := hplugin.TestPluginGRPCConn(t, false, map[string]hplugin.Plugin{
+		plugin.PVPPluginName: &plugin.PVPPlugin{Impl: provider},
+	})
+	// Closing the client also stops the server.
+	t.Cleanup(func() {
+		_ = client.Close()
+	})
+	raw, err := client.Dispense(plugin.PVPPluginName)
+	require.NoError(t, err)
+	dispensed, ok := raw.(policy.Provider)
+	require.True(t, ok)
+	return dispensed
+}
+
+// validSubjectTypes are the subject types accepted by actions.Report.
+var validSubjectTypes = []string{actions.InventoryItem, actions.Resource}
+
+// ValidateResult returns an error for each part of the PVPResult
+// that cannot be reported for the Policy.
+func ValidateResult(pl policy.Policy, result policy.PVPResult) error {
+	checkIDs := make(map[string]struct{})
+	for _, ruleSet := range pl {
+		for _, check := range ruleSet.Checks {
+			checkIDs[check.ID] = struct{}{}
+		}
+	}
+
+	var errs []error
+	for _, observation := range result.ObservationsByCheck {
+		if _, ok := checkIDs[observation.CheckID]; !ok {
+			errs = append(errs, fmt.Errorf("observation %q references check %q that is not in the policy", observation.Title, observation.CheckID))
+		}
+		if observation.Severity > policy.SeverityCritical {
+			errs = append(errs, fmt.Errorf("observation %q for check %q has invalid severity %d", observation.Title, observation.CheckID, observation.Severity))
+		}
+		if observation.Collected.IsZero() {
+			errs = append(errs, fmt.Errorf("observation %q for check %q has no collected timestamp", observation.Title, observation.CheckID))
+		}
+		for _, subject := range observation.Subjects {
+			if !slices.Contains(validSubjectTypes, subject.Type) {
+				errs = append(errs, fmt.Errorf("subject %q for check %q has invalid type %q", subject.ResourceID, observation.CheckID, subject.Type))
+			}
+			if subject.Result == policy.ResultInvalid || subject.Result > policy.ResultManual {
+				errs = append(errs, fmt.Errorf("subject %q for check %q has invalid result %d", subject.ResourceID, observation.CheckID, subject.Result))
+			}
+			if subject.Severity > policy.SeverityCritical {
+				errs = append(errs, fmt.Errorf("subject %q for check %q has invalid severity %d", subject.ResourceID, observation.CheckID, subject.Severity))
+			}
+			if subject.EvaluatedOn.IsZero() {
+				errs = append(errs, fmt.Errorf("subject %q for check %q has no evaluated timestamp", subject.ResourceID, observation.CheckID))
+			}
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// ValidateRoundTrip returns an error if the PVPResult is changed by the
+// transformation to and from protobuf. Timestamps are compared in UTC
+// and empty slices are compared as nil.
+func ValidateRoundTrip(result policy.PVPResult) error {
+	want := normalize(result)
+	got := normalize(plugin.NewResultFromProto(plugin.ResultsToProto(result)))
+	if !reflect.DeepEqual(want, got) {
+		return fmt.Errorf("result changed by protobuf round-trip:\nwant: %+v\ngot:  %+v", want, got)
+	}
+	return nil
+}
+
+// normalize returns a copy of the PVPResult with timestamps in UTC
+// and empty slices set to nil.
+func normalize(result policy.PVPResult) policy.PVPResult {
+	normalized := policy.PVPResult{Links: nilIfEmpty(result.Links)}
+	for _, observation := range result.ObservationsByCheck {
+		observation.Collected = observation.Collected.UTC()
+		observation.Methods = nilIfEmpty(observation.Methods)
+		observation.RelevantEvidences = nilIfEmpty(observation.RelevantEvidences)
+		observation.Props = nilIfEmpty(observation.Props)
+		var subjects []policy.Subject
+		for _, subject := range observation.Subjects {
+			subject.EvaluatedOn = subject.EvaluatedOn.UTC()
+			subject.Props = nilIfEmpty(subject.Props)
+			subjects = append(subjects, subject)
+		}
+		observation.Subjects = subjects
+		normalized.ObservationsByCheck = append(normalized.ObservationsByCheck, observation)
+	}
+	return normalized
+}
+
+func nilIfEmpty[T any](s []T) []T {
+	if len(s) == 0 {
+		return nil
+	}
+	return s
+}
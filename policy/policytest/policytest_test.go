@@ -0,0 +1,130 @@
+/*
+ Copyright 2025 The OSCAL Compass Authors
+ SPDX-License-Identifier: Apache-2.0
+*/
+
+package policytest
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/oscal-compass/oscal-sdk-go/extensions"
+	"github.com/stretchr/testify/require"
+
+	"github.com/oscal-compass/compliance-to-policy-go/v2/policy"
+)
+
+var (
+	testTime   = time.Date(2025, 1, 2, 3, 4, 5, 6, time.FixedZone("EST", -5*60*60))
+	testPolicy = policy.Policy{
+		{
+			Rule:   extensions.Rule{ID: "test-rule-1"},
+			Checks: []extensions.Check{{ID: "test-check-1"}},
+		},
+	}
+	testResult = policy.PVPResult{
+		ObservationsByCheck: []policy.ObservationByCheck{
+			{
+				Title:     "test-rule-1",
+				CheckID:   "test-check-1",
+				Methods:   []string{"AUTOMATED"},
+				Collected: testTime,
+				Subjects: []policy.Subject{
+					{
+						Title:       "test-resource",
+						Type:        "resource",
+						ResourceID:  "test-resource",
+						Result:      policy.ResultPass,
+						EvaluatedOn: testTime,
+						Props:       []policy.Property{},
+					},
+				},
+			},
+		},
+	}
+)
+
+func TestRun(t *testing.T) {
+	Run(t, Config{
+		NewProvider: func(t *testing.T) policy.Provider {
+			return &testProvider{result: testResult}
+		},
+		Policy: testPolicy,
+	})
+}
+
+func TestValidateResult(t *testing.T) {
+	require.NoError(t, ValidateResult(testPolicy, testResult))
+
+	invalid := policy.PVPResult{
+		ObservationsByCheck: []policy.ObservationByCheck{
+			{
+				Title:   "test-rule-2",
+				CheckID: "test-check-2",
+				Subjects: []policy.Subject{
+					{ResourceID: "test-resource", Type: "cluster", Severity: policy.Severity(9)},
+				},
+			},
+		},
+	}
+	require.EqualError(t, ValidateResult(testPolicy, invalid), "observation \"test-rule-2\" references check \"test-check-2\" that is not in the policy\n"+
+		"observation \"test-rule-2\" for check \"test-check-2\" has no collected timestamp\n"+
+		"subject \"test-resource\" for check \"test-check-2\" has invalid type \"cluster\"\n"+
+		"subject \"test-resource\" for check \"test-check-2\" has invalid result 0\n"+
+		"subject \"test-resource\" for check \"test-check-2\" has invalid severity 9\n"+
+		"subject \"test-resource\" for check \"test-check-2\" has no evaluated timestamp")
+}
+
+func TestValidateRoundTrip(t *testing.T) {
+	require.NoError(t, ValidateRoundTrip(testResult))
+
+	// Results without a protobuf representation are lost
+	lossy := policy.PVPResult{
+		ObservationsByCheck: []policy.ObservationByCheck{
+			{CheckID: "test-check-1", Subjects: []policy.Subject{{Result: policy.Result(10)}}},
+		},
+	}
+	require.ErrorContains(t, ValidateRoundTrip(lossy), "result changed by protobuf round-trip")
+}
+
+// testProvider returns a static result and stops
+// when cancelled during a progress event.
+type testProvider struct {
+	result policy.PVPResult
+	host   policy.Host
+}
+
+func (p *testProvider) SetHost(host policy.Host) {
+	p.host = host
+}
+
+func (p *testProvider) Configure(context.Context, map[string]string) error {
+	return nil
+}
+
+func (p *testProvider) Generate(ctx context.Context, _ policy.Policy) ([]policy.Artifact, error) {
+	return nil, p.reportProgress(ctx)
+}
+
+func (p *testProvider) GetResults(ctx context.Context, _ policy.Policy) (policy.PVPResult, error) {
+	if err := p.reportProgress(ctx); err != nil {
+		return policy.PVPResult{}, err
+	}
+	return p.result, nil
+}
+
+func (p *testProvider) StreamResults(ctx context.Context, _ policy.Policy, handler policy.ResultHandler) error {
+	if err := p.reportProgress(ctx); err != nil {
+		return err
+	}
+	return handler(p.result)
+}
+
+func (p *testProvider) reportProgress(ctx context.Context) error {
+	if p.host == nil {
+		return nil
+	}
+	return p.host.ReportProgress(ctx, policy.ProgressEvent{Message: "Collecting results"})
+}
@@ -12,9 +12,67 @@ type Provider interface {
 	// Configure send configuration options and selected values to the
 	// plugin.
 	Configure(context.Context, map[string]string) error
-	// Generate policy artifacts for a specific policy engine.
-	Generate(context.Context, Policy) error
+	// Generate policy artifacts for a specific policy engine and
+	// return the generated Artifacts.
+	Generate(context.Context, Policy) ([]Artifact, error)
 	// GetResults from a specific policy engine and transform into
 	// PVPResults.
 	GetResults(context.Context, Policy) (PVPResult, error)
 }
+
+// Describer is an optional interface for a Provider that can report
+// the capabilities of the running plugin.
+type Describer interface {
+	// Describe returns the version, supported checks, supported
+	// parameters and features of the plugin.
+	Describe(context.Context) (ProviderInfo, error)
+}
+
+// Validator is an optional interface for a Provider that can check
+// a Policy before policy artifacts are generated.
+type Validator interface {
+	// Validate the rules in the Policy and return a Diagnostic for
+	// each problem found. A valid Policy returns no diagnostics.
+	Validate(context.Context, Policy) ([]Diagnostic, error)
+}
+
+// CheckLister is an optional interface for a Provider that can list
+// the checks it can evaluate.
+type CheckLister interface {
+	// ListChecks returns the checks of the plugin grouped by rule
+	// with the descriptions and parameters for each rule.
+	ListChecks(context.Context) (Policy, error)
+}
+
+// ResultHandler processes a partial PVPResult sent by a ResultStreamer.
+type ResultHandler func(PVPResult) error
+
+// ResultStreamer is an optional interface for a Provider that can send
+// results incrementally instead of as a single PVPResult.
+type ResultStreamer interface {
+	// StreamResults from a specific policy engine and send each partial
+	// PVPResult to the ResultHandler as it becomes available.
+	StreamResults(context.Context, Policy, ResultHandler) error
+}
+
+// Host provides services of the C2P host to a Provider. Hosts own the placement
+// of evidence and the working directory, so providers do not need to write to
+// their own output locations.
+type Host interface {
+	// StoreEvidence stores the evidence with the host and returns a Link
+	// to the stored evidence for ObservationByCheck.RelevantEvidences.
+	StoreEvidence(context.Context, Evidence) (Link, error)
+	// ReportProgress sends a progress event to the host.
+	ReportProgress(context.Context, ProgressEvent) error
+	// ResolvePath returns the absolute path on the host for a path
+	// relative to the host working directory.
+	ResolvePath(context.Context, string) (string, error)
+}
+
+// HostConsumer is an optional interface for a Provider that uses
+// the services of the C2P host.
+type HostConsumer interface {
+	// SetHost is called with the Host before the Provider is configured.
+	// It is not called if the host does not provide services.
+	SetHost(Host)
+}
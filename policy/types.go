@@ -6,6 +6,10 @@
 package policy
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strings"
 	"time"
 
 	"github.com/oscal-compass/oscal-sdk-go/extensions"
@@ -20,6 +24,12 @@ const (
 	ResultError
 	ResultPass
 	ResultWarning
+	// ResultNotApplicable is used when the check does not apply to the subject.
+	ResultNotApplicable
+	// ResultSkipped is used when the check was not evaluated for the subject.
+	ResultSkipped
+	// ResultManual is used when the subject must be assessed manually.
+	ResultManual
 )
 
 // String prints a string representation of the Result.
@@ -35,56 +45,330 @@ func (r Result) String() string {
 		return "pass"
 	case ResultWarning:
 		return "warning"
+	case ResultNotApplicable:
+		return "not-applicable"
+	case ResultSkipped:
+		return "skipped"
+	case ResultManual:
+		return "manual"
 	default:
 		panic("invalid result")
 	}
 }
 
+// MarshalText encodes the Result by its string representation.
+func (r Result) MarshalText() ([]byte, error) {
+	if r > ResultManual {
+		return nil, fmt.Errorf("invalid result %d", r)
+	}
+	return []byte(r.String()), nil
+}
+
+// UnmarshalText decodes the Result from its string representation.
+func (r *Result) UnmarshalText(text []byte) error {
+	for result := ResultInvalid; result <= ResultManual; result++ {
+		if result.String() == string(text) {
+			*r = result
+			return nil
+		}
+	}
+	return fmt.Errorf("unknown result %q", text)
+}
+
+// Severity represents the severity of a check or of the
+// outcome of a check on a subject.
+type Severity uint
+
+const (
+	SeverityUnspecified Severity = iota
+	SeverityInfo
+	SeverityLow
+	SeverityMedium
+	SeverityHigh
+	SeverityCritical
+)
+
+// String prints a string representation of the Severity.
+func (s Severity) String() string {
+	switch s {
+	case SeverityUnspecified:
+		return "unspecified"
+	case SeverityInfo:
+		return "info"
+	case SeverityLow:
+		return "low"
+	case SeverityMedium:
+		return "medium"
+	case SeverityHigh:
+		return "high"
+	case SeverityCritical:
+		return "critical"
+	default:
+		panic("invalid severity")
+	}
+}
+
+// ParseSeverity returns the Severity for the case-insensitive string
+// representation or SeverityUnspecified if it is unknown.
+func ParseSeverity(value string) Severity {
+	switch strings.ToLower(strings.TrimSpace(value)) {
+	case "info", "informational":
+		return SeverityInfo
+	case "low":
+		return SeverityLow
+	case "medium":
+		return SeverityMedium
+	case "high":
+		return SeverityHigh
+	case "critical":
+		return SeverityCritical
+	default:
+		return SeverityUnspecified
+	}
+}
+
+// MarshalText encodes the Severity by its string representation.
+func (s Severity) MarshalText() ([]byte, error) {
+	if s > SeverityCritical {
+		return nil, fmt.Errorf("invalid severity %d", s)
+	}
+	return []byte(s.String()), nil
+}
+
+// UnmarshalText decodes the Severity from its string representation.
+func (s *Severity) UnmarshalText(text []byte) error {
+	severity := ParseSeverity(string(text))
+	if severity == SeverityUnspecified && string(text) != SeverityUnspecified.String() {
+		return fmt.Errorf("unknown severity %q", text)
+	}
+	*s = severity
+	return nil
+}
+
 // Property define an instance of a key/value that can be
 // converted to an OSCAL Property type.
 type Property struct {
-	Name  string
-	Value string
+	Name  string `json:"name"`
+	Value string `json:"value"`
 }
 
 // Link represents a link to an external artifact
 // that can be converted an OSCAL Link type.
 type Link struct {
-	Description string
-	Href        string
+	Description string `json:"description,omitempty"`
+	Href        string `json:"href"`
 }
 
 // Subject represents a specific resource that is evaluated
 // by the policy and policy outcome information. This can be converted to
 // an OSCAL Subject type.
 type Subject struct {
-	Title       string
-	Type        string
-	ResourceID  string
-	Result      Result
-	EvaluatedOn time.Time
-	Reason      string
-	Props       []Property
+	Title       string     `json:"title"`
+	Type        string     `json:"type"`
+	ResourceID  string     `json:"resourceId"`
+	Result      Result     `json:"result"`
+	EvaluatedOn time.Time  `json:"evaluatedOn"`
+	Reason      string     `json:"reason,omitempty"`
+	Props       []Property `json:"props,omitempty"`
+	// Severity is the severity of the result for the subject
+	// and overrides the severity of the observation for it.
+	Severity Severity `json:"severity,omitempty"`
+	// Remediation is the guidance to fix the subject.
+	Remediation string `json:"remediation,omitempty"`
 }
 
 // ObservationByCheck represents the assessment outcome and all associated data
 // from a single Check on applicable subjects.
 type ObservationByCheck struct {
-	Title             string
-	Description       string
-	CheckID           string
-	Methods           []string
-	Subjects          []Subject
-	Collected         time.Time
-	RelevantEvidences []Link
-	Props             []Property
+	Title             string     `json:"title"`
+	Description       string     `json:"description,omitempty"`
+	CheckID           string     `json:"checkId"`
+	Methods           []string   `json:"methods,omitempty"`
+	Subjects          []Subject  `json:"subjects,omitempty"`
+	Collected         time.Time  `json:"collected"`
+	RelevantEvidences []Link     `json:"relevantEvidences,omitempty"`
+	Props             []Property `json:"props,omitempty"`
+	// Severity is the severity of the check. It applies to the
+	// subjects without a Severity.
+	Severity Severity `json:"severity,omitempty"`
+	// Remediation is the guidance to fix the subjects that
+	// do not pass the check.
+	Remediation string `json:"remediation,omitempty"`
+	// Evidence is the raw evidence content, e.g. a policy report, that is
+	// embedded in the assessment results. Unlike RelevantEvidences, it does
+	// not depend on files that may not exist after the plugin exits.
+	Evidence []Evidence `json:"evidence,omitempty"`
 }
 
 // PVPResult represent a set of policy results generated by a PVP.
+//
+// PVPResults can be saved and loaded with encoding/json. Results and
+// severities are serialized by their string representation.
 type PVPResult struct {
-	ObservationsByCheck []ObservationByCheck
-	Links               []Link
+	ObservationsByCheck []ObservationByCheck `json:"observations"`
+	Links               []Link               `json:"links,omitempty"`
+}
+
+// Evidence is content collected by a Provider that is stored
+// with the Host or attached to an ObservationByCheck.
+type Evidence struct {
+	// Name is the file name of the evidence. It must not
+	// contain path separators.
+	Name string `json:"name"`
+	// Description is the human-readable description of the evidence.
+	Description string `json:"description,omitempty"`
+	// MediaType is the media type of the evidence content.
+	MediaType string `json:"mediaType,omitempty"`
+	// Content is the evidence content.
+	Content []byte `json:"content"`
+}
+
+// ProgressEvent reports the progress of a Provider operation
+// to the Host.
+type ProgressEvent struct {
+	// Message is the human-readable progress message.
+	Message string
+	// Current is the number of completed steps.
+	Current int64
+	// Total is the number of steps or 0 if unknown.
+	Total int64
+}
+
+// DiagnosticType represents the kind of problem found in a Policy.
+type DiagnosticType uint
+
+const (
+	DiagnosticInvalid DiagnosticType = iota
+	DiagnosticUnknownCheck
+	DiagnosticMissingParameter
+	DiagnosticUnsupportedParameterValue
+)
+
+// String prints a string representation of the DiagnosticType.
+func (d DiagnosticType) String() string {
+	switch d {
+	case DiagnosticInvalid:
+		return "INVALID"
+	case DiagnosticUnknownCheck:
+		return "unknown-check"
+	case DiagnosticMissingParameter:
+		return "missing-parameter"
+	case DiagnosticUnsupportedParameterValue:
+		return "unsupported-parameter-value"
+	default:
+		panic("invalid diagnostic type")
+	}
+}
+
+// Diagnostic describes a single problem found by a Provider in a
+// rule of a Policy.
+type Diagnostic struct {
+	// RuleID is the rule with the problem.
+	RuleID string
+	// CheckID is the check with the problem, if any.
+	CheckID string
+	// Parameter is the parameter with the problem, if any.
+	Parameter string
+	// Type is the kind of problem.
+	Type DiagnosticType
+	// Message is the human-readable description of the problem.
+	Message string
+}
+
+// Feature represents an optional capability implemented
+// by a Provider.
+type Feature string
+
+const (
+	// FeatureGenerate indicates the Provider can generate policy artifacts.
+	FeatureGenerate Feature = "generate"
+	// FeatureStreaming indicates the Provider can stream results.
+	FeatureStreaming Feature = "streaming"
+	// FeatureValidate indicates the Provider can validate a Policy
+	// before generating policy artifacts.
+	FeatureValidate Feature = "validate"
+	// FeatureListChecks indicates the Provider can list the checks
+	// it can evaluate.
+	FeatureListChecks Feature = "list-checks"
+)
+
+// ProviderInfo describes the capabilities of a running Provider.
+type ProviderInfo struct {
+	// Version is the version of the running plugin.
+	Version string
+	// SupportedChecks are the check identifiers the plugin can evaluate.
+	SupportedChecks []string
+	// SupportedParameters are the parameter identifiers the plugin accepts.
+	SupportedParameters []string
+	// Features are the optional capabilities implemented by the plugin.
+	Features []Feature
+	// Types are the plugin types implemented by the plugin, e.g. pvp.
+	Types []string
+	// ConfigurationOptions are the configuration option names the plugin accepts.
+	ConfigurationOptions []string
+}
+
+// HasFeature returns whether the given Feature is implemented.
+func (i ProviderInfo) HasFeature(feature Feature) bool {
+	for _, f := range i.Features {
+		if f == feature {
+			return true
+		}
+	}
+	return false
 }
 
 // Policy represents a list of RuleSets.
 type Policy []extensions.RuleSet
+
+// CheckIDs returns the IDs of the checks in the Policy.
+func (p Policy) CheckIDs() []string {
+	var ids []string
+	for _, ruleSet := range p {
+		for _, check := range ruleSet.Checks {
+			ids = append(ids, check.ID)
+		}
+	}
+	return ids
+}
+
+// ParameterIDs returns the IDs of the rule parameters in the Policy.
+func (p Policy) ParameterIDs() []string {
+	var ids []string
+	for _, ruleSet := range p {
+		for _, parameter := range ruleSet.Rule.Parameters {
+			ids = append(ids, parameter.ID)
+		}
+	}
+	return ids
+}
+
+// Artifact describes a single policy artifact produced by a Provider.
+type Artifact struct {
+	// Path is the location of the artifact relative to the output directory.
+	Path string
+	// MediaType is the media type of the artifact content.
+	MediaType string
+	// Checksum is the hex-encoded SHA-256 digest of the artifact content.
+	Checksum string
+	// RuleIDs are the rules the artifact was generated from.
+	RuleIDs []string
+	// CheckIDs are the checks the artifact was generated from.
+	CheckIDs []string
+	// Content is the optional inline content of the artifact. Over gRPC,
+	// the content is dropped once the total content of the artifacts
+	// exceeds plugin.MaxInlineArtifactSize.
+	Content []byte
+}
+
+// NewArtifact returns an Artifact with inline content and the checksum
+// calculated from the content.
+func NewArtifact(path, mediaType string, content []byte) Artifact {
+	digest := sha256.Sum256(content)
+	return Artifact{
+		Path:      path,
+		MediaType: mediaType,
+		Checksum:  hex.EncodeToString(digest[:]),
+		Content:   content,
+	}
+}